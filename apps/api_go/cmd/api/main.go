@@ -33,20 +33,38 @@ import (
 
 	"api_go/internal/config"
 	"api_go/internal/database"
+	"api_go/internal/domain"
+	"api_go/internal/markdown"
 	account_controller "api_go/internal/modules/account/controller"
 	account_repo "api_go/internal/modules/account/repo"
 	account_service "api_go/internal/modules/account/service"
 	auth_controller "api_go/internal/modules/auth/controller"
+	auth_keys "api_go/internal/modules/auth/keys"
+	auth_middleware "api_go/internal/modules/auth/middleware"
+	auth_provider "api_go/internal/modules/auth/provider"
+	auth_repo "api_go/internal/modules/auth/repo"
 	auth_service "api_go/internal/modules/auth/service"
+	author_controller "api_go/internal/modules/author/controller"
+	author_service "api_go/internal/modules/author/service"
+	"api_go/internal/modules/blobstore"
 	comment_controller "api_go/internal/modules/comment/controller"
 	comment_repo "api_go/internal/modules/comment/repo"
 	comment_service "api_go/internal/modules/comment/service"
+	"api_go/internal/modules/mailer"
+	media_controller "api_go/internal/modules/media/controller"
+	media_service "api_go/internal/modules/media/service"
+	series_controller "api_go/internal/modules/series/controller"
+	series_repo "api_go/internal/modules/series/repo"
+	series_service "api_go/internal/modules/series/service"
 	tag_controller "api_go/internal/modules/tag/controller"
 	tag_repo "api_go/internal/modules/tag/repo"
 	tag_service "api_go/internal/modules/tag/service"
 	tutorial_controller "api_go/internal/modules/tutorial/controller"
 	tutorial_repo "api_go/internal/modules/tutorial/repo"
 	tutorial_service "api_go/internal/modules/tutorial/service"
+	tutorial_tag_controller "api_go/internal/modules/tutorial_tag/controller"
+	tutorial_tag_repo "api_go/internal/modules/tutorial_tag/repo"
+	tutorial_tag_service "api_go/internal/modules/tutorial_tag/service"
 	video_controller "api_go/internal/modules/video/controller"
 	video_repo "api_go/internal/modules/video/repo"
 	video_service "api_go/internal/modules/video/service"
@@ -89,26 +107,59 @@ func gracefulShutdown(apiServer *http.Server, done chan bool) {
 
 // AppModules holds all controllers/services for DI
 type AppModules struct {
-	AccountController  *account_controller.AccountController
-	AuthController     *auth_controller.AuthController
-	TagController      *tag_controller.TagController
-	TutorialController *tutorial_controller.TutorialController
-	VideoController    *video_controller.VideoController
-	VideoTagController *video_tag_controller.VideoTagController
-	CommentController  *comment_controller.CommentController
-	VoteController     *vote_controller.VoteController
+	RoutePolicy           *auth_middleware.RoutePolicy
+	AccountController     *account_controller.AccountController
+	AuthController        *auth_controller.AuthController
+	TagController         *tag_controller.TagController
+	TutorialController    *tutorial_controller.TutorialController
+	TutorialTagController *tutorial_tag_controller.TutorialTagController
+	SeriesController      *series_controller.SeriesController
+	VideoController       *video_controller.VideoController
+	VideoTagController    *video_tag_controller.VideoTagController
+	CommentController     *comment_controller.CommentController
+	VoteController        *vote_controller.VoteController
+	AuthorController      *author_controller.AuthorController
+	MediaController       *media_controller.MediaController
+	PublishScheduler      *tutorial_service.PublishScheduler
+	ViewCounter           *tutorial_service.ViewCounter
 }
 
 // initModules initializes all dependencies (repo, service, controller)
-func initModules(db *gorm.DB, cfg *config.Config) *AppModules {
+func initModules(db *gorm.DB, cfg *config.Config, keySet *auth_keys.KeySet) *AppModules {
 	// Account module
 	accountRepo := account_repo.NewAccountRepository(db)
-	accountService := account_service.NewAccountService(accountRepo)
+
+	// Media module (uploaded avatars, stored in the BlobStore selected by MEDIA_STORE)
+	blobs := blobstore.NewBlobStore(cfg)
+	avatarService := media_service.NewAvatarService(cfg, accountRepo, blobs)
+	mediaController := media_controller.NewMediaController(cfg, avatarService, blobs)
+
+	// Account service (avatar changes go through the media module to delete replaced uploads,
+	// role changes revoke the account's sessions)
+	sessionRepo := auth_repo.NewSessionRepository(db)
+	accountService := account_service.NewAccountService(cfg, accountRepo, avatarService, sessionRepo)
 	accountController := account_controller.NewAccountController(accountService)
 
 	// Auth module (depends on account)
-	authService := auth_service.NewAuthService(cfg, accountService, accountRepo)
-	authController := auth_controller.NewAuthController(cfg, authService)
+	identityRepo := account_repo.NewIdentityRepository(db)
+	accountTokenRepo := auth_repo.NewAccountTokenRepository(db)
+	recoveryCodeRepo := auth_repo.NewRecoveryCodeRepository(db)
+	personalTokenRepo := auth_repo.NewPersonalTokenRepository(db)
+	mail := mailer.NewMailer(cfg)
+	authService := auth_service.NewAuthService(
+		cfg, keySet, accountService, accountRepo, sessionRepo, identityRepo,
+		accountTokenRepo, recoveryCodeRepo, personalTokenRepo, avatarService, mail,
+	)
+	oauthProviders := auth_provider.NewRegistry(cfg)
+	var loginAttempts domain.LoginAttemptStore
+	if cfg.LoginThrottleStore == "postgres" {
+		loginAttempts = auth_repo.NewLoginAttemptRepository(db)
+	} else {
+		loginAttempts = auth_repo.NewMemoryLoginAttemptStore()
+	}
+	loginThrottle := auth_service.NewLoginThrottle(cfg, loginAttempts)
+	authController := auth_controller.NewAuthController(cfg, authService, oauthProviders, loginThrottle)
+	routePolicy := auth_middleware.NewRoutePolicy(authService)
 
 	// Tag module
 	tagRepo := tag_repo.NewTagRepository(db)
@@ -117,8 +168,22 @@ func initModules(db *gorm.DB, cfg *config.Config) *AppModules {
 
 	// Tutorial module
 	tutorialRepo := tutorial_repo.NewTutorialRepository(db)
-	tutorialService := tutorial_service.NewTutorialService(tutorialRepo)
+	tutorialViewRepo := tutorial_repo.NewTutorialViewRepository(db)
+	viewCounter := tutorial_service.NewViewCounter(
+		tutorialViewRepo, cfg.TutorialViewWindow, cfg.TutorialViewFlushInterval, cfg.TutorialViewMaxViewers,
+	)
+	markdownRenderer := markdown.NewRenderer(cfg.MarkdownCacheSize)
+	seriesRepo := series_repo.NewSeriesRepository(db) // series navigation on the tutorial page
+	tutorialService := tutorial_service.NewTutorialService(
+		tutorialRepo, tutorialViewRepo, seriesRepo, markdownRenderer, viewCounter, accountService,
+	)
 	tutorialController := tutorial_controller.NewTutorialController(tutorialService)
+	publishScheduler := tutorial_service.NewPublishScheduler(tutorialService, cfg.TutorialPublishInterval)
+
+	// TutorialTag module (needs tutorial and tag repos)
+	tutorialTagRepo := tutorial_tag_repo.NewTutorialTagRepository(db)
+	tutorialTagService := tutorial_tag_service.NewTutorialTagService(tutorialTagRepo, tutorialRepo, tagRepo, tutorialService)
+	tutorialTagController := tutorial_tag_controller.NewTutorialTagController(tutorialTagService)
 
 	// VideoTag module (create repo first, service needs video and tag repos)
 	videoTagRepo := video_tag_repo.NewVideoTagRepository(db)
@@ -133,25 +198,42 @@ func initModules(db *gorm.DB, cfg *config.Config) *AppModules {
 	videoService := video_service.NewVideoService(videoRepo, videoTagService, youtubeSvc)
 	videoController := video_controller.NewVideoController(videoService)
 
-	// Comment module
-	commentRepo := comment_repo.NewCommentRepository(db)
-	commentService := comment_service.NewCommentService(commentRepo)
-	commentController := comment_controller.NewCommentController(commentService)
+	// Series module (ordered tutorials and videos, needs both repos)
+	seriesService := series_service.NewSeriesService(seriesRepo, tutorialRepo, videoRepo)
+	seriesController := series_controller.NewSeriesController(seriesService)
 
 	// Vote module
 	voteRepo := vote_repo.NewVoteRepository(db)
 	voteService := vote_service.NewVoteService(voteRepo)
 	voteController := vote_controller.NewVoteController(voteService)
 
+	// Comment module (comment votes are stored as votes, one per account; @mentions resolve through accounts)
+	commentRepo := comment_repo.NewCommentRepository(db)
+	commentService := comment_service.NewCommentService(commentRepo, accountService)
+	commentController := comment_controller.NewCommentController(commentService)
+
+	// Author module (public profiles, aggregates the content modules)
+	authorService := author_service.NewAuthorService(
+		accountRepo, tutorialRepo, videoRepo, commentRepo, voteRepo, tutorialService, videoService,
+	)
+	authorController := author_controller.NewAuthorController(authorService)
+
 	return &AppModules{
-		AccountController:  accountController,
-		AuthController:     authController,
-		TagController:      tagController,
-		TutorialController: tutorialController,
-		VideoController:    videoController,
-		VideoTagController: videoTagController,
-		CommentController:  commentController,
-		VoteController:     voteController,
+		RoutePolicy:           routePolicy,
+		AccountController:     accountController,
+		AuthController:        authController,
+		TagController:         tagController,
+		TutorialController:    tutorialController,
+		TutorialTagController: tutorialTagController,
+		SeriesController:      seriesController,
+		VideoController:       videoController,
+		VideoTagController:    videoTagController,
+		CommentController:     commentController,
+		VoteController:        voteController,
+		AuthorController:      authorController,
+		MediaController:       mediaController,
+		PublishScheduler:      publishScheduler,
+		ViewCounter:           viewCounter,
 	}
 }
 
@@ -159,38 +241,66 @@ func main() {
 	// Load config based on NODE_ENV
 	cfg := config.Load()
 	log.Printf("Starting server in %s mode...", cfg.Env)
+	if err := cfg.Validate(); err != nil {
+		log.Fatalf("invalid config: %v", err)
+	}
+
+	keySet, err := auth_keys.Load(cfg)
+	if err != nil {
+		log.Fatalf("failed to load JWT keys: %v", err)
+	}
 
 	// Khởi tạo database using shared connection
 	db := database.NewGormDB(cfg)
 
 	// Khởi tạo dependencies cho các module
-	modules := initModules(db, cfg)
+	modules := initModules(db, cfg, keySet)
 
 	// Truyền controller vào server.NewServer
 	srv := server.NewServer(
 		cfg,
+		modules.RoutePolicy,
 		modules.AccountController,
 		modules.AuthController,
 		modules.TagController,
 		modules.TutorialController,
+		modules.TutorialTagController,
+		modules.SeriesController,
 		modules.VideoController,
 		modules.VideoTagController,
 		modules.CommentController,
 		modules.VoteController,
+		modules.AuthorController,
+		modules.MediaController,
 	)
 
+	// Background jobs run until the server has shut down
+	schedulerCtx, stopScheduler := context.WithCancel(context.Background())
+
+	// Publish scheduled tutorials
+	go modules.PublishScheduler.Run(schedulerCtx)
+
+	// Flush buffered tutorial views periodically; the final flush runs on shutdown
+	viewsFlushed := make(chan struct{})
+	go func() {
+		modules.ViewCounter.Run(schedulerCtx)
+		close(viewsFlushed)
+	}()
+
 	// Create a done channel to signal when the shutdown is complete
 	done := make(chan bool, 1)
 
 	// Run graceful shutdown in a separate goroutine
 	go gracefulShutdown(srv, done)
 
-	err := srv.ListenAndServe()
+	err = srv.ListenAndServe()
 	if err != nil && err != http.ErrServerClosed {
 		panic(fmt.Sprintf("http server error: %s", err))
 	}
 
 	// Wait for the graceful shutdown to complete
 	<-done
+	stopScheduler()
+	<-viewsFlushed
 	log.Println("Graceful shutdown complete.")
 }
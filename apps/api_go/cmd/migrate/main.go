@@ -7,6 +7,15 @@ import (
 	"api_go/internal/config"
 	"api_go/internal/database"
 	"api_go/internal/domain"
+	"api_go/internal/markdown"
+	account_repo "api_go/internal/modules/account/repo"
+	account_service "api_go/internal/modules/account/service"
+	auth_repo "api_go/internal/modules/auth/repo"
+	"api_go/internal/modules/blobstore"
+	media_service "api_go/internal/modules/media/service"
+	series_repo "api_go/internal/modules/series/repo"
+	tutorial_repo "api_go/internal/modules/tutorial/repo"
+	tutorial_service "api_go/internal/modules/tutorial/service"
 )
 
 func main() {
@@ -16,13 +25,35 @@ func main() {
 	// Use shared GORM connection
 	db := database.NewGormDB(cfg)
 
+	// Tutorial.Tags goes through the TutorialTag model (tutorial_tags has its own id)
+	if err := db.SetupJoinTable(&domain.Tutorial{}, "Tags", &domain.TutorialTag{}); err != nil {
+		log.Fatalf("join table setup failed: %v", err)
+	}
+
+	// Tables from before the publishing workflow only have is_published; status is derived from it once
+	backfillStatus := db.Migrator().HasTable(&domain.Tutorial{}) &&
+		!db.Migrator().HasColumn(&domain.Tutorial{}, "status")
+
 	// AutoMigrate all entities
 	err := db.AutoMigrate(
 		&domain.Account{},
+		&domain.AccountIdentity{},
+		&domain.Session{},
+		&domain.AccountToken{},
+		&domain.RecoveryCode{},
+		&domain.PersonalAccessToken{},
+		&domain.LoginAttempt{},
 		&domain.Tag{},
 		&domain.Tutorial{},
+		&domain.TutorialRevision{},
+		&domain.TutorialTag{},
+		&domain.TutorialSlugHistory{},
+		&domain.TutorialViewDaily{},
 		&domain.Video{},
 		&domain.VideoTag{},
+		&domain.Series{},
+		&domain.SeriesItem{},
+		&domain.SeriesProgress{},
 		&domain.Comment{},
 		&domain.Vote{},
 	)
@@ -30,5 +61,46 @@ func main() {
 		log.Fatalf("migration failed: %v", err)
 	}
 
+	// Unpublished tutorials become drafts, so is_published already matches status. It stays: the
+	// Nest API still uses it, and the tutorial repo writes it with every status change.
+	if backfillStatus {
+		err := db.Exec("UPDATE tutorials SET status = ? WHERE is_published = false", domain.TutorialStatusDraft).Error
+		if err == nil {
+			err = db.Exec("UPDATE tutorials SET published_at = created_at WHERE status = ? AND published_at IS NULL", domain.TutorialStatusPublished).Error
+		}
+		if err != nil {
+			log.Fatalf("tutorial status backfill failed: %v", err)
+		}
+	}
+
+	// Accounts created before handles existed get one generated from their name
+	accountRepo := account_repo.NewAccountRepository(db)
+	avatarService := media_service.NewAvatarService(cfg, accountRepo, blobstore.NewBlobStore(cfg))
+	accountService := account_service.NewAccountService(cfg, accountRepo, avatarService, auth_repo.NewSessionRepository(db))
+	backfilled, err := accountService.BackfillHandles()
+	if err != nil {
+		log.Fatalf("handle backfill failed: %v", err)
+	}
+	if backfilled > 0 {
+		fmt.Printf("Generated handles for %d accounts\n", backfilled)
+	}
+
+	// Tutorials saved before content statistics existed get their word count, reading time and code languages
+	tutorialService := tutorial_service.NewTutorialService(
+		tutorial_repo.NewTutorialRepository(db),
+		tutorial_repo.NewTutorialViewRepository(db),
+		series_repo.NewSeriesRepository(db),
+		markdown.NewRenderer(0),
+		nil, // views are not counted here
+		nil, // nor are mentions resolved
+	)
+	analyzed, err := tutorialService.BackfillStats()
+	if err != nil {
+		log.Fatalf("tutorial statistics backfill failed: %v", err)
+	}
+	if analyzed > 0 {
+		fmt.Printf("Computed statistics for %d tutorials\n", analyzed)
+	}
+
 	fmt.Println("Migration completed successfully!")
 }
@@ -1,12 +1,20 @@
 package config
 
 import (
+	"errors"
+	"fmt"
+	"net"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	_ "github.com/joho/godotenv/autoload"
 )
 
+// defaultJWTSecret is the development fallback; production refuses to start with it
+const defaultJWTSecret = "secret"
+
 // Config holds all configuration for the application
 type Config struct {
 	Env         string
@@ -22,12 +30,92 @@ type Config struct {
 	DBPassword string
 	DBSchema   string
 
-	// Auth
-	JWTSecret         string
-	HashSaltRounds    int
+	// Auth (tokens are signed with the key file when set, otherwise with HS256 and JWTSecret)
+	JWTSecret               string
+	JWTSigningKeyFile       string // PEM RSA or Ed25519 private key
+	JWTVerificationKeyFiles string // comma separated PEM keys of retired signing keys still accepted
+	AccessTokenTTL          time.Duration
+	RefreshTokenTTL         time.Duration
+	HashSaltRounds          int
+	// AccountStatusCacheTTL bounds how long a ban made on another instance can go unnoticed
+	AccountStatusCacheTTL time.Duration
+	// HandleChangeCooldown is the minimum time between two handle changes by the same user
+	HandleChangeCooldown time.Duration
+	// TutorialPublishInterval is how often scheduled tutorials are checked (0 disables the scheduler)
+	TutorialPublishInterval time.Duration
+	// TutorialViewWindow is how long repeated views by the same reader count once
+	TutorialViewWindow time.Duration
+	// TutorialViewFlushInterval is how often buffered view counts are written
+	TutorialViewFlushInterval time.Duration
+	// TutorialViewMaxViewers caps the viewers remembered for deduplication; beyond it the viewer
+	// counted longest ago is forgotten
+	TutorialViewMaxViewers int
+	// MarkdownCacheSize is how many rendered tutorial contents are kept in memory (0 disables the cache)
+	MarkdownCacheSize int
+
+	// Two-factor authentication
+	MFAIssuer               string // issuer shown in authenticator apps
+	RequireMFAForPrivileged bool   // admin/mod permissions need a session that passed 2FA
+	MFAChallengeTTL         time.Duration
+	MFAChallengeMaxAttempts int // wrong codes before a login challenge is invalidated
+
+	// TrustedProxies lists the IPs or CIDRs of reverse proxies whose X-Forwarded-For is believed
+	// (comma separated); empty trusts none, so the client IP used for throttling is the peer address
+	TrustedProxies []string
+
+	// Login throttling ("memory" or "postgres" store)
+	LoginThrottleStore      string
+	LoginFreeAttempts       int // failures allowed before backoff starts
+	LoginBackoffBase        time.Duration
+	LoginFailureWindow      time.Duration // failures older than this are forgotten
+	LoginLockoutThreshold   int           // failures per email before lockout
+	LoginIPLockoutThreshold int           // failures per IP before lockout
+	LoginLockoutDuration    time.Duration
+
+	// Account emails
+	RequireEmailVerification bool // refuse password login until the email is verified
+	EmailVerificationTTL     time.Duration
+	PasswordResetTTL         time.Duration
+
+	// Mail ("smtp", "file" or "memory")
+	MailDriver   string
+	MailFrom     string
+	MailDir      string // output directory of the file driver
+	SMTPHost     string
+	SMTPPort     int
+	SMTPUser     string
+	SMTPPassword string
+
+	// OAuth providers (a provider is enabled when its client ID is set;
+	// an empty callback URL defaults to {APIBaseURL}/auth/{provider}/callback)
 	GoogleClientID    string
 	GoogleSecret      string
 	GoogleCallbackURL string
+	GitHubClientID    string
+	GitHubSecret      string
+	GitHubCallbackURL string
+	GitLabBaseURL     string
+	GitLabClientID    string
+	GitLabSecret      string
+	GitLabCallbackURL string
+	OIDCName          string // route segment, e.g. "keycloak"
+	OIDCIssuerURL     string // endpoints are discovered from {issuer}/.well-known/openid-configuration
+	OIDCClientID      string
+	OIDCSecret        string
+	OIDCCallbackURL   string
+	OIDCScopes        string // space separated
+
+	// Uploaded media ("local" or "s3" store)
+	MediaStore     string
+	MediaDir       string // root directory of the local store
+	MediaPublicURL string // base URL of stored files; empty serves them from {APIBaseURL}/media
+	AvatarMaxBytes int64
+	S3Endpoint     string // e.g. https://s3.eu-central-1.amazonaws.com or http://localhost:9000 for MinIO
+	S3Region       string
+	S3Bucket       string
+	S3AccessKey    string
+	S3SecretKey    string
+	S3PathStyle    bool // address the bucket as {endpoint}/{bucket} (required by MinIO)
 
 	// External
 	YoutubeAPIKey string
@@ -42,36 +130,108 @@ func Load() *Config {
 		Env: env,
 
 		// Shared
-		JWTSecret:      getEnv("JWT_SECRET", "secret"),
-		HashSaltRounds: getEnvInt("HASH_SALT_ROUNDS", 12),
-		GoogleClientID: getEnv("GOOGLE_CLIENT_ID", ""),
-		GoogleSecret:   getEnv("GOOGLE_CLIENT_SECRET", ""),
-		YoutubeAPIKey:  getEnv("YOUTUBE_API_KEY", ""),
+		JWTSecret:                 getEnv("JWT_SECRET", defaultJWTSecret),
+		JWTSigningKeyFile:         getEnv("JWT_SIGNING_KEY_FILE", ""),
+		JWTVerificationKeyFiles:   getEnv("JWT_VERIFICATION_KEY_FILES", ""),
+		AccessTokenTTL:            getEnvDuration("ACCESS_TOKEN_TTL", 15*time.Minute),
+		RefreshTokenTTL:           getEnvDuration("REFRESH_TOKEN_TTL", 30*24*time.Hour),
+		HashSaltRounds:            getEnvInt("HASH_SALT_ROUNDS", 12),
+		AccountStatusCacheTTL:     getEnvDuration("ACCOUNT_STATUS_CACHE_TTL", 30*time.Second),
+		HandleChangeCooldown:      getEnvDuration("HANDLE_CHANGE_COOLDOWN", 30*24*time.Hour),
+		TutorialPublishInterval:   getEnvDuration("TUTORIAL_PUBLISH_INTERVAL", time.Minute),
+		MarkdownCacheSize:         getEnvInt("MARKDOWN_CACHE_SIZE", 1000),
+		TutorialViewWindow:        getEnvDuration("TUTORIAL_VIEW_WINDOW", 30*time.Minute),
+		TutorialViewFlushInterval: getEnvDuration("TUTORIAL_VIEW_FLUSH_INTERVAL", 30*time.Second),
+		TutorialViewMaxViewers:    getEnvInt("TUTORIAL_VIEW_MAX_VIEWERS", 100000),
+		YoutubeAPIKey:             getEnv("YOUTUBE_API_KEY", ""),
+
+		// Two-factor authentication
+		MFAIssuer:               getEnv("MFA_ISSUER", "Dev Wiki"),
+		RequireMFAForPrivileged: getEnvBool("REQUIRE_2FA_FOR_PRIVILEGED", false),
+		MFAChallengeTTL:         getEnvDuration("MFA_CHALLENGE_TTL", 5*time.Minute),
+		MFAChallengeMaxAttempts: getEnvInt("MFA_CHALLENGE_MAX_ATTEMPTS", 5),
+
+		TrustedProxies: getEnvList("TRUSTED_PROXIES"),
+
+		// Login throttling
+		LoginFreeAttempts:       getEnvInt("LOGIN_FREE_ATTEMPTS", 3),
+		LoginBackoffBase:        getEnvDuration("LOGIN_BACKOFF_BASE", time.Second),
+		LoginFailureWindow:      getEnvDuration("LOGIN_FAILURE_WINDOW", 15*time.Minute),
+		LoginLockoutThreshold:   getEnvInt("LOGIN_LOCKOUT_THRESHOLD", 10),
+		LoginIPLockoutThreshold: getEnvInt("LOGIN_IP_LOCKOUT_THRESHOLD", 50),
+		LoginLockoutDuration:    getEnvDuration("LOGIN_LOCKOUT_DURATION", 15*time.Minute),
+
+		// Account emails
+		RequireEmailVerification: getEnvBool("REQUIRE_EMAIL_VERIFICATION", false),
+		EmailVerificationTTL:     getEnvDuration("EMAIL_VERIFICATION_TTL", 24*time.Hour),
+		PasswordResetTTL:         getEnvDuration("PASSWORD_RESET_TTL", time.Hour),
+
+		// Mail
+		MailFrom:     getEnv("MAIL_FROM", "Dev Wiki <no-reply@devwiki.io>"),
+		MailDir:      getEnv("MAIL_DIR", "tmp/mail"),
+		SMTPHost:     getEnv("SMTP_HOST", ""),
+		SMTPPort:     getEnvInt("SMTP_PORT", 587),
+		SMTPUser:     getEnv("SMTP_USER", ""),
+		SMTPPassword: getEnv("SMTP_PASSWORD", ""),
+
+		// Uploaded media
+		MediaStore:     getEnv("MEDIA_STORE", "local"),
+		MediaDir:       getEnv("MEDIA_DIR", "tmp/media"),
+		MediaPublicURL: getEnv("MEDIA_PUBLIC_URL", ""),
+		AvatarMaxBytes: int64(getEnvInt("AVATAR_MAX_BYTES", 5<<20)),
+		S3Endpoint:     getEnv("S3_ENDPOINT", ""),
+		S3Region:       getEnv("S3_REGION", "us-east-1"),
+		S3Bucket:       getEnv("S3_BUCKET", ""),
+		S3AccessKey:    getEnv("S3_ACCESS_KEY", ""),
+		S3SecretKey:    getEnv("S3_SECRET_KEY", ""),
+		S3PathStyle:    getEnvBool("S3_PATH_STYLE", true),
+
+		// OAuth providers
+		GoogleClientID:    getEnv("GOOGLE_CLIENT_ID", ""),
+		GoogleSecret:      getEnv("GOOGLE_CLIENT_SECRET", ""),
+		GoogleCallbackURL: getEnv("GOOGLE_CALLBACK_URL", ""),
+		GitHubClientID:    getEnv("GITHUB_CLIENT_ID", ""),
+		GitHubSecret:      getEnv("GITHUB_CLIENT_SECRET", ""),
+		GitHubCallbackURL: getEnv("GITHUB_CALLBACK_URL", ""),
+		GitLabBaseURL:     getEnv("GITLAB_BASE_URL", "https://gitlab.com"),
+		GitLabClientID:    getEnv("GITLAB_CLIENT_ID", ""),
+		GitLabSecret:      getEnv("GITLAB_CLIENT_SECRET", ""),
+		GitLabCallbackURL: getEnv("GITLAB_CALLBACK_URL", ""),
+		OIDCName:          getEnv("OIDC_NAME", "oidc"),
+		OIDCIssuerURL:     getEnv("OIDC_ISSUER_URL", ""),
+		OIDCClientID:      getEnv("OIDC_CLIENT_ID", ""),
+		OIDCSecret:        getEnv("OIDC_CLIENT_SECRET", ""),
+		OIDCCallbackURL:   getEnv("OIDC_CALLBACK_URL", ""),
+		OIDCScopes:        getEnv("OIDC_SCOPES", "openid email profile"),
 	}
 
 	if isProd {
 		cfg.Port = getEnvInt("PORT", 3000)
 		cfg.APIBaseURL = getEnv("API_BASE_URL", "")
 		cfg.FrontendURL = getEnv("FRONTEND_URL", "")
-		cfg.GoogleCallbackURL = getEnv("GOOGLE_CALLBACK_URL", "")
 
 		cfg.DBHost = getEnv("DB_HOST", "postgres")
 		cfg.DBPort = getEnv("DB_PORT", "5432")
 		cfg.DBName = getEnv("DB_NAME", "dev_wiki_prod")
 		cfg.DBUser = getEnv("DB_USER", "postgres")
 		cfg.DBPassword = getEnv("DB_PASSWORD", "")
+
+		cfg.MailDriver = getEnv("MAIL_DRIVER", "smtp")
+		cfg.LoginThrottleStore = getEnv("LOGIN_THROTTLE_STORE", "postgres")
 		cfg.DBSchema = getEnv("DB_SCHEMA", "public")
 	} else {
 		cfg.Port = getEnvInt("PORT", 8080)
 		cfg.APIBaseURL = getEnv("API_BASE_URL", "http://localhost:8080")
 		cfg.FrontendURL = getEnv("FRONTEND_URL", "http://localhost:3000")
-		cfg.GoogleCallbackURL = getEnv("GOOGLE_CALLBACK_URL", "http://localhost:8080/google-redirect")
 
 		cfg.DBHost = getEnv("DB_HOST", "127.0.0.1")
 		cfg.DBPort = getEnv("DB_PORT", "5432")
 		cfg.DBName = getEnv("DB_NAME", "dev_wiki_local")
 		cfg.DBUser = getEnv("DB_USER", "postgres")
 		cfg.DBPassword = getEnv("DB_PASSWORD", "postgres")
+
+		cfg.MailDriver = getEnv("MAIL_DRIVER", "file")
+		cfg.LoginThrottleStore = getEnv("LOGIN_THROTTLE_STORE", "memory")
 		cfg.DBSchema = getEnv("DB_SCHEMA", "public")
 	}
 
@@ -83,6 +243,33 @@ func LoadConfig() (*Config, error) {
 	return Load(), nil
 }
 
+// Validate reports settings that are unsafe to run with
+func (c *Config) Validate() error {
+	if c.IsProduction() && (c.JWTSecret == "" || c.JWTSecret == defaultJWTSecret) {
+		return errors.New("JWT_SECRET must be set to a non-default value in production")
+	}
+	if c.MediaStore == "s3" && (c.S3Endpoint == "" || c.S3Bucket == "") {
+		return errors.New("S3_ENDPOINT and S3_BUCKET must be set when MEDIA_STORE is s3")
+	}
+	for _, proxy := range c.TrustedProxies {
+		if net.ParseIP(proxy) == nil {
+			if _, _, err := net.ParseCIDR(proxy); err != nil {
+				return fmt.Errorf("TRUSTED_PROXIES: %q is not an IP or CIDR", proxy)
+			}
+		}
+	}
+	if c.MFAChallengeMaxAttempts <= 0 {
+		return errors.New("MFA_CHALLENGE_MAX_ATTEMPTS must be positive")
+	}
+	if c.TutorialViewFlushInterval <= 0 {
+		return errors.New("TUTORIAL_VIEW_FLUSH_INTERVAL must be positive")
+	}
+	if c.TutorialViewMaxViewers <= 0 {
+		return errors.New("TUTORIAL_VIEW_MAX_VIEWERS must be positive")
+	}
+	return nil
+}
+
 // IsDevelopment returns true if running in development mode
 func (c *Config) IsDevelopment() bool {
 	return c.Env == "development"
@@ -108,3 +295,32 @@ func getEnvInt(key string, fallback int) int {
 	}
 	return fallback
 }
+
+func getEnvBool(key string, fallback bool) bool {
+	if value, exists := os.LookupEnv(key); exists {
+		if boolVal, err := strconv.ParseBool(value); err == nil {
+			return boolVal
+		}
+	}
+	return fallback
+}
+
+// getEnvList splits a comma separated variable, dropping empty entries (nil when unset)
+func getEnvList(key string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	if value, exists := os.LookupEnv(key); exists {
+		if duration, err := time.ParseDuration(value); err == nil {
+			return duration
+		}
+	}
+	return fallback
+}
@@ -1,21 +1,72 @@
 package domain
 
+import "time"
+
 // AccountService interface - business logic layer
 type AccountService interface {
 	Create(dto CreateAccountDTO) (*AccountResponseDTO, error)
 	FindAll() ([]AccountResponseDTO, error)
+	// List returns a filtered, sorted page of accounts (including deleted ones when filtering on that status)
+	List(params AccountListParams) (*AccountListDTO, error)
 	FindOne(id uint) (*AccountResponseDTO, error)
 	FindByEmail(email string) (*AccountResponseDTO, error)
 	Update(id uint, dto UpdateAccountDTO) (*AccountResponseDTO, error)
 	Remove(id uint) (bool, error)
+	// UpdateRole changes another account's role; admins cannot change their own
+	UpdateRole(actorID, id uint, dto UpdateAccountRoleDTO) (*AccountResponseDTO, error)
+	GetProfile(id uint) (*ProfileResponseDTO, error)
+	UpdateProfile(id uint, dto UpdateProfileDTO) (*ProfileResponseDTO, error)
+	// GenerateHandle derives a free handle from a display name, adding a numeric suffix on collision
+	GenerateHandle(name string) (string, error)
+	// BackfillHandles assigns handles to accounts created before handles existed
+	BackfillHandles() (int, error)
+	MentionResolver
+}
+
+// MentionResolver looks up the accounts behind @handle mentions
+type MentionResolver interface {
+	// ResolveMentions returns the mentioned accounts keyed by handle; unknown and invalid handles are left out
+	ResolveMentions(handles []string) (map[string]MentionDTO, error)
 }
 
 // AccountRepository interface - data access layer
 type AccountRepository interface {
 	Create(account *Account) error
 	FindAll() ([]Account, error)
+	// FindPage returns up to limit accounts matching params after the cursor, in params.Sort/params.Order order
+	FindPage(params AccountListParams, after *AccountCursor, limit int) ([]Account, error)
+	// TouchLastLogin records the start of a new session
+	TouchLastLogin(id uint) error
 	FindOne(id uint) (*Account, error)
 	FindByEmail(email string) (*Account, error)
+	// FindByHandle looks up an account by its (lowercase) handle, including soft-deleted accounts that still hold it
+	FindByHandle(handle string) (*Account, error)
+	// FindByHandles returns the existing, non-deleted accounts holding any of the handles
+	FindByHandles(handles []string) ([]Account, error)
+	// FindWithoutHandle returns up to limit accounts that have no handle yet
+	FindWithoutHandle(limit int) ([]Account, error)
+	// UpdateHandle stores a new handle; changedAt is nil when the handle was assigned by the system
+	UpdateHandle(id uint, handle string, changedAt *time.Time) error
 	Update(id uint, update *Account) error
+	// UpdatePassword stores a new password hash and clears the passwordless flag
+	UpdatePassword(id uint, passwordHash string) error
+	// MarkEmailVerified records that the account email has been verified
+	MarkEmailVerified(id uint) error
+	// SetTOTP stores the TOTP secret and enabled time (nil keeps the secret pending); an empty secret disables 2FA
+	SetTOTP(id uint, secret string, enabledAt *time.Time) error
+	// AdvanceTOTPCounter records an accepted time step; returns false if it is not newer than the last one
+	AdvanceTOTPCounter(id uint, counter int64) (bool, error)
+	// MarkPasswordless clears the password hash so the account can only use linked identities
+	MarkPasswordless(id uint) error
+	// SetStatus changes the account status and ban details (reason and until are cleared when not banned)
+	SetStatus(id uint, status AccountStatus, banReason string, bannedUntil *time.Time) error
+	UpdateRole(id uint, role string) error
+	// UpdateProfile writes the profile changes in one transaction and returns the blob key of an
+	// uploaded avatar the change replaced; a handle held by another account fails with "handle is already taken"
+	UpdateProfile(id uint, update ProfileUpdate) (string, error)
+	// SetAvatar stores the avatar URL and the blob key prefix of an uploaded avatar (empty for external URLs)
+	SetAvatar(id uint, avatarURL *string, avatarKey string) error
+	// Anonymize scrubs personal data, marks the account deleted and soft-deletes it
+	Anonymize(id uint) error
 	Delete(id uint) error
 }
@@ -1,11 +1,52 @@
 package domain
 
+import (
+	"encoding/json"
+	"time"
+)
+
 type AccountResponseDTO struct {
-	ID        uint    `json:"id"`
-	Email     string  `json:"email"`
-	Name      string  `json:"name"`
-	Role      string  `json:"role,omitempty"`
-	AvatarURL *string `json:"avatar_url,omitempty"`
+	ID            uint    `json:"id"`
+	Email         string  `json:"email"`
+	Name          string  `json:"name"`
+	Handle        string  `json:"handle"`
+	Role          string  `json:"role,omitempty"`
+	AvatarURL     *string `json:"avatar_url,omitempty"`
+	EmailVerified bool    `json:"email_verified"`
+	Status        string  `json:"status,omitempty"`
+	// Ban details, present while the account is banned
+	BanReason   string     `json:"ban_reason,omitempty"`
+	BannedUntil *time.Time `json:"banned_until,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	LastLoginAt *time.Time `json:"last_login_at"`
+}
+
+// AccountListParams for GET /admin/accounts (all filters are optional)
+type AccountListParams struct {
+	Role     string `form:"role" binding:"omitempty,oneof=user mod admin"`
+	Status   string `form:"status" binding:"omitempty,oneof=active banned deleted"`
+	Provider string `form:"provider" binding:"omitempty,max=50"` // linked OAuth provider, or "password"
+	// CreatedFrom and CreatedTo bound the creation time (RFC 3339, from inclusive, to exclusive)
+	CreatedFrom *time.Time `form:"created_from" time_format:"2006-01-02T15:04:05Z07:00"`
+	CreatedTo   *time.Time `form:"created_to" time_format:"2006-01-02T15:04:05Z07:00"`
+	Q           string     `form:"q" binding:"max=100"` // prefix of the email, name or handle
+	Sort        string     `form:"sort" binding:"omitempty,oneof=created_at last_login_at email name"`
+	Order       string     `form:"order" binding:"omitempty,oneof=asc desc"`
+	Limit       int        `form:"limit"`
+	Cursor      string     `form:"cursor"` // nextCursor of the previous page (only valid with the same sort and order)
+}
+
+// AccountCursor is the keyset position after the last account of a page.
+// Value holds the sort column of that account: a time.Time for time sorts, a string otherwise.
+type AccountCursor struct {
+	Value interface{}
+	ID    uint
+}
+
+// AccountListDTO is a page of accounts for the admin console
+type AccountListDTO struct {
+	Items      []AccountResponseDTO `json:"items"`
+	NextCursor *string              `json:"nextCursor"`
 }
 
 type CreateAccountDTO struct {
@@ -15,10 +56,68 @@ type CreateAccountDTO struct {
 	Role     string `json:"role"`
 }
 
+// UpdateAccountDTO for admin edits of an account (roles change through UpdateAccountRoleDTO)
 type UpdateAccountDTO struct {
 	Email     *string `json:"email,omitempty"`
 	Name      *string `json:"name,omitempty"`
 	Password  *string `json:"password,omitempty"`
-	Role      *string `json:"role,omitempty"`
 	AvatarURL *string `json:"avatar_url,omitempty"`
 }
+
+// UpdateAccountRoleDTO for PATCH /accounts/:id/role
+type UpdateAccountRoleDTO struct {
+	Role string `json:"role" binding:"required,oneof=user mod admin"`
+}
+
+// ProfileLinkDTO is a link shown on a profile (website, GitHub, ...)
+type ProfileLinkDTO struct {
+	Label string `json:"label" binding:"required,max=50"`
+	URL   string `json:"url" binding:"required,url,max=500"`
+}
+
+// ProfileResponseDTO for GET /me/profile
+type ProfileResponseDTO struct {
+	ID               uint             `json:"id"`
+	Email            string           `json:"email"`
+	Name             string           `json:"name"`
+	Handle           string           `json:"handle"`
+	AvatarURL        *string          `json:"avatar_url,omitempty"`
+	Bio              string           `json:"bio"`
+	Links            []ProfileLinkDTO `json:"links"`
+	Role             string           `json:"role"`
+	EmailVerified    bool             `json:"email_verified"`
+	HasPassword      bool             `json:"has_password"`
+	TwoFactorEnabled bool             `json:"two_factor_enabled"`
+	CreatedAt        time.Time        `json:"created_at"`
+	// HandleChangeableAt is when the handle may be changed again (omitted when it can be changed now)
+	HandleChangeableAt *time.Time `json:"handle_changeable_at,omitempty"`
+}
+
+// ProfileUpdate holds the profile columns AccountRepository.UpdateProfile writes (empty values clear them)
+type ProfileUpdate struct {
+	Name  string
+	Bio   string
+	Links json.RawMessage
+	// Handle is written only when HandleChangedAt is set
+	Handle          string
+	HandleChangedAt *time.Time
+	// AvatarURL replaces the avatar, forgetting any uploaded one, only when SetAvatar is true
+	SetAvatar bool
+	AvatarURL *string
+}
+
+// UpdateProfileDTO for PATCH /me/profile (omitted fields are unchanged)
+type UpdateProfileDTO struct {
+	Name      *string           `json:"name,omitempty" binding:"omitempty,min=1,max=100"`
+	Handle    *string           `json:"handle,omitempty" binding:"omitempty,min=3,max=30"` // limited to one change per cooldown
+	AvatarURL *string           `json:"avatar_url,omitempty" binding:"omitempty,max=500"`  // empty string removes the avatar
+	Bio       *string           `json:"bio,omitempty" binding:"omitempty,max=1000"`
+	Links     *[]ProfileLinkDTO `json:"links,omitempty" binding:"omitempty,max=10,dive"`
+}
+
+// MentionDTO is an account mentioned as @handle in a comment or tutorial; clients link it to /users/{handle}
+type MentionDTO struct {
+	Handle    string `json:"handle"`
+	AccountID uint   `json:"accountId"`
+	Name      string `json:"name"`
+}
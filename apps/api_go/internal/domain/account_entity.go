@@ -1,6 +1,11 @@
 package domain
 
-import "gorm.io/gorm"
+import (
+	"encoding/json"
+	"time"
+
+	"gorm.io/gorm"
+)
 
 type Account struct {
 	gorm.Model
@@ -8,8 +13,54 @@ type Account struct {
 	Name      string  `gorm:"column:name"`
 	Password  string  `gorm:"column:password"`
 	AvatarURL *string `gorm:"column:avatar_url"`
+	AvatarKey string  `gorm:"column:avatar_key"` // blob key prefix when the avatar was uploaded here
 	Role      string  `gorm:"column:role"`
 	Status    string  `gorm:"column:status"`
+	// Handle is the unique public username (stored lowercase) used in profile URLs and @mentions
+	Handle          string     `gorm:"column:handle;uniqueIndex"`
+	HandleChangedAt *time.Time `gorm:"column:handle_changed_at"` // last change made by the user
+	// Public profile
+	Bio   string          `gorm:"column:bio;type:text"`
+	Links json.RawMessage `gorm:"column:links;type:jsonb"` // []ProfileLinkDTO
+	// Ban details while Status is "banned"; a nil BannedUntil is a permanent ban
+	BanReason   string     `gorm:"column:ban_reason"`
+	BannedUntil *time.Time `gorm:"column:banned_until"`
+	// LastLoginAt is the start of the most recent session (refreshes do not count)
+	LastLoginAt *time.Time `gorm:"column:last_login_at;index"`
+	// EmailVerifiedAt is set once the user confirms the address (or a provider vouches for it)
+	EmailVerifiedAt *time.Time `gorm:"column:email_verified_at"`
+	// Passwordless accounts have no usable password and sign in only through linked identities
+	Passwordless bool `gorm:"column:passwordless;not null;default:false"`
+	// TOTP two-factor authentication: the secret is pending until TOTPEnabledAt is set
+	TOTPSecret      string     `gorm:"column:totp_secret"`
+	TOTPEnabledAt   *time.Time `gorm:"column:totp_enabled_at"`
+	TOTPLastCounter int64      `gorm:"column:totp_last_counter;not null;default:0"` // last accepted time step (replay protection)
+}
+
+// TwoFactorEnabled reports whether the account requires a second factor at login
+func (a *Account) TwoFactorEnabled() bool {
+	return a.TOTPEnabledAt != nil && a.TOTPSecret != ""
+}
+
+// ProfileLinks decodes the links stored on the profile
+func (a *Account) ProfileLinks() ([]ProfileLinkDTO, error) {
+	links := []ProfileLinkDTO{}
+	if len(a.Links) == 0 || string(a.Links) == "null" {
+		return links, nil
+	}
+	if err := json.Unmarshal(a.Links, &links); err != nil {
+		return nil, err
+	}
+	return links, nil
+}
+
+// StatusAt returns the effective status: a temporary ban that has run out counts as active
+func (a *Account) StatusAt(now time.Time) AccountStatus {
+	status := AccountStatus(a.Status)
+	if status == AccountStatusBanned && a.BannedUntil != nil && !now.Before(*a.BannedUntil) {
+		return AccountStatusActive
+	}
+	return status
 }
 
 func (Account) TableName() string {
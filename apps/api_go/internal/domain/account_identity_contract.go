@@ -0,0 +1,11 @@
+package domain
+
+// AccountIdentityRepository interface - data access layer for linked OAuth identities
+type AccountIdentityRepository interface {
+	Create(identity *AccountIdentity) error
+	FindByProviderSubject(provider, subject string) (*AccountIdentity, error)
+	FindByAccount(accountID uint) ([]AccountIdentity, error)
+	FindByAccountAndProvider(accountID uint, provider string) (*AccountIdentity, error)
+	Delete(id uint) error
+	DeleteForAccount(accountID uint) error
+}
@@ -0,0 +1,16 @@
+package domain
+
+import "time"
+
+// IdentityResponseDTO for a linked OAuth identity
+type IdentityResponseDTO struct {
+	Provider  string    `json:"provider"`
+	Email     string    `json:"email,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// IdentitiesResponseDTO for GET /me/identities
+type IdentitiesResponseDTO struct {
+	Passwordless bool                  `json:"passwordless"` // true when the account can only sign in through linked identities
+	Identities   []IdentityResponseDTO `json:"identities"`
+}
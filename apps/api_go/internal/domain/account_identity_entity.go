@@ -0,0 +1,19 @@
+package domain
+
+import "time"
+
+// AccountIdentity entity - maps to 'account_identities' table
+// Links an account to a user at an external OAuth provider; an account has at most one identity per provider.
+type AccountIdentity struct {
+	ID        uint      `gorm:"primaryKey"`
+	AccountID uint      `gorm:"column:account_id;not null;uniqueIndex:idx_account_identities_account_provider"`
+	Account   *Account  `gorm:"foreignKey:AccountID;constraint:OnDelete:CASCADE"`
+	Provider  string    `gorm:"column:provider;type:varchar(50);not null;uniqueIndex:idx_account_identities_provider_subject;uniqueIndex:idx_account_identities_account_provider"`
+	Subject   string    `gorm:"column:subject;type:varchar(255);not null;uniqueIndex:idx_account_identities_provider_subject"`
+	Email     string    `gorm:"column:email"`
+	CreatedAt time.Time `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (AccountIdentity) TableName() string {
+	return "account_identities"
+}
@@ -7,3 +7,8 @@ const (
 	AccountRoleAdmin AccountRole = "admin"
 	AccountRoleMod   AccountRole = "mod"
 )
+
+// IsPrivileged reports whether the role can manage other users' content
+func (r AccountRole) IsPrivileged() bool {
+	return r == AccountRoleAdmin || r == AccountRoleMod
+}
@@ -1,5 +1,7 @@
 package domain
 
+import "time"
+
 type AccountStatus string
 
 const (
@@ -7,3 +9,13 @@ const (
 	AccountStatusDeleted AccountStatus = "deleted"
 	AccountStatusBanned  AccountStatus = "banned"
 )
+
+// AccountBannedError is returned when a banned account tries to sign in or use a token
+type AccountBannedError struct {
+	Reason string
+	Until  *time.Time // nil for a permanent ban
+}
+
+func (e *AccountBannedError) Error() string {
+	return "account is banned"
+}
@@ -0,0 +1,13 @@
+package domain
+
+// AccountTokenRepository interface - persists email verification, password reset and MFA challenge tokens
+type AccountTokenRepository interface {
+	Create(token *AccountToken) error
+	FindByTokenHash(tokenHash string) (*AccountToken, error)
+	// MarkUsed consumes a token; returns false if it was already used
+	MarkUsed(id uint) (bool, error)
+	// RecordAttempt increments the failed attempt counter and returns the new value
+	RecordAttempt(id uint) (int, error)
+	// InvalidateForAccount consumes every outstanding token of a purpose
+	InvalidateForAccount(accountID uint, purpose AccountTokenPurpose) error
+}
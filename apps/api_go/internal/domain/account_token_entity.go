@@ -0,0 +1,30 @@
+package domain
+
+import "time"
+
+// AccountTokenPurpose identifies what a single-use account token is for
+type AccountTokenPurpose string
+
+const (
+	AccountTokenVerifyEmail   AccountTokenPurpose = "verify_email"
+	AccountTokenPasswordReset AccountTokenPurpose = "password_reset"
+	AccountTokenMFAChallenge  AccountTokenPurpose = "mfa_challenge"
+)
+
+// AccountToken entity - maps to 'account_tokens' table
+// Single-use tokens sent by email or embedded in the MFA login challenge; only the SHA-256 hash is stored.
+type AccountToken struct {
+	ID        uint                `gorm:"primaryKey"`
+	AccountID uint                `gorm:"column:account_id;not null;index"`
+	Account   *Account            `gorm:"foreignKey:AccountID;constraint:OnDelete:CASCADE"`
+	Purpose   AccountTokenPurpose `gorm:"column:purpose;type:varchar(20);not null"`
+	TokenHash string              `gorm:"column:token_hash;type:varchar(64);not null;uniqueIndex"`
+	ExpiresAt time.Time           `gorm:"column:expires_at;not null"`
+	UsedAt    *time.Time          `gorm:"column:used_at"`
+	Attempts  int                 `gorm:"column:attempts;not null;default:0"` // failed codes against an MFA challenge
+	CreatedAt time.Time           `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (AccountToken) TableName() string {
+	return "account_tokens"
+}
@@ -0,0 +1,17 @@
+package domain
+
+// Actor identifies the authenticated account performing a request
+type Actor struct {
+	ID   uint
+	Role AccountRole
+}
+
+// CanModerate reports whether the actor may manage content owned by others
+func (a Actor) CanModerate() bool {
+	return a.Role.IsPrivileged()
+}
+
+// CanModify reports whether the actor owns the resource or may moderate it
+func (a Actor) CanModify(ownerID uint) bool {
+	return (a.ID != 0 && a.ID == ownerID) || a.CanModerate()
+}
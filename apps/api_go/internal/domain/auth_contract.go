@@ -8,8 +8,71 @@ type AuthService interface {
 	// Register creates a new user account
 	Register(dto RegisterDTO) error
 
-	// HandleGoogleLogin handles Google OAuth login (creates user if not exists)
-	HandleGoogleLogin(profile GoogleProfile) (*AuthResponseDTO, error)
+	// HandleOAuthLogin handles OAuth login (creates user if not exists)
+	HandleOAuthLogin(profile OAuthProfile) (*AuthResponseDTO, error)
+
+	// MFAChallengeAccount returns the account ID a login challenge was issued for
+	MFAChallengeAccount(challengeToken string) (uint, error)
+
+	// CompleteMFALogin exchanges a login challenge and a TOTP or recovery code for tokens
+	CompleteMFALogin(challengeToken, code string) (*AuthResponseDTO, error)
+
+	// GetMFAStatus returns the 2FA state of an account
+	GetMFAStatus(accountID uint) (*MFAStatusDTO, error)
+
+	// SetupMFA generates a pending TOTP secret
+	SetupMFA(accountID uint) (*MFASetupResponseDTO, error)
+
+	// EnableMFA confirms the pending secret with a code and returns recovery codes
+	EnableMFA(accountID uint, code string) (*MFARecoveryCodesDTO, error)
+
+	// DisableMFA turns 2FA off after verifying a code
+	DisableMFA(accountID uint, code string) error
+
+	// RegenerateRecoveryCodes replaces the recovery codes after verifying a code
+	RegenerateRecoveryCodes(accountID uint, code string) (*MFARecoveryCodesDTO, error)
+
+	// CreatePersonalToken creates a personal access token; mfa records whether the creating session passed 2FA
+	CreatePersonalToken(accountID uint, mfa bool, dto CreatePersonalTokenDTO) (*CreatedPersonalTokenDTO, error)
+
+	// ListPersonalTokens lists the active personal access tokens of an account
+	ListPersonalTokens(accountID uint) ([]PersonalTokenResponseDTO, error)
+
+	// RevokePersonalToken revokes one of the account's personal access tokens
+	RevokePersonalToken(accountID, tokenID uint) error
+
+	// RequestPasswordReset emails a password reset link (silently does nothing for unknown emails)
+	RequestPasswordReset(email string) error
+
+	// ResetPassword sets a new password with a reset token and revokes all sessions
+	ResetPassword(dto ResetPasswordDTO) error
+
+	// SendVerificationEmail emails a verification link unless the email is already verified
+	SendVerificationEmail(email string) error
+
+	// VerifyEmail consumes an email verification token
+	VerifyEmail(token string) error
+
+	// LinkIdentity links an OAuth identity to an existing account
+	LinkIdentity(accountID uint, profile OAuthProfile) error
+
+	// UnlinkIdentity removes the identity an account has at a provider (never the last login method)
+	UnlinkIdentity(accountID uint, provider string) error
+
+	// ListIdentities lists the identities linked to an account
+	ListIdentities(accountID uint) (*IdentitiesResponseDTO, error)
+
+	// RemovePassword makes an account passwordless (requires a linked identity)
+	RemovePassword(accountID uint) error
+
+	// RefreshSession rotates a refresh token and issues a new access token
+	RefreshSession(refreshToken string) (*AuthResponseDTO, error)
+
+	// Logout revokes the session family of a refresh token
+	Logout(refreshToken string) error
+
+	// RevokeSession revokes a session family by ID
+	RevokeSession(sessionID string) error
 
 	// GenerateJWT generates a JWT token from payload
 	GenerateJWT(payload JWTPayload) (string, error)
@@ -17,6 +80,24 @@ type AuthService interface {
 	// ValidateJWT validates a JWT token and returns the payload
 	ValidateJWT(token string) (*JWTPayload, error)
 
+	// ChangePassword sets a new password after checking the current one; other sessions are revoked
+	ChangePassword(accountID uint, sessionID string, dto ChangePasswordDTO) error
+
+	// DeleteAccount anonymizes and soft-deletes the account, removing its logins and revoking its sessions
+	DeleteAccount(accountID uint, dto DeleteAccountDTO) error
+
+	// BanAccount bans an account (until dto.ExpiresAt, or permanently) and revokes its sessions and tokens
+	BanAccount(actorID, accountID uint, dto BanAccountDTO) error
+
+	// UnbanAccount lifts a ban
+	UnbanAccount(accountID uint) error
+
+	// JWKS returns the public keys that verify access tokens
+	JWKS() JWKSDTO
+
+	// AuthenticateToken validates an access token and checks that its session is still active
+	AuthenticateToken(token string) (*JWTPayload, error)
+
 	// GetUserFromToken extracts user info from JWT token
 	GetUserFromToken(token string) (*AccountResponseDTO, error)
 }
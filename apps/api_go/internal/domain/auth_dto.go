@@ -1,5 +1,7 @@
 package domain
 
+import "time"
+
 // LoginDTO for user login request
 type LoginDTO struct {
 	Email    string `json:"email" binding:"required,email"`
@@ -13,10 +15,65 @@ type RegisterDTO struct {
 	Password string `json:"password" binding:"required,min=8"`
 }
 
-// AuthResponseDTO for login/register response
+// RefreshDTO for token refresh and logout requests (falls back to the refresh_token cookie)
+type RefreshDTO struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// ForgotPasswordDTO for requesting a password reset email
+type ForgotPasswordDTO struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// ResetPasswordDTO for setting a new password with a reset token
+type ResetPasswordDTO struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required,min=8"`
+}
+
+// ResendVerificationDTO for requesting a new verification email
+type ResendVerificationDTO struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// MFALoginDTO for the second login step (TOTP or recovery code)
+type MFALoginDTO struct {
+	ChallengeToken string `json:"challenge_token"` // falls back to the mfa_challenge cookie
+	Code           string `json:"code" binding:"required"`
+}
+
+// MFACodeDTO for 2FA management endpoints that require a current code
+type MFACodeDTO struct {
+	Code string `json:"code" binding:"required"`
+}
+
+// MFASetupResponseDTO for POST /me/2fa/setup
+type MFASetupResponseDTO struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"` // otpauth:// URI to render as a QR code
+}
+
+// MFARecoveryCodesDTO returns freshly generated recovery codes (shown once)
+type MFARecoveryCodesDTO struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// MFAStatusDTO for GET /me/2fa
+type MFAStatusDTO struct {
+	Enabled                bool  `json:"enabled"`
+	Required               bool  `json:"required"` // enforced for the account's role
+	RecoveryCodesRemaining int64 `json:"recovery_codes_remaining"`
+}
+
+// AuthResponseDTO for login/register response.
+// When 2FA is enabled the first login step returns only MFARequired and ChallengeToken.
 type AuthResponseDTO struct {
-	AccessToken string              `json:"access_token"`
-	Account     AuthAccountResponse `json:"account"`
+	AccessToken    string              `json:"access_token,omitempty"`
+	RefreshToken   string              `json:"refresh_token,omitempty"`
+	ExpiresIn      int64               `json:"expires_in,omitempty"` // access token lifetime in seconds
+	MFARequired    bool                `json:"mfa_required,omitempty"`
+	ChallengeToken string              `json:"challenge_token,omitempty"`
+	Account        AuthAccountResponse `json:"account"`
 }
 
 // AuthAccountResponse for authenticated user info
@@ -28,24 +85,81 @@ type AuthAccountResponse struct {
 	AvatarURL *string `json:"avatar_url,omitempty"`
 }
 
+// ChangePasswordDTO for POST /me/password (passwordless accounts omit the current password)
+type ChangePasswordDTO struct {
+	CurrentPassword string `json:"current_password"`
+	NewPassword     string `json:"new_password" binding:"required,min=8"`
+}
+
+// DeleteAccountDTO for DELETE /me; accounts with a password must confirm it
+type DeleteAccountDTO struct {
+	Password string `json:"password"`
+}
+
+// BanAccountDTO for POST /admin/accounts/:id/ban
+type BanAccountDTO struct {
+	Reason    string     `json:"reason" binding:"required,max=500"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"` // omit for a permanent ban
+}
+
+// JWKDTO is a public signing key in JSON Web Key format (RFC 7517)
+type JWKDTO struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`   // RSA modulus
+	E   string `json:"e,omitempty"`   // RSA exponent
+	Crv string `json:"crv,omitempty"` // OKP curve
+	X   string `json:"x,omitempty"`   // OKP public key
+}
+
+// JWKSDTO for GET /.well-known/jwks.json
+type JWKSDTO struct {
+	Keys []JWKDTO `json:"keys"`
+}
+
 // JWTPayload for JWT token claims
 type JWTPayload struct {
-	Sub      uint   `json:"sub"`      // User ID
-	Email    string `json:"email"`    // User email
-	Role     string `json:"role"`     // User role
-	Name     string `json:"name"`     // User name
-	Avatar   string `json:"avatar"`   // Avatar URL (optional)
-	Provider string `json:"provider"` // "local" or "google"
+	Sub       uint   `json:"sub"`      // User ID
+	Email     string `json:"email"`    // User email
+	Role      string `json:"role"`     // User role
+	Name      string `json:"name"`     // User name
+	Avatar    string `json:"avatar"`   // Avatar URL (optional)
+	Provider  string `json:"provider"` // "local" or an OAuth provider name
+	SessionID string `json:"sid"`      // Session family the token was issued for
+	MFA       bool   `json:"mfa"`      // Session passed a second factor
+
+	// MFARequired is set by AuthenticateToken when a privileged role is withheld until 2FA (not a claim)
+	MFARequired bool `json:"-"`
+	// TokenID and Scopes are set when the request used a personal access token (not claims)
+	TokenID uint     `json:"-"`
+	Scopes  []string `json:"-"`
+}
+
+// IsPersonalToken reports whether the payload comes from a personal access token
+func (p *JWTPayload) IsPersonalToken() bool {
+	return p.TokenID != 0
+}
+
+// HasScope reports whether a personal access token was granted the scope
+func (p *JWTPayload) HasScope(scope TokenScope) bool {
+	for _, s := range p.Scopes {
+		if s == string(scope) {
+			return true
+		}
+	}
+	return false
 }
 
-// GoogleProfile for Google OAuth user info
-type GoogleProfile struct {
-	GoogleID      string `json:"google_id"`
+// OAuthProfile is the identity returned by an OAuth provider, normalized across providers
+type OAuthProfile struct {
+	Provider      string `json:"provider"` // "google", "github", "gitlab" or the configured OIDC name
+	Subject       string `json:"subject"`  // Stable user ID at the provider
 	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
 	Name          string `json:"name"`
 	Avatar        string `json:"avatar"`
-	Provider      string `json:"provider"`
-	EmailVerified bool   `json:"email_verified"`
 }
 
 // MeResponseDTO for /me endpoint
@@ -53,3 +167,12 @@ type MeResponseDTO struct {
 	User        AccountResponseDTO `json:"user"`
 	AccessToken string             `json:"access_token,omitempty"`
 }
+
+// LoginLockoutDTO for admin lockout listing
+type LoginLockoutDTO struct {
+	Key           string    `json:"key"`
+	Failures      int       `json:"failures"`
+	LastFailureAt time.Time `json:"last_failure_at"`
+	BlockedUntil  time.Time `json:"blocked_until"`
+	Locked        bool      `json:"locked"` // true for a full lockout, false for a backoff delay
+}
@@ -0,0 +1,10 @@
+package domain
+
+// AuthorService interface - public author pages.
+// An author is referenced by account ID or by handle (handles always contain a letter).
+type AuthorService interface {
+	// GetProfile returns the public profile and stats of an active account
+	GetProfile(ref string) (*AuthorProfileDTO, error)
+	FindTutorials(ref string, params AuthorContentParams) (*AuthorTutorialsDTO, error)
+	FindVideos(ref string, params AuthorContentParams) (*AuthorVideosDTO, error)
+}
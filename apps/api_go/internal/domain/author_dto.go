@@ -0,0 +1,53 @@
+package domain
+
+import "time"
+
+// AuthorContentParams for the paginated lists on an author page (keyset pagination on ID)
+type AuthorContentParams struct {
+	Limit  int   `form:"limit"`
+	Cursor *uint `form:"cursor"`
+}
+
+// PageLimit returns the requested page size clamped to 1..50 (default 20)
+func (p AuthorContentParams) PageLimit() int {
+	if p.Limit <= 0 {
+		return 20
+	}
+	if p.Limit > 50 {
+		return 50
+	}
+	return p.Limit
+}
+
+// AuthorStatsDTO counts an author's public contributions
+type AuthorStatsDTO struct {
+	Tutorials         int64 `json:"tutorials"`
+	Videos            int64 `json:"videos"`
+	Comments          int64 `json:"comments"`
+	ReceivedUpvotes   int64 `json:"receivedUpvotes"`
+	ReceivedDownvotes int64 `json:"receivedDownvotes"`
+}
+
+// AuthorProfileDTO is the public profile of an account (no email or account settings)
+type AuthorProfileDTO struct {
+	ID        uint             `json:"id"`
+	Name      string           `json:"name"`
+	Handle    string           `json:"handle"`
+	AvatarURL *string          `json:"avatarUrl,omitempty"`
+	Bio       string           `json:"bio"`
+	Links     []ProfileLinkDTO `json:"links"`
+	JoinedAt  time.Time        `json:"joinedAt"`
+	Stats     AuthorStatsDTO   `json:"stats"`
+}
+
+// AuthorTutorialsDTO is a page of an author's tutorials
+type AuthorTutorialsDTO struct {
+	Items      []TutorialListItemDTO `json:"items"`
+	NextCursor *uint                 `json:"nextCursor"`
+}
+
+// AuthorVideosDTO is a page of an author's videos
+type AuthorVideosDTO struct {
+	Items      []VideoResponseDTO `json:"items"`
+	NextCursor *uint              `json:"nextCursor"`
+}
@@ -0,0 +1,19 @@
+package domain
+
+import "io"
+
+// Blob is a stored file opened for reading
+type Blob struct {
+	Body        io.ReadCloser
+	ContentType string
+	Size        int64
+}
+
+// BlobStore interface for uploaded files, addressed by slash-separated keys ("avatars/12/ab34/64.jpg")
+type BlobStore interface {
+	Put(key string, data []byte, contentType string) error
+	// Open returns nil when the key does not exist; the caller closes Body
+	Open(key string) (*Blob, error)
+	// Delete removes the key; deleting a missing key is not an error
+	Delete(key string) error
+}
@@ -5,13 +5,14 @@ type CommentService interface {
 	Create(dto CreateCommentDTO) (*CommentResponseDTO, error)
 	FindAll() ([]CommentResponseDTO, error)
 	FindOne(id uint) (*CommentResponseDTO, error)
-	Update(id uint, dto UpdateCommentDTO) (*CommentResponseDTO, error)
-	Remove(id uint) error
+	Update(id uint, dto UpdateCommentDTO, actor Actor) (*CommentResponseDTO, error)
+	Remove(id uint, actor Actor) error
 	FindByEntity(entityType EntityType, entityID int64) ([]CommentResponseDTO, error)
 	FindByAuthor(authorID uint) ([]CommentResponseDTO, error)
 	FindReplies(parentID uint) ([]CommentResponseDTO, error)
-	IncrementUpvotes(id uint) (*CommentResponseDTO, error)
-	DecrementUpvotes(id uint) (*CommentResponseDTO, error)
+	// IncrementUpvotes and DecrementUpvotes record the actor's single vote on a comment
+	IncrementUpvotes(id uint, actor Actor) (*CommentResponseDTO, error)
+	DecrementUpvotes(id uint, actor Actor) (*CommentResponseDTO, error)
 }
 
 // CommentRepository interface - returns entities
@@ -24,4 +25,7 @@ type CommentRepository interface {
 	FindByEntity(entityType EntityType, entityID int64) ([]Comment, error)
 	FindByAuthor(authorID uint) ([]Comment, error)
 	FindByParent(parentID uint) ([]Comment, error)
+	CountByAuthor(authorID uint) (int64, error)
+	// ApplyVote stores the account's single vote and recounts the score from the votes table
+	ApplyVote(id uint, userID uint, voteType VoteType) error
 }
@@ -4,7 +4,7 @@ import "time"
 
 type CreateCommentDTO struct {
 	Content    string     `json:"content" binding:"required"`
-	AuthorID   uint       `json:"authorId" binding:"required"`
+	AuthorID   uint       `json:"-"` // set from the authenticated user
 	ParentID   *uint      `json:"parentId,omitempty"`
 	EntityType EntityType `json:"entityType" binding:"required"`
 	EntityID   int64      `json:"entityId" binding:"required"`
@@ -16,15 +16,18 @@ type UpdateCommentDTO struct {
 }
 
 type CommentResponseDTO struct {
-	ID         uint                 `json:"id"`
-	Content    string               `json:"content"`
-	AuthorID   uint                 `json:"authorId"`
-	AuthorName string               `json:"authorName,omitempty"`
-	ParentID   *uint                `json:"parentId,omitempty"`
-	EntityType EntityType           `json:"entityType"`
-	EntityID   int64                `json:"entityId"`
-	Upvotes    int64                `json:"upvotes"`
-	CreatedAt  time.Time            `json:"createdAt"`
-	UpdatedAt  time.Time            `json:"updatedAt"`
-	Replies    []CommentResponseDTO `json:"replies,omitempty"`
+	ID           uint                 `json:"id"`
+	Content      string               `json:"content"`
+	AuthorID     uint                 `json:"authorId"`
+	AuthorName   string               `json:"authorName,omitempty"`
+	AuthorHandle string               `json:"authorHandle,omitempty"`
+	ParentID     *uint                `json:"parentId,omitempty"`
+	EntityType   EntityType           `json:"entityType"`
+	EntityID     int64                `json:"entityId"`
+	Upvotes      int64                `json:"upvotes"`
+	CreatedAt    time.Time            `json:"createdAt"`
+	UpdatedAt    time.Time            `json:"updatedAt"`
+	Replies      []CommentResponseDTO `json:"replies,omitempty"`
+	// Mentions lists the accounts mentioned as @handle in Content
+	Mentions []MentionDTO `json:"mentions,omitempty"`
 }
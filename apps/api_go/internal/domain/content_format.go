@@ -0,0 +1,10 @@
+package domain
+
+// ContentFormat selects how tutorial content is returned
+type ContentFormat string
+
+const (
+	ContentFormatMarkdown ContentFormat = "markdown" // raw source (default)
+	ContentFormatHTML     ContentFormat = "html"     // sanitized HTML and table of contents
+	ContentFormatBoth     ContentFormat = "both"
+)
@@ -7,4 +7,5 @@ const (
 	EntityTypeTutorial EntityType = "tutorial"
 	EntityTypeVideo    EntityType = "video"
 	EntityTypeProduct  EntityType = "product"
+	EntityTypeComment  EntityType = "comment"
 )
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// LoginAttemptStore interface - persists failed login counters (in-memory or Postgres)
+type LoginAttemptStore interface {
+	Get(key string) (*LoginAttempt, error)
+	// RecordFailure increments the failure count, restarting it when the last failure is older than window
+	RecordFailure(key string, window time.Duration) (*LoginAttempt, error)
+	// BlockUntil rejects further attempts for the key until the given time
+	BlockUntil(key string, until time.Time) error
+	Reset(key string) error
+	// ListBlocked returns the keys that are currently blocked
+	ListBlocked() ([]LoginAttempt, error)
+}
+
+// LoginThrottle interface - brute-force protection for password and 2FA login
+type LoginThrottle interface {
+	// Check returns a *RetryAfterError when the IP or the email is currently blocked
+	Check(ip, email string) error
+	// RecordFailure counts a failed login and applies backoff or lockout
+	RecordFailure(ip, email string) error
+	// RecordSuccess clears the failure count of the email
+	RecordSuccess(email string) error
+	// CheckMFA returns a *RetryAfterError when the IP or the account is blocked for 2FA codes
+	CheckMFA(ip string, accountID uint) error
+	// RecordMFAFailure counts a wrong 2FA code for the IP and the account
+	RecordMFAFailure(ip string, accountID uint) error
+	// RecordMFASuccess clears the 2FA failure count of the account
+	RecordMFASuccess(accountID uint) error
+	// ListLockouts lists blocked keys for admins
+	ListLockouts() ([]LoginLockoutDTO, error)
+	// Unblock clears a key (e.g. "email:jane@example.com")
+	Unblock(key string) error
+}
+
+// RetryAfterError is returned when a request must wait before retrying
+type RetryAfterError struct {
+	RetryAfter time.Duration
+}
+
+func (e *RetryAfterError) Error() string {
+	return "too many login attempts"
+}
+
+// RetryAfterSeconds returns the wait rounded up to whole seconds (Retry-After header)
+func (e *RetryAfterError) RetryAfterSeconds() string {
+	return fmt.Sprintf("%d", int64(math.Ceil(e.RetryAfter.Seconds())))
+}
@@ -0,0 +1,16 @@
+package domain
+
+import "time"
+
+// LoginAttempt entity - maps to 'login_attempts' table
+// Tracks recent failed logins per throttle key ("ip:<addr>" or "email:<address>").
+type LoginAttempt struct {
+	Key           string     `gorm:"column:throttle_key;type:varchar(320);primaryKey"`
+	Failures      int        `gorm:"column:failures;not null;default:0"`
+	LastFailureAt time.Time  `gorm:"column:last_failure_at;not null"`
+	BlockedUntil  *time.Time `gorm:"column:blocked_until;index"`
+}
+
+func (LoginAttempt) TableName() string {
+	return "login_attempts"
+}
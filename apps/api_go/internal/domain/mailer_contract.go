@@ -0,0 +1,13 @@
+package domain
+
+// MailMessage is a plain-text email
+type MailMessage struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer interface for sending transactional email
+type Mailer interface {
+	Send(msg MailMessage) error
+}
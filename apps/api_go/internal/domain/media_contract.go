@@ -0,0 +1,15 @@
+package domain
+
+import "io"
+
+// AvatarService interface - uploaded profile pictures
+type AvatarService interface {
+	// Upload validates the image, stores it resized to the avatar sizes and makes it the account's avatar
+	Upload(accountID uint, file io.Reader) (*AvatarResponseDTO, error)
+	// Remove clears the avatar and deletes the uploaded files
+	Remove(accountID uint) error
+	// SetURL points the avatar at an external URL (nil clears it) and deletes the uploaded files it replaces
+	SetURL(accountID uint, avatarURL *string) error
+	// DeleteUpload deletes the files of an upload no account refers to any more (an empty key is ignored)
+	DeleteUpload(avatarKey string)
+}
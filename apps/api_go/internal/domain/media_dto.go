@@ -0,0 +1,7 @@
+package domain
+
+// AvatarResponseDTO for POST /me/avatar
+type AvatarResponseDTO struct {
+	AvatarURL string            `json:"avatar_url"` // largest size, stored as the account avatar
+	Sizes     map[string]string `json:"sizes"`      // pixel size -> URL
+}
@@ -0,0 +1,14 @@
+package domain
+
+import "time"
+
+// PersonalTokenRepository interface - data access layer for personal access tokens
+type PersonalTokenRepository interface {
+	Create(token *PersonalAccessToken) error
+	FindByTokenHash(tokenHash string) (*PersonalAccessToken, error)
+	FindByAccount(accountID uint) ([]PersonalAccessToken, error)
+	// Revoke revokes a token of the account; returns false if no such active token exists
+	Revoke(id, accountID uint) (bool, error)
+	RevokeAllForAccount(accountID uint) error
+	TouchLastUsed(id uint, at time.Time) error
+}
@@ -0,0 +1,27 @@
+package domain
+
+import "time"
+
+// CreatePersonalTokenDTO for POST /me/tokens
+type CreatePersonalTokenDTO struct {
+	Name          string       `json:"name" binding:"required,max=100"`
+	Scopes        []TokenScope `json:"scopes" binding:"required,min=1"`
+	ExpiresInDays *int         `json:"expires_in_days,omitempty" binding:"omitempty,min=1,max=365"` // omit for a token without expiry
+}
+
+// PersonalTokenResponseDTO describes a token without its secret
+type PersonalTokenResponseDTO struct {
+	ID         uint       `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// CreatedPersonalTokenDTO is returned once at creation and includes the token itself
+type CreatedPersonalTokenDTO struct {
+	PersonalTokenResponseDTO
+	Token string `json:"token"`
+}
@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// PersonalAccessToken entity - maps to 'personal_access_tokens' table
+// API tokens for scripts and CI; only the SHA-256 hash of the token is stored.
+type PersonalAccessToken struct {
+	ID         uint       `gorm:"primaryKey"`
+	AccountID  uint       `gorm:"column:account_id;not null;index"`
+	Account    *Account   `gorm:"foreignKey:AccountID;constraint:OnDelete:CASCADE"`
+	Name       string     `gorm:"column:name;type:varchar(100);not null"`
+	TokenHash  string     `gorm:"column:token_hash;type:varchar(64);not null;uniqueIndex"`
+	Prefix     string     `gorm:"column:prefix;type:varchar(16);not null"` // first characters, shown to identify the token
+	Scopes     string     `gorm:"column:scopes;not null"`                  // space separated TokenScope values
+	MFA        bool       `gorm:"column:mfa;not null;default:false"`       // created from a session that passed 2FA
+	ExpiresAt  *time.Time `gorm:"column:expires_at"`
+	LastUsedAt *time.Time `gorm:"column:last_used_at"`
+	RevokedAt  *time.Time `gorm:"column:revoked_at"`
+	CreatedAt  time.Time  `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (PersonalAccessToken) TableName() string {
+	return "personal_access_tokens"
+}
+
+// ScopeList returns the token scopes as a slice
+func (t *PersonalAccessToken) ScopeList() []string {
+	return strings.Fields(t.Scopes)
+}
@@ -0,0 +1,11 @@
+package domain
+
+// RecoveryCodeRepository interface - persists hashed 2FA recovery codes
+type RecoveryCodeRepository interface {
+	// ReplaceForAccount deletes existing codes and stores the new set
+	ReplaceForAccount(accountID uint, codeHashes []string) error
+	// Consume marks an unused code as used; returns false if no such code exists
+	Consume(accountID uint, codeHash string) (bool, error)
+	CountUnused(accountID uint) (int64, error)
+	DeleteForAccount(accountID uint) error
+}
@@ -0,0 +1,18 @@
+package domain
+
+import "time"
+
+// RecoveryCode entity - maps to 'account_recovery_codes' table
+// Single-use 2FA backup codes; only the SHA-256 hash is stored.
+type RecoveryCode struct {
+	ID        uint       `gorm:"primaryKey"`
+	AccountID uint       `gorm:"column:account_id;not null;index"`
+	Account   *Account   `gorm:"foreignKey:AccountID;constraint:OnDelete:CASCADE"`
+	CodeHash  string     `gorm:"column:code_hash;type:varchar(64);not null"`
+	UsedAt    *time.Time `gorm:"column:used_at"`
+	CreatedAt time.Time  `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (RecoveryCode) TableName() string {
+	return "account_recovery_codes"
+}
@@ -0,0 +1,52 @@
+package domain
+
+// SeriesService interface - returns DTOs
+type SeriesService interface {
+	Create(dto CreateSeriesDTO, authorID uint) (*SeriesDetailDTO, error)
+	// FindAll lists every series, newest first; item counts only include items the actor can see
+	FindAll(actor Actor) ([]SeriesListItemDTO, error)
+	// FindOne returns the series with the items visible to the actor and, for a logged-in
+	// actor, their progress
+	FindOne(id uint, actor Actor) (*SeriesDetailDTO, error)
+	Update(id uint, dto UpdateSeriesDTO, actor Actor) (*SeriesDetailDTO, error)
+	Remove(id uint, actor Actor) error
+
+	// AddItem appends a tutorial or a video (owner or moderator; tutorials must be the actor's own)
+	AddItem(id uint, dto AddSeriesItemDTO, actor Actor) (*SeriesDetailDTO, error)
+	RemoveItem(id, itemID uint, actor Actor) (*SeriesDetailDTO, error)
+	// Reorder sets the order of the items; dto.ItemIDs must list every item once
+	Reorder(id uint, dto ReorderSeriesItemsDTO, actor Actor) (*SeriesDetailDTO, error)
+
+	// CompleteItem and UncompleteItem record the actor's progress through the series
+	CompleteItem(id, itemID uint, actor Actor) (*SeriesProgressDTO, error)
+	UncompleteItem(id, itemID uint, actor Actor) (*SeriesProgressDTO, error)
+}
+
+// SeriesRepository interface - returns entities
+type SeriesRepository interface {
+	Create(series *Series) error
+	// FindAll returns every series with its author and items, newest first (the items' tutorials
+	// and videos only carry what visibility checks need)
+	FindAll() ([]Series, error)
+	FindOne(id uint) (*Series, error)
+	// FindOneWithItems returns the series with its items in order, their tutorial or video preloaded
+	FindOneWithItems(id uint) (*Series, error)
+	// Update stores the title and description (an empty description clears it)
+	Update(id uint, title, description string) error
+	// Delete removes the series and its items
+	Delete(id uint) error
+
+	// AddItem appends the item after the last one
+	AddItem(item *SeriesItem) error
+	// RemoveItem deletes the item and closes the gap in the positions
+	RemoveItem(seriesID, itemID uint) error
+	// Reorder gives the items positions 1..n in the order of itemIDs
+	Reorder(seriesID uint, itemIDs []uint) error
+	// FindItemByTutorial returns the series item holding the tutorial
+	FindItemByTutorial(tutorialID uint) (*SeriesItem, error)
+
+	CompleteItem(accountID, itemID uint) error
+	UncompleteItem(accountID, itemID uint) error
+	// FindCompletedItemIDs returns the items of the series the account has completed
+	FindCompletedItemIDs(accountID, seriesID uint) ([]uint, error)
+}
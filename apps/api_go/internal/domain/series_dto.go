@@ -0,0 +1,88 @@
+package domain
+
+import "time"
+
+// SeriesItemType tells what a series item points to
+type SeriesItemType string
+
+const (
+	SeriesItemTutorial SeriesItemType = "tutorial"
+	SeriesItemVideo    SeriesItemType = "video"
+)
+
+type CreateSeriesDTO struct {
+	Title       string `json:"title" binding:"required,min=1,max=100"`
+	Description string `json:"description" binding:"max=2000"`
+}
+
+type UpdateSeriesDTO struct {
+	Title       *string `json:"title,omitempty" binding:"omitempty,min=1,max=100"`
+	Description *string `json:"description,omitempty" binding:"omitempty,max=2000"`
+}
+
+// AddSeriesItemDTO for POST /series/:id/items (exactly one of tutorialId and videoId)
+type AddSeriesItemDTO struct {
+	TutorialID *uint `json:"tutorialId,omitempty"`
+	VideoID    *uint `json:"videoId,omitempty"`
+}
+
+// ReorderSeriesItemsDTO for PUT /series/:id/items/order
+type ReorderSeriesItemsDTO struct {
+	ItemIDs []uint `json:"itemIds" binding:"required"`
+}
+
+type SeriesItemDTO struct {
+	ID         uint           `json:"id"`
+	Position   int            `json:"position"`
+	Type       SeriesItemType `json:"type"`
+	TutorialID *uint          `json:"tutorialId,omitempty"`
+	VideoID    *uint          `json:"videoId,omitempty"`
+	Title      string         `json:"title"`
+	Slug       string         `json:"slug,omitempty"`   // tutorials only
+	Status     TutorialStatus `json:"status,omitempty"` // tutorials only
+	YoutubeID  string         `json:"youtubeId,omitempty"`
+	Completed  bool           `json:"completed"`
+}
+
+type SeriesProgressDTO struct {
+	SeriesID         uint   `json:"seriesId"`
+	Completed        int    `json:"completed"`
+	Total            int    `json:"total"`
+	CompletedItemIDs []uint `json:"completedItemIds"`
+}
+
+type SeriesListItemDTO struct {
+	ID           uint      `json:"id"`
+	Title        string    `json:"title"`
+	Description  string    `json:"description"`
+	ItemCount    int       `json:"itemCount"`
+	AuthorID     uint      `json:"authorId"`
+	AuthorName   string    `json:"authorName"`
+	AuthorHandle string    `json:"authorHandle"`
+	CreatedAt    time.Time `json:"createdAt"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+}
+
+type SeriesDetailDTO struct {
+	ID           uint               `json:"id"`
+	Title        string             `json:"title"`
+	Description  string             `json:"description"`
+	AuthorID     uint               `json:"authorId"`
+	AuthorName   string             `json:"authorName"`
+	AuthorHandle string             `json:"authorHandle"`
+	CreatedAt    time.Time          `json:"createdAt"`
+	UpdatedAt    time.Time          `json:"updatedAt"`
+	Items        []SeriesItemDTO    `json:"items"`
+	Progress     *SeriesProgressDTO `json:"progress,omitempty"` // logged-in readers only
+}
+
+// TutorialSeriesNavDTO places a tutorial within its series; Position and Total count the
+// items visible to the reader, Previous and Next are nil at either end
+type TutorialSeriesNavDTO struct {
+	ID       uint           `json:"id"`
+	Title    string         `json:"title"`
+	Position int            `json:"position"`
+	Total    int            `json:"total"`
+	Previous *SeriesItemDTO `json:"previous"`
+	Next     *SeriesItemDTO `json:"next"`
+}
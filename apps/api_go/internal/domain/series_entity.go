@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Series entity - maps to 'series' table
+// An ordered collection of tutorials and videos, e.g. the parts of a multi-part guide.
+type Series struct {
+	gorm.Model
+	Title       string       `gorm:"column:title;type:text;not null"`
+	Description string       `gorm:"column:description;type:text;not null;default:''"`
+	AuthorID    uint         `gorm:"column:author_id;not null;index"`
+	Author      *Account     `gorm:"foreignKey:AuthorID"`
+	Items       []SeriesItem `gorm:"foreignKey:SeriesID"`
+}
+
+func (Series) TableName() string {
+	return "series"
+}
+
+// SeriesItem entity - maps to 'series_items' table
+// Exactly one of TutorialID and VideoID is set. A tutorial belongs to at most one series,
+// which is what gives its detail page a previous/next navigation.
+type SeriesItem struct {
+	ID         uint      `gorm:"primaryKey"`
+	SeriesID   uint      `gorm:"column:series_id;not null;index;uniqueIndex:idx_series_items_video"`
+	Series     *Series   `gorm:"foreignKey:SeriesID;constraint:OnDelete:CASCADE"`
+	Position   int       `gorm:"column:position;not null"` // 1-based order within the series
+	TutorialID *uint     `gorm:"column:tutorial_id;uniqueIndex"`
+	Tutorial   *Tutorial `gorm:"foreignKey:TutorialID;constraint:OnDelete:CASCADE"`
+	VideoID    *uint     `gorm:"column:video_id;uniqueIndex:idx_series_items_video"`
+	Video      *Video    `gorm:"foreignKey:VideoID;constraint:OnDelete:CASCADE"`
+	CreatedAt  time.Time `gorm:"column:created_at;autoCreateTime"`
+}
+
+// VisibleTo reports whether the actor may see the item: videos always, tutorials by their
+// own rules; items whose tutorial or video was deleted are hidden (both must be preloaded)
+func (i *SeriesItem) VisibleTo(actor Actor) bool {
+	if i.TutorialID != nil {
+		return i.Tutorial != nil && i.Tutorial.VisibleTo(actor)
+	}
+	return i.Video != nil
+}
+
+func (SeriesItem) TableName() string {
+	return "series_items"
+}
+
+// SeriesProgress entity - maps to 'series_progress' table
+// One row per series item an account has marked as completed.
+type SeriesProgress struct {
+	AccountID    uint        `gorm:"column:account_id;primaryKey"`
+	Account      *Account    `gorm:"foreignKey:AccountID;constraint:OnDelete:CASCADE"`
+	SeriesItemID uint        `gorm:"column:series_item_id;primaryKey;index"`
+	SeriesItem   *SeriesItem `gorm:"foreignKey:SeriesItemID;constraint:OnDelete:CASCADE"`
+	CompletedAt  time.Time   `gorm:"column:completed_at;autoCreateTime"`
+}
+
+func (SeriesProgress) TableName() string {
+	return "series_progress"
+}
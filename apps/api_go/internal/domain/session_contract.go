@@ -0,0 +1,15 @@
+package domain
+
+// SessionRepository interface - persists refresh tokens and session families
+type SessionRepository interface {
+	Create(session *Session) error
+	FindByTokenHash(tokenHash string) (*Session, error)
+	// MarkRotated flags a refresh token as used; returns false if it was already used or revoked
+	MarkRotated(id uint) (bool, error)
+	RevokeFamily(familyID string) error
+	RevokeAllForAccount(accountID uint) error
+	// RevokeOthersForAccount revokes every session of the account except the given family
+	RevokeOthersForAccount(accountID uint, keepFamilyID string) error
+	// IsFamilyActive reports whether the family still has an unrevoked, unexpired refresh token
+	IsFamilyActive(familyID string) (bool, error)
+}
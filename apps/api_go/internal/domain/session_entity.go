@@ -0,0 +1,23 @@
+package domain
+
+import "time"
+
+// Session entity - maps to 'sessions' table
+// Each row holds one opaque refresh token; rotated tokens of the same login share a FamilyID.
+type Session struct {
+	ID        uint       `gorm:"primaryKey"`
+	AccountID uint       `gorm:"column:account_id;not null;index"`
+	Account   *Account   `gorm:"foreignKey:AccountID;constraint:OnDelete:CASCADE"`
+	FamilyID  string     `gorm:"column:family_id;type:varchar(64);not null;index"`
+	TokenHash string     `gorm:"column:token_hash;type:varchar(64);not null;uniqueIndex"`
+	Provider  string     `gorm:"column:provider;type:varchar(20);not null"`
+	MFA       bool       `gorm:"column:mfa;not null;default:false"` // login passed a second factor
+	ExpiresAt time.Time  `gorm:"column:expires_at;not null"`
+	RotatedAt *time.Time `gorm:"column:rotated_at"`
+	RevokedAt *time.Time `gorm:"column:revoked_at"`
+	CreatedAt time.Time  `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (Session) TableName() string {
+	return "sessions"
+}
@@ -0,0 +1,31 @@
+package domain
+
+// TokenScope limits what a personal access token may do
+type TokenScope string
+
+const (
+	ScopeVideosWrite    TokenScope = "videos:write"
+	ScopeTagsWrite      TokenScope = "tags:write"
+	ScopeTutorialsWrite TokenScope = "tutorials:write"
+	ScopeCommentsWrite  TokenScope = "comments:write"
+	ScopeVotesWrite     TokenScope = "votes:write"
+)
+
+// AllTokenScopes lists the scopes a token can be created with
+var AllTokenScopes = []TokenScope{
+	ScopeVideosWrite,
+	ScopeTagsWrite,
+	ScopeTutorialsWrite,
+	ScopeCommentsWrite,
+	ScopeVotesWrite,
+}
+
+// IsValid reports whether the scope is known
+func (s TokenScope) IsValid() bool {
+	for _, scope := range AllTokenScopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
@@ -1,23 +1,93 @@
 package domain
 
+import "time"
+
 // TutorialService interface - returns DTOs
 type TutorialService interface {
+	// Create stores a new tutorial as a draft
 	Create(dto CreateTutorialDTO, authorID uint) (*TutorialDetailDTO, error)
-	FindAll() ([]TutorialListItemDTO, error)
-	FindOne(id uint) (*TutorialDetailDTO, error)
-	FindBySlug(slug string) (*TutorialDetailDTO, error)
-	Update(id uint, dto UpdateTutorialDTO, requesterID uint) (*TutorialDetailDTO, error)
-	Remove(id uint, requesterID uint) error
+	// FindAll returns a page of tutorials matching the filters (published ones unless a
+	// moderator or the author asks for another status)
+	FindAll(params TutorialListParams, actor Actor) (*TutorialPageDTO, error)
+	// FindOne and FindBySlug hide unpublished tutorials from everyone but the author and moderators,
+	// return the content in the requested format and count a view of published tutorials
+	FindOne(id uint, query TutorialDetailQuery, actor Actor) (*TutorialDetailDTO, error)
+	FindBySlug(slug string, query TutorialDetailQuery, actor Actor) (*TutorialDetailDTO, error)
+	// ResolveSlug returns the current slug of the tutorial that used to have slug
+	ResolveSlug(slug string, actor Actor) (string, error)
+	Update(id uint, dto UpdateTutorialDTO, actor Actor) (*TutorialDetailDTO, error)
+	Remove(id uint, actor Actor) error
+	// FindPublishedByAuthor lists an author's published tutorials, newest first
+	FindPublishedByAuthor(authorID uint, params AuthorContentParams) (*AuthorTutorialsDTO, error)
+	// FindMine lists the actor's tutorials in every status
+	FindMine(authorID uint) ([]TutorialListItemDTO, error)
+	// FindInReview lists tutorials waiting for a moderator, oldest first
+	FindInReview() ([]TutorialListItemDTO, error)
+	// FindPublishedByTag lists published tutorials with the tag, newest first
+	FindPublishedByTag(tagID uint) ([]TutorialListItemDTO, error)
+
+	// Submit sends a draft to review (author or moderator)
+	Submit(id uint, actor Actor) (*TutorialDetailDTO, error)
+	// Publish publishes now, or schedules publication when dto.PublishAt is in the future (moderators only)
+	Publish(id uint, dto PublishTutorialDTO, actor Actor) (*TutorialDetailDTO, error)
+	// Unpublish moves a tutorial back to draft and cancels a scheduled publication (author or moderator)
+	Unpublish(id uint, actor Actor) (*TutorialDetailDTO, error)
+	// Archive retires a published tutorial (author or moderator)
+	Archive(id uint, actor Actor) (*TutorialDetailDTO, error)
+	// PublishDue publishes tutorials whose scheduled time has passed and returns how many were published
+	PublishDue() (int64, error)
+
+	// BackfillStats computes the content statistics of tutorials saved before they existed
+	// and returns how many were updated
+	BackfillStats() (int, error)
+
+	// FindViewStats returns the daily views of a tutorial (author or moderator)
+	FindViewStats(id uint, query TutorialViewsQuery, actor Actor) (*TutorialViewStatsDTO, error)
+
+	// FindRevisions lists the saved versions of a tutorial, newest first
+	FindRevisions(id uint, actor Actor) ([]TutorialRevisionDTO, error)
+	// DiffRevisions compares the content of two revisions line by line
+	DiffRevisions(id uint, query TutorialDiffQuery, actor Actor) (*TutorialRevisionDiffDTO, error)
+	// RestoreRevision saves an old revision as the current version (author or moderator)
+	RestoreRevision(id uint, number int, actor Actor) (*TutorialDetailDTO, error)
 }
 
 // TutorialRepository interface - returns entities
 type TutorialRepository interface {
-	Create(tutorial *Tutorial) error
-	FindAll() ([]Tutorial, error)
+	// Create inserts the tutorial and its first revision
+	Create(tutorial *Tutorial, revision *TutorialRevision) error
+	// FindList returns up to limit tutorials matching the filter, after filter.After in its sort order
+	FindList(filter TutorialListFilter, limit int) ([]Tutorial, error)
+	// CountList counts the tutorials matching the filter (filter.After is ignored)
+	CountList(filter TutorialListFilter) (int64, error)
+	FindByAuthor(authorID uint) ([]Tutorial, error)
+	FindByStatus(status TutorialStatus) ([]Tutorial, error)
+	FindPublishedByTag(tagID uint) ([]Tutorial, error)
 	FindOne(id uint) (*Tutorial, error)
 	FindBySlug(slug string) (*Tutorial, error)
 	FindOneWithTags(id uint) (*Tutorial, error)
 	FindBySlugWithTags(slug string) (*Tutorial, error)
-	Update(id uint, tutorial *Tutorial) error
+	// FindBySlugHistory returns the tutorial that used slug before a title change
+	FindBySlugHistory(slug string) (*Tutorial, error)
+	// FindTakenSlugs returns the slugs matching base or base-N held by other tutorials, current or past
+	FindTakenSlugs(base string, exceptID uint) ([]string, error)
+	// Update applies the changes and records them as the next revision (tutorials created
+	// before revisions existed first get their current version saved as revision 1).
+	// A changed slug moves the previous one to the slug history; a set status also clears the schedule.
+	Update(id uint, tutorial *Tutorial, revision *TutorialRevision) error
+	// FindMissingStats returns tutorials saved before content statistics existed
+	FindMissingStats(limit int) ([]Tutorial, error)
+	// UpdateStats writes WordCount, ReadingMinutes and CodeLanguages
+	UpdateStats(id uint, stats *Tutorial) error
+	// UpdateStatus writes the status and both publication times (nil clears them)
+	UpdateStatus(id uint, status TutorialStatus, publishAt, publishedAt *time.Time) error
+	// PublishDue publishes every draft or in-review tutorial scheduled at or before now (safe to run on several instances)
+	PublishDue(now time.Time) (int64, error)
 	Delete(id uint) error
+	// FindPublishedByAuthor returns up to limit published tutorials with an ID below cursor (nil for the first page)
+	FindPublishedByAuthor(authorID uint, cursor *uint, limit int) ([]Tutorial, error)
+	CountPublishedByAuthor(authorID uint) (int64, error)
+	// FindRevisions returns the revisions with their editor, newest first
+	FindRevisions(tutorialID uint) ([]TutorialRevision, error)
+	FindRevision(tutorialID uint, number int) (*TutorialRevision, error)
 }
@@ -10,28 +10,189 @@ type CreateTutorialDTO struct {
 type UpdateTutorialDTO struct {
 	Title   *string `json:"title,omitempty" binding:"omitempty,min=1,max=100"`
 	Content *string `json:"content,omitempty" binding:"omitempty,min=1"`
+	// Summary describes the change in the revision history
+	Summary string `json:"summary,omitempty" binding:"max=255"`
 }
 
-type TutorialListItemDTO struct {
-	ID              uint      `json:"id"`
-	Title           string    `json:"title"`
-	Slug            string    `json:"slug"`
-	CreatedAt       time.Time `json:"createdAt"`
-	UpdatedAt       time.Time `json:"updatedAt"`
-	AuthorName      string    `json:"authorName"`
-	AuthorAvatarURL string    `json:"authorAvatarUrl"`
+// TutorialSort orders the tutorial list; every order is descending with the ID as tie-breaker
+type TutorialSort string
+
+const (
+	TutorialSortNewest TutorialSort = "newest" // created_at
+	TutorialSortViews  TutorialSort = "views"
+	TutorialSortVotes  TutorialSort = "votes" // upvotes minus downvotes
+)
+
+// TutorialListParams for GET /tutorials (keyset pagination; dates are YYYY-MM-DD, UTC, and
+// filter on the creation day). Statuses other than published need a moderator, or authorId
+// set to the caller.
+type TutorialListParams struct {
+	Limit        int            `form:"limit"`
+	Cursor       string         `form:"cursor"`
+	TagIDs       []uint         `form:"tags" collection_format:"csv"`
+	TagMatch     string         `form:"tagMatch" binding:"omitempty,oneof=any all"` // default any
+	AuthorID     *uint          `form:"authorId"`
+	Status       TutorialStatus `form:"status" binding:"omitempty,oneof=draft in_review published archived"`
+	From         string         `form:"from"`
+	To           string         `form:"to"`
+	Sort         TutorialSort   `form:"sort" binding:"omitempty,oneof=newest views votes"`
+	Lang         string         `form:"lang"` // language of a fenced code block, e.g. go
+	IncludeTotal bool           `form:"includeTotal"`
 }
 
-type TutorialDetailDTO struct {
+// PageLimit returns the requested page size clamped to 1..50 (default 20)
+func (p TutorialListParams) PageLimit() int {
+	if p.Limit <= 0 {
+		return 20
+	}
+	if p.Limit > 50 {
+		return 50
+	}
+	return p.Limit
+}
+
+// TutorialListFilter is the validated form of TutorialListParams passed to the repository.
+// After is the position of the last tutorial of the previous page.
+type TutorialListFilter struct {
+	TagIDs       []uint
+	MatchAllTags bool
+	AuthorID     *uint
+	Status       TutorialStatus
+	From         *time.Time // inclusive
+	Until        *time.Time // exclusive
+	Language     string     // normalized code language
+	Sort         TutorialSort
+	After        *TutorialCursor
+}
+
+// TutorialCursor is the sort key of a tutorial in the list: CreatedAt for newest, Count for
+// views and votes
+type TutorialCursor struct {
+	Sort      TutorialSort `json:"s"`
+	CreatedAt time.Time    `json:"t,omitempty"`
+	Count     int64        `json:"n,omitempty"`
+	ID        uint         `json:"id"`
+}
+
+// TutorialPageDTO is a page of GET /tutorials; Total is only set when includeTotal is requested
+type TutorialPageDTO struct {
+	Items      []TutorialListItemDTO `json:"items"`
+	NextCursor *string               `json:"nextCursor"`
+	Total      *int64                `json:"total,omitempty"`
+}
+
+// TutorialDetailQuery for GET /tutorials/:id and /tutorials/slug/:slug
+type TutorialDetailQuery struct {
+	Format ContentFormat `form:"format" binding:"omitempty,oneof=html markdown both"`
+	// Viewer identifies the reader for view counting (set by the controller, not bound)
+	Viewer string `form:"-"`
+}
+
+// TutorialViewsQuery for GET /tutorials/:id/views (dates are YYYY-MM-DD, UTC)
+type TutorialViewsQuery struct {
+	From string `form:"from"`
+	To   string `form:"to"`
+}
+
+type TutorialViewDayDTO struct {
+	Date  string `json:"date"`
+	Views int64  `json:"views"`
+}
+
+type TutorialViewStatsDTO struct {
+	TutorialID uint                 `json:"tutorialId"`
+	From       string               `json:"from"`
+	To         string               `json:"to"`
+	Total      int64                `json:"total"` // views in the range
+	Days       []TutorialViewDayDTO `json:"days"`
+}
+
+// TutorialTOCEntryDTO is a heading of the rendered content; ID is its anchor in contentHtml
+type TutorialTOCEntryDTO struct {
+	Level int    `json:"level"`
+	Text  string `json:"text"`
+	ID    string `json:"id"`
+}
+
+// PublishTutorialDTO for POST /tutorials/:id/publish (without publishAt the tutorial is published now)
+type PublishTutorialDTO struct {
+	PublishAt *time.Time `json:"publishAt,omitempty"`
+}
+
+type TutorialListItemDTO struct {
 	ID              uint             `json:"id"`
 	Title           string           `json:"title"`
 	Slug            string           `json:"slug"`
-	Content         string           `json:"content"`
-	Views           int64            `json:"views"`
-	IsPublished     bool             `json:"isPublished"`
+	Status          TutorialStatus   `json:"status"`
+	PublishedAt     *time.Time       `json:"publishedAt"`
 	CreatedAt       time.Time        `json:"createdAt"`
 	UpdatedAt       time.Time        `json:"updatedAt"`
 	AuthorName      string           `json:"authorName"`
+	AuthorHandle    string           `json:"authorHandle"`
 	AuthorAvatarURL string           `json:"authorAvatarUrl"`
 	Tags            []TagResponseDTO `json:"tags"`
+	WordCount       int              `json:"wordCount"`
+	ReadingMinutes  int              `json:"readingMinutes"`
+	CodeLanguages   []string         `json:"codeLanguages"`
+}
+
+type TutorialDetailDTO struct {
+	ID              uint                  `json:"id"`
+	Title           string                `json:"title"`
+	Slug            string                `json:"slug"`
+	Content         string                `json:"content,omitempty"`
+	ContentHTML     string                `json:"contentHtml,omitempty"`
+	TOC             []TutorialTOCEntryDTO `json:"toc,omitempty"`
+	Views           int64                 `json:"views"`
+	IsPublished     bool                  `json:"isPublished"`
+	Status          TutorialStatus        `json:"status"`
+	PublishAt       *time.Time            `json:"publishAt,omitempty"` // scheduled publication
+	PublishedAt     *time.Time            `json:"publishedAt"`
+	CreatedAt       time.Time             `json:"createdAt"`
+	UpdatedAt       time.Time             `json:"updatedAt"`
+	AuthorName      string                `json:"authorName"`
+	AuthorHandle    string                `json:"authorHandle"`
+	AuthorAvatarURL string                `json:"authorAvatarUrl"`
+	Tags            []TagResponseDTO      `json:"tags"`
+	WordCount       int                   `json:"wordCount"`
+	ReadingMinutes  int                   `json:"readingMinutes"`
+	CodeLanguages   []string              `json:"codeLanguages"`
+	// Series holds the previous/next navigation when the tutorial is part of a series
+	Series *TutorialSeriesNavDTO `json:"series,omitempty"`
+	// Mentions lists the accounts mentioned as @handle in the content (outside code)
+	Mentions []MentionDTO `json:"mentions,omitempty"`
+}
+
+type TutorialRevisionDTO struct {
+	Number       int       `json:"number"`
+	Title        string    `json:"title"`
+	Summary      string    `json:"summary"`
+	EditorID     uint      `json:"editorId"`
+	EditorName   string    `json:"editorName"`
+	EditorHandle string    `json:"editorHandle"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+// TutorialDiffQuery for GET /tutorials/:id/revisions/diff
+type TutorialDiffQuery struct {
+	From int `form:"from" binding:"required,min=1"`
+	To   int `form:"to" binding:"required,min=1"`
+}
+
+// TutorialDiffLineDTO is one line of a revision diff (line numbers are 1-based, omitted on the side the line is missing from)
+type TutorialDiffLineDTO struct {
+	Op      string `json:"op"` // equal, insert or delete
+	Text    string `json:"text"`
+	OldLine int    `json:"oldLine,omitempty"`
+	NewLine int    `json:"newLine,omitempty"`
+}
+
+type TutorialRevisionDiffDTO struct {
+	From      int                   `json:"from"`
+	To        int                   `json:"to"`
+	FromTitle string                `json:"fromTitle"`
+	ToTitle   string                `json:"toTitle"`
+	Added     int                   `json:"added"`
+	Removed   int                   `json:"removed"`
+	Lines     []TutorialDiffLineDTO `json:"lines"`
 }
@@ -1,20 +1,42 @@
 package domain
 
-import "gorm.io/gorm"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 type Tutorial struct {
 	gorm.Model
-	Title       string   `gorm:"column:title;type:text;not null"`
-	Content     string   `gorm:"column:content;type:text;not null"`
-	AuthorID    uint     `gorm:"column:author_id;not null;index"`
-	Author      *Account `gorm:"foreignKey:AuthorID"`
-	Views       int64    `gorm:"column:views;default:0"`
-	Slug        string   `gorm:"column:slug;type:varchar(255);unique;not null;index"`
-	IsPublished bool     `gorm:"column:is_published;default:true"`
+	Title    string   `gorm:"column:title;type:text;not null"`
+	Content  string   `gorm:"column:content;type:text;not null"`
+	AuthorID uint     `gorm:"column:author_id;not null;index"`
+	Author   *Account `gorm:"foreignKey:AuthorID"`
+	Views    int64    `gorm:"column:views;default:0"`
+	Slug     string   `gorm:"column:slug;type:varchar(255);unique;not null;index"`
+	// Status follows draft -> in_review -> published -> archived; only published tutorials are public
+	Status TutorialStatus `gorm:"column:status;type:varchar(20);not null;default:'published';index"`
+	// IsPublished mirrors Status == published for the Nest API, which shares the table and still
+	// reads and writes it; the repository keeps it in sync on every status change
+	IsPublished bool `gorm:"column:is_published;not null;default:true"`
+	// PublishAt schedules publication of an approved draft; PublishedAt is the first publication time
+	PublishAt   *time.Time `gorm:"column:publish_at;index"`
+	PublishedAt *time.Time `gorm:"column:published_at"`
+	// WordCount, ReadingMinutes and CodeLanguages are computed from the content on every save
+	WordCount      int        `gorm:"column:word_count;not null;default:0"`
+	ReadingMinutes int        `gorm:"column:reading_minutes;not null;default:0"` // 0 until computed
+	CodeLanguages  StringList `gorm:"column:code_languages;type:jsonb;not null;default:'[]';index:,type:gin"`
+	// Score is upvotes minus downvotes; read-only, only filled by lists sorted by votes
+	Score int64 `gorm:"column:score;->;-:migration"`
 	// Many2Many with Tag through tutorial_tags table
 	Tags []Tag `gorm:"many2many:tutorial_tags;joinForeignKey:tutorial_id;joinReferences:tag_id"`
 }
 
+// VisibleTo reports whether the actor may read the tutorial (unpublished ones only by the author and moderators)
+func (t *Tutorial) VisibleTo(actor Actor) bool {
+	return t.Status == TutorialStatusPublished || actor.CanModify(t.AuthorID)
+}
+
 func (Tutorial) TableName() string {
 	return "tutorials"
 }
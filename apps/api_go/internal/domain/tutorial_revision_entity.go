@@ -0,0 +1,22 @@
+package domain
+
+import "time"
+
+// TutorialRevision entity - maps to 'tutorial_revisions' table
+// Every saved version of a tutorial is kept; Number counts up from 1 per tutorial.
+type TutorialRevision struct {
+	ID         uint      `gorm:"primaryKey"`
+	TutorialID uint      `gorm:"column:tutorial_id;not null;uniqueIndex:idx_tutorial_revisions_number"`
+	Tutorial   *Tutorial `gorm:"foreignKey:TutorialID;constraint:OnDelete:CASCADE"`
+	Number     int       `gorm:"column:number;not null;uniqueIndex:idx_tutorial_revisions_number"`
+	Title      string    `gorm:"column:title;type:text;not null"`
+	Content    string    `gorm:"column:content;type:text;not null"`
+	EditorID   uint      `gorm:"column:editor_id;not null;index"`
+	Editor     *Account  `gorm:"foreignKey:EditorID"`
+	Summary    string    `gorm:"column:summary;type:varchar(255);not null;default:''"`
+	CreatedAt  time.Time `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (TutorialRevision) TableName() string {
+	return "tutorial_revisions"
+}
@@ -0,0 +1,17 @@
+package domain
+
+import "time"
+
+// TutorialSlugHistory entity - maps to 'tutorial_slug_history' table
+// Slugs a tutorial used before a title change; they stay reserved and redirect to the current slug.
+type TutorialSlugHistory struct {
+	ID         uint      `gorm:"primaryKey"`
+	TutorialID uint      `gorm:"column:tutorial_id;not null;index"`
+	Tutorial   *Tutorial `gorm:"foreignKey:TutorialID;constraint:OnDelete:CASCADE"`
+	Slug       string    `gorm:"column:slug;type:varchar(255);not null;uniqueIndex"`
+	CreatedAt  time.Time `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (TutorialSlugHistory) TableName() string {
+	return "tutorial_slug_history"
+}
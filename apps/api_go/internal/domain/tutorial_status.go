@@ -0,0 +1,28 @@
+package domain
+
+type TutorialStatus string
+
+const (
+	TutorialStatusDraft     TutorialStatus = "draft"
+	TutorialStatusInReview  TutorialStatus = "in_review"
+	TutorialStatusPublished TutorialStatus = "published"
+	TutorialStatusArchived  TutorialStatus = "archived"
+)
+
+// tutorialTransitions lists the statuses each status may move to
+var tutorialTransitions = map[TutorialStatus][]TutorialStatus{
+	TutorialStatusDraft:     {TutorialStatusInReview, TutorialStatusPublished},
+	TutorialStatusInReview:  {TutorialStatusDraft, TutorialStatusPublished},
+	TutorialStatusPublished: {TutorialStatusDraft, TutorialStatusArchived},
+	TutorialStatusArchived:  {TutorialStatusDraft, TutorialStatusPublished},
+}
+
+// CanTransitionTo reports whether the workflow allows moving from s to next
+func (s TutorialStatus) CanTransitionTo(next TutorialStatus) bool {
+	for _, allowed := range tutorialTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
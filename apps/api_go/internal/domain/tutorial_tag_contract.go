@@ -0,0 +1,21 @@
+package domain
+
+// TutorialTagService interface
+type TutorialTagService interface {
+	AttachOne(dto CreateTutorialTagDTO, actor Actor) (*TutorialTagResponseDTO, error)
+	DetachOne(tutorialID, tagID uint, actor Actor) error
+	UpsertForTutorial(dto UpsertTutorialTagsDTO, actor Actor) ([]TagResponseDTO, error)
+	// FindTagsByTutorial hides the tags of unpublished tutorials like the tutorial itself
+	FindTagsByTutorial(tutorialID uint, actor Actor) ([]TagResponseDTO, error)
+	// FindTutorialsByTag lists the published tutorials with the tag
+	FindTutorialsByTag(tagID uint) ([]TutorialListItemDTO, error)
+}
+
+// TutorialTagRepository interface
+type TutorialTagRepository interface {
+	Create(tutorialTag *TutorialTag) error
+	Delete(tutorialID, tagID uint) error
+	FindByTutorialID(tutorialID uint) ([]TutorialTag, error)
+	FindOne(tutorialID, tagID uint) (*TutorialTag, error)
+	BulkCreate(tutorialTags []TutorialTag) error
+}
@@ -0,0 +1,21 @@
+package domain
+
+import "time"
+
+type CreateTutorialTagDTO struct {
+	TutorialID uint `json:"tutorialId" binding:"required"`
+	TagID      uint `json:"tagId" binding:"required"`
+}
+
+type UpsertTutorialTagsDTO struct {
+	TutorialID uint   `json:"tutorialId" binding:"required"`
+	TagIDs     []uint `json:"tagIds" binding:"required"`
+}
+
+type TutorialTagResponseDTO struct {
+	ID         uint      `json:"id"`
+	TutorialID uint      `json:"tutorialId"`
+	TagID      uint      `json:"tagId"`
+	CreatedAt  time.Time `json:"createdAt"`
+	CreatedBy  *uint     `json:"createdBy,omitempty"`
+}
@@ -0,0 +1,18 @@
+package domain
+
+import "time"
+
+// TutorialTag entity - maps to 'tutorial_tags' table (junction behind Tutorial.Tags, with extra fields)
+type TutorialTag struct {
+	ID         uint      `gorm:"primaryKey"`
+	TutorialID uint      `gorm:"column:tutorial_id;not null;uniqueIndex:idx_tutorial_tag"`
+	Tutorial   *Tutorial `gorm:"foreignKey:TutorialID;constraint:OnDelete:CASCADE"`
+	TagID      uint      `gorm:"column:tag_id;not null;uniqueIndex:idx_tutorial_tag"`
+	Tag        *Tag      `gorm:"foreignKey:TagID;constraint:OnDelete:CASCADE"`
+	CreatedAt  time.Time `gorm:"column:created_at;autoCreateTime"`
+	CreatedBy  *uint     `gorm:"column:created_by"`
+}
+
+func (TutorialTag) TableName() string {
+	return "tutorial_tags"
+}
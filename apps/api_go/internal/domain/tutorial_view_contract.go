@@ -0,0 +1,11 @@
+package domain
+
+import "time"
+
+// TutorialViewRepository stores buffered view counts
+type TutorialViewRepository interface {
+	// AddViews adds the counts to the daily rows and to tutorials.views in one transaction
+	AddViews(counts []TutorialViewDaily) error
+	// FindDaily returns the days with views in [from, to], oldest first
+	FindDaily(tutorialID uint, from, to time.Time) ([]TutorialViewDaily, error)
+}
@@ -0,0 +1,15 @@
+package domain
+
+import "time"
+
+// TutorialViewDaily entity - maps to 'tutorial_view_daily' table (deduplicated views per UTC day)
+type TutorialViewDaily struct {
+	TutorialID uint      `gorm:"column:tutorial_id;primaryKey;autoIncrement:false"`
+	Tutorial   *Tutorial `gorm:"foreignKey:TutorialID;constraint:OnDelete:CASCADE"`
+	Day        time.Time `gorm:"column:day;type:date;primaryKey"`
+	Views      int64     `gorm:"column:views;not null;default:0"`
+}
+
+func (TutorialViewDaily) TableName() string {
+	return "tutorial_view_daily"
+}
@@ -6,11 +6,13 @@ type VideoService interface {
 	FindAll() ([]VideoResponseDTO, error)
 	FindOne(id uint) (*VideoResponseDTO, error)
 	FindByYoutubeID(youtubeID string) (*VideoResponseDTO, error)
-	Update(id uint, dto UpdateVideoDTO) (*VideoResponseDTO, error)
-	Remove(id uint) error
+	Update(id uint, dto UpdateVideoDTO, actor Actor) (*VideoResponseDTO, error)
+	Remove(id uint, actor Actor) error
 	FindByUploaderID(uploaderID uint) ([]VideoResponseDTO, error)
 	FindByTagID(tagID uint) ([]VideoResponseDTO, error)
 	FindByTagName(tagName string) ([]VideoResponseDTO, error)
+	// FindPageByUploaderID lists an uploader's videos, newest first
+	FindPageByUploaderID(uploaderID uint, params AuthorContentParams) (*AuthorVideosDTO, error)
 }
 
 // VideoRepository interface - returns entities
@@ -22,4 +24,7 @@ type VideoRepository interface {
 	Update(id uint, video *Video) error
 	Delete(id uint) error
 	FindByUploaderID(uploaderID uint) ([]Video, error)
+	// FindPageByUploaderID returns up to limit videos with an ID below cursor (nil for the first page)
+	FindPageByUploaderID(uploaderID uint, cursor *uint, limit int) ([]Video, error)
+	CountByUploaderID(uploaderID uint) (int64, error)
 }
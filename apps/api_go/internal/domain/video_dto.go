@@ -11,7 +11,7 @@ type CreateVideoDTO struct {
 	Description  *string         `json:"description,omitempty"`
 	ThumbnailURL *string         `json:"thumbnailUrl,omitempty"`
 	Duration     *int64          `json:"duration,omitempty"`
-	UploaderID   *uint           `json:"uploaderId,omitempty"`
+	UploaderID   *uint           `json:"-"` // set from the authenticated user
 	ChannelTitle *string         `json:"channelTitle,omitempty"`
 	Metadata     json.RawMessage `json:"metadata,omitempty"`
 }
@@ -22,7 +22,6 @@ type UpdateVideoDTO struct {
 	Description  *string         `json:"description,omitempty"`
 	ThumbnailURL *string         `json:"thumbnailUrl,omitempty"`
 	Duration     *int64          `json:"duration,omitempty"`
-	UploaderID   *uint           `json:"uploaderId,omitempty"`
 	ChannelTitle *string         `json:"channelTitle,omitempty"`
 	Metadata     json.RawMessage `json:"metadata,omitempty"`
 }
@@ -2,9 +2,9 @@ package domain
 
 // VideoTagService interface
 type VideoTagService interface {
-	AttachOne(dto CreateVideoTagDTO, createdBy *uint) (*VideoTagResponseDTO, error)
-	DetachOne(videoID, tagID uint) error
-	UpsertForVideo(dto UpsertVideoTagsDTO, createdBy *uint) ([]TagResponseDTO, error)
+	AttachOne(dto CreateVideoTagDTO, actor Actor) (*VideoTagResponseDTO, error)
+	DetachOne(videoID, tagID uint, actor Actor) error
+	UpsertForVideo(dto UpsertVideoTagsDTO, actor Actor) ([]TagResponseDTO, error)
 	FindTagsByVideo(videoID uint) ([]TagResponseDTO, error)
 	FindVideosByTag(tagID uint) ([]VideoResponseDTO, error)
 	FindVideosByTagName(tagName string) ([]VideoResponseDTO, error)
@@ -5,8 +5,8 @@ type VoteService interface {
 	Create(dto CreateVoteDTO) (*VoteResponseDTO, error)
 	FindAll() ([]VoteResponseDTO, error)
 	FindOne(id uint) (*VoteResponseDTO, error)
-	Update(id uint, dto UpdateVoteDTO) (*VoteResponseDTO, error)
-	Remove(id uint) error
+	Update(id uint, dto UpdateVoteDTO, actor Actor) (*VoteResponseDTO, error)
+	Remove(id uint, actor Actor) error
 	FindByEntity(entityType EntityType, entityID int64) ([]VoteResponseDTO, error)
 	FindByUser(userID uint) ([]VoteResponseDTO, error)
 	FindUserVoteOnEntity(userID uint, entityType EntityType, entityID int64) (*VoteResponseDTO, error)
@@ -26,4 +26,6 @@ type VoteRepository interface {
 	FindByEntity(entityType EntityType, entityID int64) ([]Vote, error)
 	FindByUser(userID uint) ([]Vote, error)
 	CountByEntity(entityType EntityType, entityID int64, voteType VoteType) (int64, error)
+	// CountReceivedByAuthor counts votes of a type on the tutorials and videos an account authored
+	CountReceivedByAuthor(authorID uint, voteType VoteType) (int64, error)
 }
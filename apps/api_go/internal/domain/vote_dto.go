@@ -1,7 +1,7 @@
 package domain
 
 type CreateVoteDTO struct {
-	UserID     uint       `json:"userId" binding:"required"`
+	UserID     uint       `json:"-"` // set from the authenticated user
 	EntityID   int64      `json:"entityId" binding:"required"`
 	EntityType EntityType `json:"entityType" binding:"required"`
 	VoteType   VoteType   `json:"voteType" binding:"required"`
@@ -0,0 +1,110 @@
+package markdown
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// admonitionMarker matches the first line of a GitHub-style alert ("> [!NOTE]")
+var admonitionMarker = regexp.MustCompile(`(?i)^\[!(note|tip|important|warning|caution)\]$`)
+
+// KindAdmonition is the node kind of an alert block
+var KindAdmonition = ast.NewNodeKind("Admonition")
+
+// Admonition is a blockquote turned into a callout box
+type Admonition struct {
+	ast.BaseBlock
+	Alert string // note, tip, important, warning or caution
+}
+
+// Kind implements ast.Node
+func (n *Admonition) Kind() ast.NodeKind {
+	return KindAdmonition
+}
+
+// Dump implements ast.Node
+func (n *Admonition) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, map[string]string{"Alert": n.Alert}, nil)
+}
+
+// admonitionTransformer replaces blockquotes starting with an alert marker line
+type admonitionTransformer struct{}
+
+func (admonitionTransformer) Transform(doc *ast.Document, reader text.Reader, _ parser.Context) {
+	source := reader.Source()
+
+	var quotes []*ast.Blockquote
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if quote, ok := n.(*ast.Blockquote); ok && entering {
+			quotes = append(quotes, quote)
+		}
+		return ast.WalkContinue, nil
+	})
+
+	for _, quote := range quotes {
+		para, ok := quote.FirstChild().(*ast.Paragraph)
+		if !ok || para.Lines().Len() == 0 {
+			continue
+		}
+		first := para.Lines().At(0)
+		match := admonitionMarker.FindStringSubmatch(strings.TrimSpace(string(first.Value(source))))
+		if match == nil {
+			continue
+		}
+
+		// Drop the marker line; the paragraph goes away when it held nothing else
+		for child := para.FirstChild(); child != nil; {
+			t, ok := child.(*ast.Text)
+			if !ok || t.Segment.Stop > first.Stop {
+				break
+			}
+			next := child.NextSibling()
+			para.RemoveChild(para, child)
+			child = next
+		}
+		if para.ChildCount() == 0 {
+			quote.RemoveChild(quote, para)
+		}
+
+		box := &Admonition{Alert: strings.ToLower(match[1])}
+		for child := quote.FirstChild(); child != nil; {
+			next := child.NextSibling()
+			box.AppendChild(box, child)
+			child = next
+		}
+		quote.Parent().ReplaceChild(quote.Parent(), quote, box)
+	}
+}
+
+// admonitionRenderer writes <div class="admonition admonition-KIND"> with a title paragraph
+type admonitionRenderer struct{}
+
+func (admonitionRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(KindAdmonition, renderAdmonition)
+}
+
+func renderAdmonition(w util.BufWriter, _ []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	n := node.(*Admonition)
+	if entering {
+		_, _ = w.WriteString(`<div class="admonition admonition-` + n.Alert + `">` + "\n")
+		_, _ = w.WriteString(`<p class="admonition-title">` + strings.ToUpper(n.Alert[:1]) + n.Alert[1:] + "</p>\n")
+	} else {
+		_, _ = w.WriteString("</div>\n")
+	}
+	return ast.WalkContinue, nil
+}
+
+// admonitions is the goldmark extension wiring the transformer and renderer
+type admonitions struct{}
+
+func (admonitions) Extend(m goldmark.Markdown) {
+	m.Parser().AddOptions(parser.WithASTTransformers(util.Prioritized(admonitionTransformer{}, 100)))
+	m.Renderer().AddOptions(renderer.WithNodeRenderers(util.Prioritized(admonitionRenderer{}, 100)))
+}
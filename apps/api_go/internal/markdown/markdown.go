@@ -0,0 +1,203 @@
+// Package markdown renders tutorial Markdown to sanitized HTML with a table of contents.
+package markdown
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"fmt"
+	"regexp"
+	"sync"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
+
+	"api_go/internal/slug"
+)
+
+// Heading is one table of contents entry; ID is the anchor of the heading in the HTML
+type Heading struct {
+	Level int
+	Text  string
+	ID    string
+}
+
+// Document is the rendered form of a Markdown source. It is shared through the cache,
+// so callers must not modify it.
+type Document struct {
+	HTML string
+	TOC  []Heading
+}
+
+// Renderer converts Markdown (GFM tables, task lists, fenced code, GitHub-style alerts)
+// and keeps the most recently rendered documents keyed by a hash of their source
+type Renderer struct {
+	md     goldmark.Markdown
+	policy *bluemonday.Policy
+
+	mu       sync.Mutex
+	capacity int
+	entries  map[[sha256.Size]byte]*list.Element
+	order    *list.List // front is the most recently used
+}
+
+type cacheEntry struct {
+	key [sha256.Size]byte
+	doc *Document
+}
+
+// NewRenderer creates a Renderer caching up to cacheSize documents (0 disables the cache)
+func NewRenderer(cacheSize int) *Renderer {
+	return &Renderer{
+		md: goldmark.New(
+			goldmark.WithExtensions(extension.GFM, admonitions{}),
+			goldmark.WithParserOptions(parser.WithAutoHeadingID()),
+		),
+		policy:   newPolicy(),
+		capacity: cacheSize,
+		entries:  make(map[[sha256.Size]byte]*list.Element),
+		order:    list.New(),
+	}
+}
+
+// newPolicy allows user-generated content plus the markup the renderer itself produces
+func newPolicy() *bluemonday.Policy {
+	p := bluemonday.UGCPolicy()
+	p.AllowAttrs("id").Matching(regexp.MustCompile(`^[a-z0-9-]+$`)).OnElements("h1", "h2", "h3", "h4", "h5", "h6")
+	p.AllowAttrs("class").Matching(regexp.MustCompile(`^language-[\w.+#-]+$`)).OnElements("code")
+	p.AllowAttrs("class").Matching(regexp.MustCompile(`^admonition admonition-(note|tip|important|warning|caution)$`)).OnElements("div")
+	p.AllowAttrs("class").Matching(regexp.MustCompile(`^admonition-title$`)).OnElements("p")
+	p.AllowAttrs("type").Matching(regexp.MustCompile(`^checkbox$`)).OnElements("input")
+	p.AllowAttrs("checked", "disabled").OnElements("input")
+	return p
+}
+
+// Render returns the sanitized HTML and table of contents of source
+func (r *Renderer) Render(source string) (*Document, error) {
+	key := sha256.Sum256([]byte(source))
+	if doc, ok := r.cached(key); ok {
+		return doc, nil
+	}
+
+	src := []byte(source)
+	ctx := parser.NewContext(parser.WithIDs(newHeadingIDs()))
+	root := r.md.Parser().Parse(text.NewReader(src), parser.WithContext(ctx))
+
+	var buf bytes.Buffer
+	if err := r.md.Renderer().Render(&buf, src, root); err != nil {
+		return nil, fmt.Errorf("render markdown: %w", err)
+	}
+
+	doc := &Document{
+		HTML: r.policy.Sanitize(buf.String()),
+		TOC:  tableOfContents(root, src),
+	}
+	r.store(key, doc)
+	return doc, nil
+}
+
+func (r *Renderer) cached(key [sha256.Size]byte) (*Document, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	element, ok := r.entries[key]
+	if !ok {
+		return nil, false
+	}
+	r.order.MoveToFront(element)
+	return element.Value.(*cacheEntry).doc, true
+}
+
+func (r *Renderer) store(key [sha256.Size]byte, doc *Document) {
+	if r.capacity <= 0 {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if element, ok := r.entries[key]; ok {
+		r.order.MoveToFront(element)
+		return
+	}
+	r.entries[key] = r.order.PushFront(&cacheEntry{key: key, doc: doc})
+	for r.order.Len() > r.capacity {
+		oldest := r.order.Back()
+		r.order.Remove(oldest)
+		delete(r.entries, oldest.Value.(*cacheEntry).key)
+	}
+}
+
+// tableOfContents lists the headings in document order
+func tableOfContents(root ast.Node, source []byte) []Heading {
+	var toc []Heading
+	_ = ast.Walk(root, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		heading, ok := n.(*ast.Heading)
+		if !ok || !entering {
+			return ast.WalkContinue, nil
+		}
+		entry := Heading{Level: heading.Level, Text: plainText(heading, source)}
+		if id, ok := heading.AttributeString("id"); ok {
+			if b, ok := id.([]byte); ok {
+				entry.ID = string(b)
+			}
+		}
+		toc = append(toc, entry)
+		return ast.WalkSkipChildren, nil
+	})
+	return toc
+}
+
+// plainText concatenates the text of the inline children of n
+func plainText(n ast.Node, source []byte) string {
+	var buf bytes.Buffer
+	_ = ast.Walk(n, func(child ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch t := child.(type) {
+		case *ast.Text:
+			buf.Write(t.Segment.Value(source))
+			if t.SoftLineBreak() {
+				buf.WriteByte(' ')
+			}
+		case *ast.String:
+			buf.Write(t.Value)
+		}
+		return ast.WalkContinue, nil
+	})
+	return buf.String()
+}
+
+// headingIDs generates anchors with the same rules as tutorial slugs, adding -2, -3, ...
+// for repeated headings
+type headingIDs struct {
+	used map[string]bool
+}
+
+func newHeadingIDs() *headingIDs {
+	return &headingIDs{used: make(map[string]bool)}
+}
+
+// Generate implements parser.IDs
+func (ids *headingIDs) Generate(value []byte, _ ast.NodeKind) []byte {
+	base := slug.Make(string(value))
+	if base == "" {
+		base = "section"
+	}
+	id := base
+	for n := 2; ids.used[id]; n++ {
+		id = fmt.Sprintf("%s-%d", base, n)
+	}
+	ids.used[id] = true
+	return []byte(id)
+}
+
+// Put implements parser.IDs
+func (ids *headingIDs) Put(value []byte) {
+	ids.used[string(value)] = true
+}
@@ -0,0 +1,157 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderStripsUnsafeMarkup(t *testing.T) {
+	r := NewRenderer(0)
+
+	tests := []struct {
+		name    string
+		source  string
+		absent  []string
+		present []string
+	}{
+		{"script tag", "<script>alert(1)</script>\n\ntext", []string{"<script", "alert(1)"}, []string{"<p>text</p>"}},
+		{"javascript link", "[click](javascript:alert(1))", []string{"javascript:", "href"}, []string{"click"}},
+		{"event handler", `<a href="https://example.com" onclick="evil()">a</a>`, []string{"onclick", "evil()"}, nil},
+		{"image onerror", `<img src="x" onerror="evil()">`, []string{"onerror", "evil()"}, nil},
+		{"raw heading", `<h2 id="login-form">x</h2>`, []string{"login-form"}, nil},
+		{"safe link", "[docs](https://example.com)", nil, []string{`href="https://example.com"`, `rel="nofollow"`}},
+		{"task list", "- [x] done", nil, []string{`<input checked="" disabled="" type="checkbox"`}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := r.Render(tt.source)
+			if err != nil {
+				t.Fatalf("Render: %v", err)
+			}
+			for _, s := range tt.absent {
+				if strings.Contains(doc.HTML, s) {
+					t.Errorf("HTML %q contains %q", doc.HTML, s)
+				}
+			}
+			for _, s := range tt.present {
+				if !strings.Contains(doc.HTML, s) {
+					t.Errorf("HTML %q is missing %q", doc.HTML, s)
+				}
+			}
+		})
+	}
+}
+
+func TestPolicyAllowList(t *testing.T) {
+	policy := newPolicy()
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"heading id", `<h2 id="getting-started">x</h2>`, `<h2 id="getting-started">x</h2>`},
+		{"code language", `<code class="language-c++">x</code>`, `<code class="language-c++">x</code>`},
+		{"code other class", `<code class="language-go evil">x</code>`, `<code>x</code>`},
+		{"admonition box", `<div class="admonition admonition-note">x</div>`, `<div class="admonition admonition-note">x</div>`},
+		{"unknown admonition", `<div class="admonition admonition-evil">x</div>`, `<div>x</div>`},
+		{"admonition title", `<p class="admonition-title">Note</p>`, `<p class="admonition-title">Note</p>`},
+		{"other paragraph class", `<p class="hidden">x</p>`, `<p>x</p>`},
+		{"checkbox", `<input type="checkbox" checked disabled>`, `<input type="checkbox" checked="" disabled="">`},
+		{"text input", `<input type="text" value="x">`, ``},
+	}
+	for _, tt := range tests {
+		if got := policy.Sanitize(tt.input); got != tt.want {
+			t.Errorf("%s: Sanitize(%q) = %q, want %q", tt.name, tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestRenderAdmonitions(t *testing.T) {
+	r := NewRenderer(0)
+
+	tests := []struct {
+		name   string
+		source string
+		want   string
+	}{
+		{
+			"note with body",
+			"> [!NOTE]\n> Body text",
+			"<div class=\"admonition admonition-note\">\n<p class=\"admonition-title\">Note</p>\n<p>Body text</p>\n</div>\n",
+		},
+		{
+			"lowercase marker only",
+			"> [!warning]",
+			"<div class=\"admonition admonition-warning\">\n<p class=\"admonition-title\">Warning</p>\n</div>\n",
+		},
+		{
+			"marker then blank line",
+			"> [!TIP]\n>\n> Second paragraph",
+			"<div class=\"admonition admonition-tip\">\n<p class=\"admonition-title\">Tip</p>\n<p>Second paragraph</p>\n</div>\n",
+		},
+		{
+			"unknown marker",
+			"> [!DANGER]\n> x",
+			"<blockquote>\n<p>[!DANGER]\nx</p>\n</blockquote>\n",
+		},
+		{
+			"plain quote",
+			"> quoted",
+			"<blockquote>\n<p>quoted</p>\n</blockquote>\n",
+		},
+		{
+			"marker not on the first line",
+			"> text\n> [!NOTE]",
+			"<blockquote>\n<p>text\n[!NOTE]</p>\n</blockquote>\n",
+		},
+	}
+	for _, tt := range tests {
+		doc, err := r.Render(tt.source)
+		if err != nil {
+			t.Fatalf("%s: Render: %v", tt.name, err)
+		}
+		if doc.HTML != tt.want {
+			t.Errorf("%s: HTML = %q, want %q", tt.name, doc.HTML, tt.want)
+		}
+	}
+}
+
+func TestRenderTableOfContents(t *testing.T) {
+	doc, err := NewRenderer(0).Render("# Intro\n\n## Setup *fast*\n\n### Intro\n\n## Intro\n\n## `code` & more\n")
+	if err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+
+	want := []Heading{
+		{Level: 1, Text: "Intro", ID: "intro"},
+		{Level: 2, Text: "Setup fast", ID: "setup-fast"},
+		{Level: 3, Text: "Intro", ID: "intro-2"},
+		{Level: 2, Text: "Intro", ID: "intro-3"},
+		{Level: 2, Text: "code & more", ID: "code-more"},
+	}
+	if len(doc.TOC) != len(want) {
+		t.Fatalf("TOC = %+v, want %+v", doc.TOC, want)
+	}
+	for i := range want {
+		if doc.TOC[i] != want[i] {
+			t.Errorf("TOC[%d] = %+v, want %+v", i, doc.TOC[i], want[i])
+		}
+		if !strings.Contains(doc.HTML, `id="`+want[i].ID+`"`) {
+			t.Errorf("HTML has no anchor %q", want[i].ID)
+		}
+	}
+}
+
+func TestRenderCachesDocuments(t *testing.T) {
+	r := NewRenderer(1)
+
+	first, _ := r.Render("# A")
+	if again, _ := r.Render("# A"); again != first {
+		t.Error("a cached source must return the cached document")
+	}
+	_, _ = r.Render("# B")
+	if again, _ := r.Render("# A"); again == first {
+		t.Error("the least recently used document must be evicted")
+	}
+}
@@ -0,0 +1,116 @@
+package markdown
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"unicode"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/text"
+)
+
+// wordsPerMinute is the reading speed behind Stats.ReadingMinutes
+const wordsPerMinute = 200
+
+// languageAliases maps common fence labels to one name, so ?lang=go also finds ```golang
+var languageAliases = map[string]string{
+	"golang":     "go",
+	"js":         "javascript",
+	"jsx":        "javascript",
+	"ts":         "typescript",
+	"tsx":        "typescript",
+	"py":         "python",
+	"rb":         "ruby",
+	"rs":         "rust",
+	"sh":         "bash",
+	"shell":      "bash",
+	"zsh":        "bash",
+	"yml":        "yaml",
+	"c++":        "cpp",
+	"cs":         "csharp",
+	"c#":         "csharp",
+	"kt":         "kotlin",
+	"postgresql": "sql",
+	"dockerfile": "docker",
+}
+
+// statsParser only parses, so it skips the renderer extensions
+var statsParser = goldmark.New(goldmark.WithExtensions(extension.GFM, admonitions{})).Parser()
+
+// Stats describes the length and the code of a Markdown document
+type Stats struct {
+	// Words counts prose and code alike
+	Words int
+	// ReadingMinutes is Words at 200 words per minute, rounded up, at least 1
+	ReadingMinutes int
+	// CodeLanguages lists the normalized languages of the fenced code blocks in order of first use
+	CodeLanguages []string
+}
+
+// Analyze counts the words of source and collects the languages of its fenced code blocks
+func Analyze(source string) Stats {
+	src := []byte(source)
+	root := statsParser.Parse(text.NewReader(src))
+
+	stats := Stats{CodeLanguages: []string{}}
+	seen := make(map[string]bool)
+	_ = ast.Walk(root, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch node := n.(type) {
+		case *ast.Text:
+			stats.Words += countWords(node.Segment.Value(src))
+		case *ast.String:
+			stats.Words += countWords(node.Value)
+		case *ast.FencedCodeBlock:
+			stats.Words += countLines(node.Lines(), src)
+			if language := NormalizeLanguage(string(node.Language(src))); language != "" && !seen[language] {
+				seen[language] = true
+				stats.CodeLanguages = append(stats.CodeLanguages, language)
+			}
+		case *ast.CodeBlock:
+			stats.Words += countLines(node.Lines(), src)
+		}
+		return ast.WalkContinue, nil
+	})
+
+	stats.ReadingMinutes = int(math.Ceil(float64(stats.Words) / wordsPerMinute))
+	if stats.ReadingMinutes < 1 {
+		stats.ReadingMinutes = 1
+	}
+	return stats
+}
+
+// NormalizeLanguage lowercases a fence label and resolves aliases ("Golang" -> "go")
+func NormalizeLanguage(language string) string {
+	language = strings.ToLower(strings.TrimSpace(language))
+	if alias, ok := languageAliases[language]; ok {
+		return alias
+	}
+	return language
+}
+
+// countWords skips fields without a letter or digit, such as the "." left over after
+// **bold**. or a `code` span
+func countWords(prose []byte) int {
+	words := 0
+	for _, field := range bytes.Fields(prose) {
+		if bytes.IndexFunc(field, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }) >= 0 {
+			words++
+		}
+	}
+	return words
+}
+
+func countLines(lines *text.Segments, source []byte) int {
+	words := 0
+	for i := 0; i < lines.Len(); i++ {
+		segment := lines.At(i)
+		words += len(bytes.Fields(segment.Value(source)))
+	}
+	return words
+}
@@ -0,0 +1,61 @@
+package markdown
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestAnalyze(t *testing.T) {
+	tests := []struct {
+		name      string
+		source    string
+		words     int
+		minutes   int
+		languages []string
+	}{
+		{"empty", "", 0, 1, []string{}},
+		{"prose", "# Title\n\nOne two three,\nfour **five**.", 6, 1, []string{}},
+		{"punctuation only", "Wait -- what?! ...", 2, 1, []string{}},
+		{"links and inline code", "See [the docs](https://example.com) and `go test`.", 6, 1, []string{}},
+		{"fence without language", "```\nplain text here\n```", 3, 1, []string{}},
+		{"indented code", "text\n\n    x := 1\n", 4, 1, []string{}},
+		{"code only", "```go\nfmt.Println(x)\nreturn nil\n```", 3, 1, []string{"go"}},
+		{"duplicate languages", "```go\na\n```\n\n```Golang\nb\n```\n\n```sh\nc\n```\n\n```bash\nd\n```", 4, 1, []string{"go", "bash"}},
+		{"first use order", "```py\na\n```\n\n```ts\nb\n```\n\n```python\nc\n```", 3, 1, []string{"python", "typescript"}},
+		{"fence info after language", "```js title=app.js\nx\n```", 1, 1, []string{"javascript"}},
+		{"exactly one minute", strings.Repeat("word ", 200), 200, 1, []string{}},
+		{"rounds up", strings.Repeat("word ", 201), 201, 2, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Analyze(tt.source)
+			if got.Words != tt.words {
+				t.Errorf("Words = %d, want %d", got.Words, tt.words)
+			}
+			if got.ReadingMinutes != tt.minutes {
+				t.Errorf("ReadingMinutes = %d, want %d", got.ReadingMinutes, tt.minutes)
+			}
+			if !slices.Equal(got.CodeLanguages, tt.languages) || got.CodeLanguages == nil {
+				t.Errorf("CodeLanguages = %#v, want %#v", got.CodeLanguages, tt.languages)
+			}
+		})
+	}
+}
+
+func TestNormalizeLanguage(t *testing.T) {
+	tests := map[string]string{
+		"go":      "go",
+		"Golang":  "go",
+		" TSX ":   "typescript",
+		"C++":     "cpp",
+		"c#":      "csharp",
+		"haskell": "haskell",
+		"":        "",
+	}
+	for input, want := range tests {
+		if got := NormalizeLanguage(input); got != want {
+			t.Errorf("NormalizeLanguage(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+// Package mention finds @handle mentions in comments and tutorial Markdown.
+package mention
+
+import (
+	"regexp"
+	"strings"
+)
+
+// MaxHandles caps the mentions taken from one text so it cannot fan out into a huge lookup
+const MaxHandles = 50
+
+var (
+	// handleRef matches "@" plus a handle (see account handles) at the start of the text or after a
+	// character that cannot be part of an email address or URL
+	handleRef = regexp.MustCompile(`(?:^|[^\w@./-])@([A-Za-z0-9](?:[A-Za-z0-9_-]*[A-Za-z0-9])?)`)
+	// codeSpan removes fenced and inline code, where "@" is a decorator or an annotation
+	codeSpan = regexp.MustCompile("(?s)```.*?```|(?s)~~~.*?~~~|`[^`\n]*`")
+)
+
+// Extract returns the lowercase handles mentioned in the texts, each once, in order of appearance.
+// Handles are not checked against accounts; that is up to the caller.
+func Extract(texts ...string) []string {
+	var handles []string
+	seen := make(map[string]bool)
+	for _, text := range texts {
+		text = codeSpan.ReplaceAllString(text, " ")
+		found := 0
+		for _, match := range handleRef.FindAllStringSubmatchIndex(text, -1) {
+			if found == MaxHandles {
+				break
+			}
+			end := match[3]
+			// "@name@host" and similar are not mentions
+			if end < len(text) && text[end] == '@' {
+				continue
+			}
+			handle := strings.ToLower(text[match[2]:end])
+			found++
+			if seen[handle] {
+				continue
+			}
+			seen[handle] = true
+			handles = append(handles, handle)
+		}
+	}
+	return handles
+}
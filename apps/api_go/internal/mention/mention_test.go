@@ -0,0 +1,54 @@
+package mention
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestExtract(t *testing.T) {
+	tests := []struct {
+		name  string
+		texts []string
+		want  []string
+	}{
+		{"none", []string{"no mentions here"}, nil},
+		{"start of text", []string{"@alice hi"}, []string{"alice"}},
+		{"lowercased", []string{"thanks @Alice and @BOB"}, []string{"alice", "bob"}},
+		{"duplicates in order", []string{"@bob @alice @Bob @alice"}, []string{"bob", "alice"}},
+		{"across texts", []string{"@alice", "@bob and @ALICE"}, []string{"alice", "bob"}},
+		{"trailing punctuation", []string{"cc @alice, @bob. (@carol) @dave!"}, []string{"alice", "bob", "carol", "dave"}},
+		{"trailing dash or underscore", []string{"@alice- and @bob_"}, []string{"alice", "bob"}},
+		{"inner dash and underscore", []string{"@jane-doe @john_doe"}, []string{"jane-doe", "john_doe"}},
+		{"email", []string{"mail alice@example.com"}, nil},
+		{"user at host", []string{"@alice@example.com"}, nil},
+		{"url path", []string{"see https://example.com/@alice"}, nil},
+		{"after word character", []string{"a@alice"}, nil},
+		{"bare at", []string{"@ alone, @-dash"}, nil},
+		{"inline code", []string{"use `@Override` like @alice"}, []string{"alice"}},
+		{"fenced code", []string{"```java\n@Override\n```\n~~~\n@Test\n~~~\n@bob"}, []string{"bob"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Extract(tt.texts...); !slices.Equal(got, tt.want) {
+				t.Errorf("Extract(%q) = %q, want %q", tt.texts, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractCapsHandlesPerText(t *testing.T) {
+	var refs []string
+	for i := 0; i < MaxHandles+10; i++ {
+		refs = append(refs, fmt.Sprintf("@user%d", i))
+	}
+
+	got := Extract(strings.Join(refs, " "), "@later")
+	if len(got) != MaxHandles+1 {
+		t.Fatalf("got %d handles, want %d", len(got), MaxHandles+1)
+	}
+	if got[MaxHandles-1] != fmt.Sprintf("user%d", MaxHandles-1) || got[MaxHandles] != "later" {
+		t.Errorf("handles end with %q", got[MaxHandles-1:])
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"api_go/internal/domain"
+	"api_go/internal/modules/auth/middleware"
 )
 
 type AccountController struct {
@@ -18,16 +19,28 @@ func NewAccountController(service domain.AccountService) *AccountController {
 	return &AccountController{service: service}
 }
 
-// RegisterRoutes registers all account routes
-func (ctrl *AccountController) RegisterRoutes(r *gin.RouterGroup) {
-	accounts := r.Group("/accounts")
+// RegisterRoutes registers the admin account routes and the caller's own profile routes
+func (ctrl *AccountController) RegisterRoutes(r *gin.RouterGroup, policy *middleware.RoutePolicy) {
+	accounts := r.Group("/accounts", policy.Admins())
 	{
 		accounts.POST("", ctrl.Create)
 		accounts.GET("", ctrl.FindAll)
 		accounts.GET("/:id", ctrl.FindOne)
 		accounts.PATCH("/:id", ctrl.Update)
+		accounts.PATCH("/:id/role", ctrl.UpdateRole)
 		accounts.DELETE("/:id", ctrl.Remove)
 	}
+
+	admin := r.Group("/admin/accounts", policy.Admins())
+	{
+		admin.GET("", ctrl.List)
+	}
+
+	profile := r.Group("/me/profile", policy.Authenticated())
+	{
+		profile.GET("", ctrl.GetProfile)
+		profile.PATCH("", ctrl.UpdateProfile)
+	}
 }
 
 // Create handles POST /accounts
@@ -39,6 +52,7 @@ func (ctrl *AccountController) RegisterRoutes(r *gin.RouterGroup) {
 // @Success 201 {object} domain.AccountResponseDTO
 // @Failure 400 {object} map[string]string
 // @Failure 409 {object} map[string]string
+// @Security BearerAuth
 // @Router /accounts [post]
 func (ctrl *AccountController) Create(c *gin.Context) {
 	var dto domain.CreateAccountDTO
@@ -66,6 +80,7 @@ func (ctrl *AccountController) Create(c *gin.Context) {
 // @Produce json
 // @Success 200 {array} domain.AccountResponseDTO
 // @Failure 500 {object} map[string]string
+// @Security BearerAuth
 // @Router /accounts [get]
 func (ctrl *AccountController) FindAll(c *gin.Context) {
 	accounts, err := ctrl.service.FindAll()
@@ -77,6 +92,47 @@ func (ctrl *AccountController) FindAll(c *gin.Context) {
 	c.JSON(http.StatusOK, accounts)
 }
 
+// List handles GET /admin/accounts
+// @Summary List accounts for the admin console
+// @Description Filter by role, status, linked provider ("password" for accounts with a password), creation range and email/name/handle prefix; sort by created_at, last_login_at, email or name. Pass nextCursor to get the next page.
+// @Tags admin
+// @Produce json
+// @Param role query string false "user, mod or admin"
+// @Param status query string false "active, banned or deleted"
+// @Param provider query string false "OAuth provider or password"
+// @Param created_from query string false "RFC 3339 time (inclusive)"
+// @Param created_to query string false "RFC 3339 time (exclusive)"
+// @Param q query string false "Email, name or handle prefix"
+// @Param sort query string false "created_at (default), last_login_at, email or name"
+// @Param order query string false "desc (default) or asc"
+// @Param limit query int false "Page size (default 20, max 100)"
+// @Param cursor query string false "nextCursor of the previous page"
+// @Success 200 {object} domain.AccountListDTO
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Security BearerAuth
+// @Router /admin/accounts [get]
+func (ctrl *AccountController) List(c *gin.Context) {
+	var params domain.AccountListParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := ctrl.service.List(params)
+	if err != nil {
+		if err.Error() == "invalid cursor" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
 // FindOne handles GET /accounts/:id
 // @Summary Get an account by ID
 // @Tags accounts
@@ -85,6 +141,7 @@ func (ctrl *AccountController) FindAll(c *gin.Context) {
 // @Success 200 {object} domain.AccountResponseDTO
 // @Failure 400 {object} map[string]string
 // @Failure 404 {object} map[string]string
+// @Security BearerAuth
 // @Router /accounts/{id} [get]
 func (ctrl *AccountController) FindOne(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
@@ -116,6 +173,7 @@ func (ctrl *AccountController) FindOne(c *gin.Context) {
 // @Success 200 {object} domain.AccountResponseDTO
 // @Failure 400 {object} map[string]string
 // @Failure 404 {object} map[string]string
+// @Security BearerAuth
 // @Router /accounts/{id} [patch]
 func (ctrl *AccountController) Update(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
@@ -143,6 +201,127 @@ func (ctrl *AccountController) Update(c *gin.Context) {
 	c.JSON(http.StatusOK, account)
 }
 
+// UpdateRole handles PATCH /accounts/:id/role
+// @Summary Change an account's role
+// @Description Admins cannot change their own role. The account is signed out of every session and gets the new role at its next login.
+// @Tags accounts
+// @Accept json
+// @Produce json
+// @Param id path int true "Account ID"
+// @Param dto body domain.UpdateAccountRoleDTO true "New role"
+// @Success 200 {object} domain.AccountResponseDTO
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Security BearerAuth
+// @Router /accounts/{id}/role [patch]
+func (ctrl *AccountController) UpdateRole(c *gin.Context) {
+	actor, ok := middleware.GetActor(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	var dto domain.UpdateAccountRoleDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	account, err := ctrl.service.UpdateRole(actor.ID, uint(id), dto)
+	if err != nil {
+		switch err.Error() {
+		case "cannot change your own role":
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case "record not found":
+			c.JSON(http.StatusNotFound, gin.H{"error": "account not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, account)
+}
+
+// GetProfile handles GET /me/profile
+// @Summary Get my profile
+// @Tags accounts
+// @Produce json
+// @Success 200 {object} domain.ProfileResponseDTO
+// @Failure 401 {object} map[string]string
+// @Security BearerAuth
+// @Router /me/profile [get]
+func (ctrl *AccountController) GetProfile(c *gin.Context) {
+	actor, ok := middleware.GetActor(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	profile, err := ctrl.service.GetProfile(actor.ID)
+	if err != nil {
+		if err.Error() == "user not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
+
+// UpdateProfile handles PATCH /me/profile
+// @Summary Update my profile
+// @Description Update name, handle, avatar, bio and links; omitted fields are unchanged. The handle can be changed once per cooldown period.
+// @Tags accounts
+// @Accept json
+// @Produce json
+// @Param dto body domain.UpdateProfileDTO true "Profile fields"
+// @Success 200 {object} domain.ProfileResponseDTO
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Security BearerAuth
+// @Router /me/profile [patch]
+func (ctrl *AccountController) UpdateProfile(c *gin.Context) {
+	actor, ok := middleware.GetActor(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var dto domain.UpdateProfileDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	profile, err := ctrl.service.UpdateProfile(actor.ID, dto)
+	if err != nil {
+		switch err.Error() {
+		case "name cannot be empty", "avatar_url must be an http(s) URL", "links must be http(s) URLs",
+			"invalid handle", "handle is reserved":
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case "handle is already taken", "handle was changed recently":
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case "user not found":
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
+
 // Remove handles DELETE /accounts/:id
 // @Summary Delete an account
 // @Tags accounts
@@ -150,6 +329,7 @@ func (ctrl *AccountController) Update(c *gin.Context) {
 // @Success 200 {object} map[string]bool
 // @Failure 400 {object} map[string]string
 // @Failure 404 {object} map[string]string
+// @Security BearerAuth
 // @Router /accounts/{id} [delete]
 func (ctrl *AccountController) Remove(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
@@ -0,0 +1,73 @@
+package repo
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+
+	"api_go/internal/domain"
+)
+
+type identityRepository struct {
+	db *gorm.DB
+}
+
+// NewIdentityRepository creates a new AccountIdentityRepository instance
+func NewIdentityRepository(db *gorm.DB) domain.AccountIdentityRepository {
+	return &identityRepository{db: db}
+}
+
+// Create inserts a new linked identity
+func (r *identityRepository) Create(identity *domain.AccountIdentity) error {
+	return r.db.Create(identity).Error
+}
+
+// FindByProviderSubject retrieves the identity of a provider user
+func (r *identityRepository) FindByProviderSubject(provider, subject string) (*domain.AccountIdentity, error) {
+	var identity domain.AccountIdentity
+	err := r.db.Where("provider = ? AND subject = ?", provider, subject).First(&identity).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &identity, nil
+}
+
+// FindByAccount retrieves all identities linked to an account
+func (r *identityRepository) FindByAccount(accountID uint) ([]domain.AccountIdentity, error) {
+	var identities []domain.AccountIdentity
+	err := r.db.Where("account_id = ?", accountID).Order("created_at ASC").Find(&identities).Error
+	return identities, err
+}
+
+// FindByAccountAndProvider retrieves the identity an account has at a provider
+func (r *identityRepository) FindByAccountAndProvider(accountID uint, provider string) (*domain.AccountIdentity, error) {
+	var identity domain.AccountIdentity
+	err := r.db.Where("account_id = ? AND provider = ?", accountID, provider).First(&identity).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &identity, nil
+}
+
+// Delete removes an identity by ID
+func (r *identityRepository) Delete(id uint) error {
+	result := r.db.Delete(&domain.AccountIdentity{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// DeleteForAccount removes every identity linked to an account
+func (r *identityRepository) DeleteForAccount(accountID uint) error {
+	return r.db.Where("account_id = ?", accountID).Delete(&domain.AccountIdentity{}).Error
+}
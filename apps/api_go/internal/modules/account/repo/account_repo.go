@@ -2,8 +2,13 @@ package repo
 
 import (
 	"errors"
+	"fmt"
+	"strings"
+	"time"
 
+	"github.com/jackc/pgx/v5/pgconn"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"api_go/internal/domain"
 )
@@ -29,6 +34,76 @@ func (r *accountRepository) FindAll() ([]domain.Account, error) {
 	return accounts, err
 }
 
+// accountSortColumns maps the admin listing sorts to SQL (accounts that never logged in sort as oldest)
+var accountSortColumns = map[string]string{
+	"created_at":    "accounts.created_at",
+	"last_login_at": "COALESCE(accounts.last_login_at, 'epoch'::timestamptz)",
+	"email":         "accounts.email",
+	"name":          "accounts.name",
+}
+
+// likeEscaper escapes LIKE wildcards in user input (PostgreSQL's default escape character is "\")
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// FindPage filters and sorts accounts with keyset pagination on (sort column, id)
+func (r *accountRepository) FindPage(params domain.AccountListParams, after *domain.AccountCursor, limit int) ([]domain.Account, error) {
+	query := r.db.Model(&domain.Account{})
+
+	// Deleted accounts are soft-deleted, so they are only visible when asked for
+	if params.Status == string(domain.AccountStatusDeleted) {
+		query = query.Unscoped()
+	}
+	if params.Status != "" {
+		query = query.Where("accounts.status = ?", params.Status)
+	}
+	if params.Role != "" {
+		query = query.Where("accounts.role = ?", params.Role)
+	}
+	switch {
+	case params.Provider == "password":
+		query = query.Where("accounts.passwordless = ?", false)
+	case params.Provider != "":
+		query = query.Where(
+			"EXISTS (SELECT 1 FROM account_identities ai WHERE ai.account_id = accounts.id AND ai.provider = ?)",
+			params.Provider,
+		)
+	}
+	if params.CreatedFrom != nil {
+		query = query.Where("accounts.created_at >= ?", *params.CreatedFrom)
+	}
+	if params.CreatedTo != nil {
+		query = query.Where("accounts.created_at < ?", *params.CreatedTo)
+	}
+	if term := strings.ToLower(strings.TrimSpace(params.Q)); term != "" {
+		prefix := likeEscaper.Replace(term) + "%"
+		query = query.Where(
+			"(LOWER(accounts.email) LIKE ? OR LOWER(accounts.name) LIKE ? OR accounts.handle LIKE ?)",
+			prefix, prefix, prefix,
+		)
+	}
+
+	column := accountSortColumns[params.Sort]
+	direction, comparison := "DESC", "<"
+	if params.Order == "asc" {
+		direction, comparison = "ASC", ">"
+	}
+	if after != nil {
+		query = query.Where(fmt.Sprintf("(%s, accounts.id) %s (?, ?)", column, comparison), after.Value, after.ID)
+	}
+
+	var accounts []domain.Account
+	err := query.
+		Order(fmt.Sprintf("%s %s, accounts.id %s", column, direction, direction)).
+		Limit(limit).
+		Find(&accounts).Error
+	return accounts, err
+}
+
+// TouchLastLogin sets last_login_at to now
+func (r *accountRepository) TouchLastLogin(id uint) error {
+	return r.db.Model(&domain.Account{}).Where("id = ?", id).Update("last_login_at", time.Now()).Error
+}
+
 // FindOne retrieves an account by ID
 func (r *accountRepository) FindOne(id uint) (*domain.Account, error) {
 	var account domain.Account
@@ -55,6 +130,52 @@ func (r *accountRepository) FindByEmail(email string) (*domain.Account, error) {
 	return &account, nil
 }
 
+// FindByHandle retrieves an account by handle (soft-deleted accounts included, they keep their handle)
+func (r *accountRepository) FindByHandle(handle string) (*domain.Account, error) {
+	var account domain.Account
+	err := r.db.Unscoped().Where("handle = ?", handle).First(&account).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+// FindByHandles retrieves the active accounts holding any of the handles
+func (r *accountRepository) FindByHandles(handles []string) ([]domain.Account, error) {
+	var accounts []domain.Account
+	if len(handles) == 0 {
+		return accounts, nil
+	}
+	err := r.db.Where("handle IN ? AND status <> ?", handles, domain.AccountStatusDeleted).Find(&accounts).Error
+	return accounts, err
+}
+
+// FindWithoutHandle retrieves accounts whose handle is not set, oldest first
+func (r *accountRepository) FindWithoutHandle(limit int) ([]domain.Account, error) {
+	var accounts []domain.Account
+	err := r.db.Unscoped().Where("handle IS NULL OR handle = ''").Order("id").Limit(limit).Find(&accounts).Error
+	return accounts, err
+}
+
+// UpdateHandle sets the handle and the time of the user's last change
+func (r *accountRepository) UpdateHandle(id uint, handle string, changedAt *time.Time) error {
+	updates := map[string]interface{}{"handle": handle}
+	if changedAt != nil {
+		updates["handle_changed_at"] = changedAt
+	}
+	result := r.db.Unscoped().Model(&domain.Account{}).Where("id = ?", id).Updates(updates)
+	if result.Error != nil {
+		return handleError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
 // Update updates an existing account
 func (r *accountRepository) Update(id uint, update *domain.Account) error {
 	result := r.db.Model(&domain.Account{}).Where("id = ?", id).Updates(update)
@@ -67,6 +188,171 @@ func (r *accountRepository) Update(id uint, update *domain.Account) error {
 	return nil
 }
 
+// UpdatePassword sets the password hash and clears the passwordless flag
+func (r *accountRepository) UpdatePassword(id uint, passwordHash string) error {
+	result := r.db.Model(&domain.Account{}).Where("id = ?", id).
+		Updates(map[string]interface{}{"password": passwordHash, "passwordless": false})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// MarkEmailVerified sets email_verified_at if it is not set yet
+func (r *accountRepository) MarkEmailVerified(id uint) error {
+	return r.db.Model(&domain.Account{}).
+		Where("id = ? AND email_verified_at IS NULL", id).
+		Update("email_verified_at", time.Now()).Error
+}
+
+// SetTOTP updates the TOTP secret and enabled time and resets the replay counter
+func (r *accountRepository) SetTOTP(id uint, secret string, enabledAt *time.Time) error {
+	result := r.db.Model(&domain.Account{}).Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"totp_secret":       secret,
+			"totp_enabled_at":   enabledAt,
+			"totp_last_counter": 0,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// AdvanceTOTPCounter stores a newer accepted time step (atomic, a code can only be used once)
+func (r *accountRepository) AdvanceTOTPCounter(id uint, counter int64) (bool, error) {
+	result := r.db.Model(&domain.Account{}).
+		Where("id = ? AND totp_last_counter < ?", id, counter).
+		Update("totp_last_counter", counter)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// MarkPasswordless clears the password and sets the passwordless flag
+func (r *accountRepository) MarkPasswordless(id uint) error {
+	result := r.db.Model(&domain.Account{}).Where("id = ?", id).
+		Updates(map[string]interface{}{"password": "", "passwordless": true})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// SetStatus changes the status and ban details in one update
+func (r *accountRepository) SetStatus(id uint, status domain.AccountStatus, banReason string, bannedUntil *time.Time) error {
+	result := r.db.Model(&domain.Account{}).Where("id = ?", id).
+		Updates(map[string]interface{}{"status": string(status), "ban_reason": banReason, "banned_until": bannedUntil})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// UpdateRole sets the account role
+func (r *accountRepository) UpdateRole(id uint, role string) error {
+	result := r.db.Model(&domain.Account{}).Where("id = ?", id).Update("role", role)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// UpdateProfile writes all profile fields, so cleared values are stored too.
+// The avatar is written with SetAvatar, together with its blob key.
+func (r *accountRepository) UpdateProfile(id uint, update domain.ProfileUpdate) (string, error) {
+	var previousKey string
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var current domain.Account
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id", "avatar_key").First(&current, id).Error; err != nil {
+			return err
+		}
+
+		updates := map[string]interface{}{"name": update.Name, "bio": update.Bio, "links": update.Links}
+		if update.HandleChangedAt != nil {
+			updates["handle"] = update.Handle
+			updates["handle_changed_at"] = update.HandleChangedAt
+		}
+		if update.SetAvatar {
+			updates["avatar_url"] = update.AvatarURL
+			updates["avatar_key"] = ""
+			previousKey = current.AvatarKey
+		}
+		return tx.Model(&domain.Account{}).Where("id = ?", id).Updates(updates).Error
+	})
+	if err != nil {
+		return "", handleError(err)
+	}
+	return previousKey, nil
+}
+
+// handleError reports a lost race for a handle like the service's own check does
+func handleError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" && strings.Contains(pgErr.ConstraintName, "handle") {
+		return errors.New("handle is already taken")
+	}
+	return err
+}
+
+// SetAvatar updates the avatar URL together with its blob key
+func (r *accountRepository) SetAvatar(id uint, avatarURL *string, avatarKey string) error {
+	result := r.db.Model(&domain.Account{}).Where("id = ?", id).
+		Updates(map[string]interface{}{"avatar_url": avatarURL, "avatar_key": avatarKey})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// Anonymize replaces personal data with placeholders and soft-deletes the account.
+// The row is kept so authored content still resolves to a (deleted) author.
+func (r *accountRepository) Anonymize(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&domain.Account{}).Where("id = ?", id).Updates(map[string]interface{}{
+			"email":             fmt.Sprintf("deleted-%d@users.invalid", id),
+			"name":              "Deleted user",
+			"handle":            fmt.Sprintf("deleted-%d", id),
+			"password":          "",
+			"passwordless":      true,
+			"avatar_url":        nil,
+			"avatar_key":        "",
+			"bio":               "",
+			"links":             nil,
+			"email_verified_at": nil,
+			"totp_secret":       "",
+			"totp_enabled_at":   nil,
+			"status":            string(domain.AccountStatusDeleted),
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return tx.Delete(&domain.Account{}, id).Error
+	})
+}
+
 // Delete removes an account by ID
 func (r *accountRepository) Delete(id uint) error {
 	result := r.db.Delete(&domain.Account{}, id)
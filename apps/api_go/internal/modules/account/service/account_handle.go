@@ -0,0 +1,182 @@
+package service
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+	"time"
+
+	"api_go/internal/domain"
+	"api_go/internal/slug"
+)
+
+const (
+	minHandleLength = 3
+	maxHandleLength = 30
+	// generated handles keep room for a collision suffix
+	maxGeneratedBase = maxHandleLength - 7
+	// deletedHandlePrefix marks anonymized accounts (see AccountRepository.Anonymize)
+	deletedHandlePrefix = "deleted-"
+	// backfillBatchSize is the number of accounts loaded per BackfillHandles round
+	backfillBatchSize = 200
+)
+
+// handlePattern allows letters, digits, "-" and "_", starting and ending with a letter or digit,
+// so an @mention ends at the first other character
+var handlePattern = regexp.MustCompile(`^[a-z0-9](?:[a-z0-9_-]*[a-z0-9])?$`)
+
+// reservedHandles would be confused with routes, staff or system messages
+var reservedHandles = map[string]struct{}{
+	"about": {}, "account": {}, "accounts": {}, "admin": {}, "administrator": {}, "anonymous": {},
+	"api": {}, "auth": {}, "comments": {}, "deleted": {}, "devwiki": {}, "everyone": {}, "help": {},
+	"here": {}, "login": {}, "logout": {}, "me": {}, "media": {}, "mod": {}, "moderator": {},
+	"moderators": {}, "new": {}, "null": {}, "register": {}, "root": {}, "settings": {}, "staff": {},
+	"support": {}, "system": {}, "tags": {}, "tutorials": {}, "undefined": {}, "user": {}, "users": {},
+	"videos": {}, "votes": {},
+}
+
+// normalizeHandle lowercases the handle and drops a leading "@"
+func normalizeHandle(handle string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(handle), "@"))
+}
+
+// validateHandle checks the format of a normalized handle. Handles need a letter so they never
+// look like an account ID in /users/{id-or-handle}.
+func validateHandle(handle string) error {
+	if len(handle) < minHandleLength || len(handle) > maxHandleLength ||
+		!handlePattern.MatchString(handle) || !strings.ContainsAny(handle, "abcdefghijklmnopqrstuvwxyz") {
+		return errors.New("invalid handle")
+	}
+	if _, reserved := reservedHandles[handle]; reserved || strings.HasPrefix(handle, deletedHandlePrefix) {
+		return errors.New("handle is reserved")
+	}
+	return nil
+}
+
+// GenerateHandle folds the name like tutorial slugs and appends -2, -3, ... (then a random number) until it is free
+func (s *accountService) GenerateHandle(name string) (string, error) {
+	base := strings.Trim(truncate(slug.Make(name), maxGeneratedBase), "-")
+	if len(base) < minHandleLength || validateHandle(base) != nil {
+		base = strings.Trim(truncate("user-"+base, maxGeneratedBase), "-")
+	}
+
+	candidates := []string{base}
+	for n := 2; n < 10; n++ {
+		candidates = append(candidates, fmt.Sprintf("%s-%d", base, n))
+	}
+	for i := 0; i < 5; i++ {
+		n, err := rand.Int(rand.Reader, big.NewInt(900000))
+		if err != nil {
+			return "", err
+		}
+		candidates = append(candidates, fmt.Sprintf("%s-%d", base, n.Int64()+100000))
+	}
+
+	for _, candidate := range candidates {
+		if validateHandle(candidate) != nil {
+			continue
+		}
+		existing, err := s.repo.FindByHandle(candidate)
+		if err != nil {
+			return "", err
+		}
+		if existing == nil {
+			return candidate, nil
+		}
+	}
+	return "", errors.New("could not generate a handle")
+}
+
+// BackfillHandles generates handles for accounts that have none and returns how many were updated
+func (s *accountService) BackfillHandles() (int, error) {
+	updated := 0
+	for {
+		accounts, err := s.repo.FindWithoutHandle(backfillBatchSize)
+		if err != nil {
+			return updated, err
+		}
+		if len(accounts) == 0 {
+			return updated, nil
+		}
+
+		for i := range accounts {
+			handle, err := s.GenerateHandle(accounts[i].Name)
+			if err != nil {
+				return updated, err
+			}
+			if err := s.repo.UpdateHandle(accounts[i].ID, handle, nil); err != nil {
+				return updated, err
+			}
+			updated++
+		}
+	}
+}
+
+// ResolveMentions looks up the mentioned handles in one query
+func (s *accountService) ResolveMentions(handles []string) (map[string]domain.MentionDTO, error) {
+	valid := make([]string, 0, len(handles))
+	for _, handle := range handles {
+		if handle = normalizeHandle(handle); validateHandle(handle) == nil {
+			valid = append(valid, handle)
+		}
+	}
+
+	mentions := make(map[string]domain.MentionDTO)
+	if len(valid) == 0 {
+		return mentions, nil
+	}
+	accounts, err := s.repo.FindByHandles(valid)
+	if err != nil {
+		return nil, err
+	}
+	for _, account := range accounts {
+		mentions[account.Handle] = domain.MentionDTO{Handle: account.Handle, AccountID: account.ID, Name: account.Name}
+	}
+	return mentions, nil
+}
+
+// checkHandleChange validates a handle the user wants to switch to (an unchanged handle returns false)
+func (s *accountService) checkHandleChange(account *domain.Account, requested string) (string, bool, error) {
+	handle := normalizeHandle(requested)
+	if handle == account.Handle {
+		return handle, false, nil
+	}
+
+	if s.handleChangeableAt(account) != nil {
+		return "", false, errors.New("handle was changed recently")
+	}
+	if err := validateHandle(handle); err != nil {
+		return "", false, err
+	}
+
+	existing, err := s.repo.FindByHandle(handle)
+	if err != nil {
+		return "", false, err
+	}
+	if existing != nil && existing.ID != account.ID {
+		return "", false, errors.New("handle is already taken")
+	}
+	return handle, true, nil
+}
+
+// handleChangeableAt returns when the cooldown after the last handle change ends, or nil if it already has
+func (s *accountService) handleChangeableAt(account *domain.Account) *time.Time {
+	if account.HandleChangedAt == nil {
+		return nil
+	}
+	at := account.HandleChangedAt.Add(s.handleCooldown)
+	if !time.Now().Before(at) {
+		return nil
+	}
+	return &at
+}
+
+func truncate(s string, max int) string {
+	if len(s) > max {
+		return s[:max]
+	}
+	return s
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"api_go/internal/domain"
+)
+
+// fakeAccountRepo only answers handle lookups; other calls panic via the nil interface
+type fakeAccountRepo struct {
+	domain.AccountRepository
+	taken    map[string]bool
+	allTaken bool
+	lookups  []string
+}
+
+func (f *fakeAccountRepo) FindByHandle(handle string) (*domain.Account, error) {
+	f.lookups = append(f.lookups, handle)
+	if f.allTaken || f.taken[handle] {
+		return &domain.Account{Handle: handle}, nil
+	}
+	return nil, nil
+}
+
+func TestValidateHandle(t *testing.T) {
+	tests := []struct {
+		handle string
+		want   string
+	}{
+		{"alice", ""},
+		{"jane-doe_42", ""},
+		{"abc", ""},
+		{strings.Repeat("a", 30), ""},
+		{"ab", "invalid handle"},
+		{strings.Repeat("a", 31), "invalid handle"},
+		{"12345", "invalid handle"},
+		{"-alice", "invalid handle"},
+		{"alice_", "invalid handle"},
+		{"Alice", "invalid handle"},
+		{"al.ice", "invalid handle"},
+		{"admin", "handle is reserved"},
+		{"me1", ""},
+		{"settings", "handle is reserved"},
+		{"deleted", "handle is reserved"},
+		{"deleted-42", "handle is reserved"},
+		{"deletedfoo", ""},
+	}
+	for _, tt := range tests {
+		err := validateHandle(tt.handle)
+		if got := errorText(err); got != tt.want {
+			t.Errorf("validateHandle(%q) = %q, want %q", tt.handle, got, tt.want)
+		}
+	}
+}
+
+func TestGenerateHandle(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		taken []string
+		want  string
+	}{
+		{"free", "Jane Doe", nil, "jane-doe"},
+		{"diacritics", "Nguyễn Văn Á", nil, "nguyen-van-a"},
+		{"second", "Jane Doe", []string{"jane-doe"}, "jane-doe-2"},
+		{"ninth", "Jane Doe", []string{"jane-doe", "jane-doe-2", "jane-doe-3", "jane-doe-4", "jane-doe-5", "jane-doe-6", "jane-doe-7", "jane-doe-8"}, "jane-doe-9"},
+		{"too short", "Al", nil, "user-al"},
+		{"reserved", "Admin", nil, "user-admin"},
+		{"digits only", "2024", nil, "user-2024"},
+		{"no letters", "!!!", nil, "user-2"},
+		{"long name", strings.Repeat("abcde ", 10), nil, "abcde-abcde-abcde-abcde"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeAccountRepo{taken: make(map[string]bool)}
+			for _, handle := range tt.taken {
+				repo.taken[handle] = true
+			}
+			s := &accountService{repo: repo}
+
+			got, err := s.GenerateHandle(tt.input)
+			if err != nil {
+				t.Fatalf("GenerateHandle: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("GenerateHandle(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateHandleFallsBackToRandomSuffix(t *testing.T) {
+	repo := &fakeAccountRepo{taken: map[string]bool{"jane-doe": true}}
+	for n := 2; n < 10; n++ {
+		repo.taken[fmt.Sprintf("jane-doe-%d", n)] = true
+	}
+	s := &accountService{repo: repo}
+
+	got, err := s.GenerateHandle("Jane Doe")
+	if err != nil {
+		t.Fatalf("GenerateHandle: %v", err)
+	}
+	if !regexp.MustCompile(`^jane-doe-[1-9][0-9]{5}$`).MatchString(got) {
+		t.Errorf("GenerateHandle = %q, want jane-doe plus a six-digit number", got)
+	}
+	if len(repo.lookups) != 10 {
+		t.Errorf("looked up %q, want the base, -2 to -9 and one random suffix", repo.lookups)
+	}
+}
+
+func TestGenerateHandleGivesUp(t *testing.T) {
+	repo := &fakeAccountRepo{allTaken: true}
+	s := &accountService{repo: repo}
+
+	if _, err := s.GenerateHandle("Jane Doe"); errorText(err) != "could not generate a handle" {
+		t.Errorf("GenerateHandle error = %v, want could not generate a handle", err)
+	}
+	if len(repo.lookups) != 14 {
+		t.Errorf("made %d lookups, want 14", len(repo.lookups))
+	}
+}
+
+func errorText(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"api_go/internal/domain"
+)
+
+const (
+	defaultAccountPageSize = 20
+	maxAccountPageSize     = 100
+)
+
+// listCursor is the JSON inside the opaque cursor string
+type listCursor struct {
+	Value string `json:"v"`
+	ID    uint   `json:"id"`
+}
+
+// List pages through accounts for the admin console (default: newest first)
+func (s *accountService) List(params domain.AccountListParams) (*domain.AccountListDTO, error) {
+	if params.Sort == "" {
+		params.Sort = "created_at"
+	}
+	if params.Order == "" {
+		params.Order = "desc"
+	}
+	limit := params.Limit
+	if limit <= 0 {
+		limit = defaultAccountPageSize
+	}
+	if limit > maxAccountPageSize {
+		limit = maxAccountPageSize
+	}
+
+	var after *domain.AccountCursor
+	if params.Cursor != "" {
+		cursor, err := decodeAccountCursor(params.Cursor, params.Sort)
+		if err != nil {
+			return nil, err
+		}
+		after = cursor
+	}
+
+	// Fetch one extra to know whether there is a next page
+	accounts, err := s.repo.FindPage(params, after, limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	var nextCursor *string
+	if len(accounts) > limit {
+		accounts = accounts[:limit]
+		cursor, err := encodeAccountCursor(&accounts[limit-1], params.Sort)
+		if err != nil {
+			return nil, err
+		}
+		nextCursor = &cursor
+	}
+
+	return &domain.AccountListDTO{
+		Items:      toResponseDTOList(accounts),
+		NextCursor: nextCursor,
+	}, nil
+}
+
+// encodeAccountCursor captures the sort value of the last account on the page
+func encodeAccountCursor(account *domain.Account, sort string) (string, error) {
+	cursor := listCursor{ID: account.ID}
+	switch sort {
+	case "created_at":
+		cursor.Value = account.CreatedAt.Format(time.RFC3339Nano)
+	case "last_login_at":
+		// Matches the repository's COALESCE for accounts that never logged in
+		lastLogin := time.Unix(0, 0).UTC()
+		if account.LastLoginAt != nil {
+			lastLogin = *account.LastLoginAt
+		}
+		cursor.Value = lastLogin.Format(time.RFC3339Nano)
+	case "email":
+		cursor.Value = account.Email
+	case "name":
+		cursor.Value = account.Name
+	}
+
+	data, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// decodeAccountCursor parses a cursor produced by encodeAccountCursor for the same sort
+func decodeAccountCursor(raw, sort string) (*domain.AccountCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, errors.New("invalid cursor")
+	}
+	var cursor listCursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.ID == 0 {
+		return nil, errors.New("invalid cursor")
+	}
+
+	if sort == "created_at" || sort == "last_login_at" {
+		value, err := time.Parse(time.RFC3339Nano, cursor.Value)
+		if err != nil {
+			return nil, errors.New("invalid cursor")
+		}
+		return &domain.AccountCursor{Value: value, ID: cursor.ID}, nil
+	}
+	return &domain.AccountCursor{Value: cursor.Value, ID: cursor.ID}, nil
+}
@@ -1,25 +1,37 @@
 package service
 
 import (
+	"encoding/json"
 	"errors"
+	"net/url"
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"golang.org/x/crypto/bcrypt"
 
+	"api_go/internal/config"
 	"api_go/internal/domain"
 )
 
 const defaultSaltRounds = 10
 
 type accountService struct {
-	repo domain.AccountRepository
+	repo           domain.AccountRepository
+	avatars        domain.AvatarService
+	sessions       domain.SessionRepository
+	handleCooldown time.Duration
 }
 
 // NewAccountService creates a new AccountService instance
-func NewAccountService(repo domain.AccountRepository) domain.AccountService {
-	return &accountService{repo: repo}
+func NewAccountService(
+	cfg *config.Config,
+	repo domain.AccountRepository,
+	avatars domain.AvatarService,
+	sessions domain.SessionRepository,
+) domain.AccountService {
+	return &accountService{repo: repo, avatars: avatars, sessions: sessions, handleCooldown: cfg.HandleChangeCooldown}
 }
 
 // toResponseDTO converts Account entity to AccountResponseDTO
@@ -28,25 +40,26 @@ func toResponseDTO(account *domain.Account) *domain.AccountResponseDTO {
 		return nil
 	}
 	return &domain.AccountResponseDTO{
-		ID:        account.ID,
-		Email:     account.Email,
-		Name:      account.Name,
-		Role:      account.Role,
-		AvatarURL: account.AvatarURL,
+		ID:            account.ID,
+		Email:         account.Email,
+		Name:          account.Name,
+		Handle:        account.Handle,
+		Role:          account.Role,
+		AvatarURL:     account.AvatarURL,
+		EmailVerified: account.EmailVerifiedAt != nil,
+		Status:        account.Status,
+		BanReason:     account.BanReason,
+		BannedUntil:   account.BannedUntil,
+		CreatedAt:     account.CreatedAt,
+		LastLoginAt:   account.LastLoginAt,
 	}
 }
 
 // toResponseDTOList converts slice of Account entities to slice of AccountResponseDTO
 func toResponseDTOList(accounts []domain.Account) []domain.AccountResponseDTO {
 	result := make([]domain.AccountResponseDTO, len(accounts))
-	for i, account := range accounts {
-		result[i] = domain.AccountResponseDTO{
-			ID:        account.ID,
-			Email:     account.Email,
-			Name:      account.Name,
-			Role:      account.Role,
-			AvatarURL: account.AvatarURL,
-		}
+	for i := range accounts {
+		result[i] = *toResponseDTO(&accounts[i])
 	}
 	return result
 }
@@ -79,9 +92,14 @@ func (s *accountService) Create(dto domain.CreateAccountDTO) (*domain.AccountRes
 	}
 
 	// 4. Create account entity
+	handle, err := s.GenerateHandle(dto.Name)
+	if err != nil {
+		return nil, err
+	}
 	account := &domain.Account{
 		Email:    normalizedEmail,
 		Name:     dto.Name,
+		Handle:   handle,
 		Password: string(hashedPassword),
 		Role:     dto.Role,
 		Status:   string(domain.AccountStatusActive),
@@ -147,9 +165,6 @@ func (s *accountService) Update(id uint, dto domain.UpdateAccountDTO) (*domain.A
 		}
 		update.Password = string(hashedPassword)
 	}
-	if dto.Role != nil {
-		update.Role = *dto.Role
-	}
 	if dto.AvatarURL != nil {
 		update.AvatarURL = dto.AvatarURL
 	}
@@ -171,3 +186,143 @@ func (s *accountService) Remove(id uint) (bool, error) {
 	}
 	return true, nil
 }
+
+// UpdateRole changes the role of another account and revokes its sessions, so the old role
+// cannot outlive the change in a token or in a cached status check
+func (s *accountService) UpdateRole(actorID, id uint, dto domain.UpdateAccountRoleDTO) (*domain.AccountResponseDTO, error) {
+	if actorID == id {
+		return nil, errors.New("cannot change your own role")
+	}
+	if err := s.repo.UpdateRole(id, dto.Role); err != nil {
+		return nil, err
+	}
+	if err := s.sessions.RevokeAllForAccount(id); err != nil {
+		return nil, err
+	}
+	return s.FindOne(id)
+}
+
+// GetProfile returns the account's own profile
+func (s *accountService) GetProfile(id uint) (*domain.ProfileResponseDTO, error) {
+	account, err := s.repo.FindOne(id)
+	if err != nil {
+		return nil, err
+	}
+	if account == nil {
+		return nil, errors.New("user not found")
+	}
+	return s.toProfileDTO(account)
+}
+
+// UpdateProfile applies the provided fields and keeps the others
+func (s *accountService) UpdateProfile(id uint, dto domain.UpdateProfileDTO) (*domain.ProfileResponseDTO, error) {
+	account, err := s.repo.FindOne(id)
+	if err != nil {
+		return nil, err
+	}
+	if account == nil {
+		return nil, errors.New("user not found")
+	}
+
+	if dto.Name != nil {
+		name := strings.TrimSpace(*dto.Name)
+		if name == "" {
+			return nil, errors.New("name cannot be empty")
+		}
+		account.Name = name
+	}
+	handleChanged := false
+	if dto.Handle != nil {
+		handle, changed, err := s.checkHandleChange(account, *dto.Handle)
+		if err != nil {
+			return nil, err
+		}
+		account.Handle, handleChanged = handle, changed
+	}
+	avatarChanged := false
+	if dto.AvatarURL != nil {
+		var avatarURL *string
+		if avatar := strings.TrimSpace(*dto.AvatarURL); avatar != "" {
+			if !isHTTPURL(avatar) {
+				return nil, errors.New("avatar_url must be an http(s) URL")
+			}
+			avatarURL = &avatar
+		}
+		// Echoing the current URL back must not delete the uploaded files it points to
+		avatarChanged = !sameURL(account.AvatarURL, avatarURL)
+		account.AvatarURL = avatarURL
+	}
+	if dto.Bio != nil {
+		account.Bio = strings.TrimSpace(*dto.Bio)
+	}
+	if dto.Links != nil {
+		for _, link := range *dto.Links {
+			if !isHTTPURL(link.URL) {
+				return nil, errors.New("links must be http(s) URLs")
+			}
+		}
+		links, err := json.Marshal(*dto.Links)
+		if err != nil {
+			return nil, err
+		}
+		account.Links = links
+	}
+
+	update := domain.ProfileUpdate{
+		Name:      account.Name,
+		Bio:       account.Bio,
+		Links:     account.Links,
+		SetAvatar: avatarChanged,
+		AvatarURL: account.AvatarURL,
+	}
+	if handleChanged {
+		now := time.Now()
+		update.Handle, update.HandleChangedAt = account.Handle, &now
+		account.HandleChangedAt = &now
+	}
+	previousKey, err := s.repo.UpdateProfile(id, update)
+	if err != nil {
+		return nil, err
+	}
+	// A replaced upload is deleted only once nothing refers to it
+	s.avatars.DeleteUpload(previousKey)
+	return s.toProfileDTO(account)
+}
+
+// toProfileDTO converts an Account entity to the self-service profile
+func (s *accountService) toProfileDTO(account *domain.Account) (*domain.ProfileResponseDTO, error) {
+	links, err := account.ProfileLinks()
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.ProfileResponseDTO{
+		ID:                 account.ID,
+		Email:              account.Email,
+		Name:               account.Name,
+		Handle:             account.Handle,
+		AvatarURL:          account.AvatarURL,
+		Bio:                account.Bio,
+		Links:              links,
+		Role:               account.Role,
+		EmailVerified:      account.EmailVerifiedAt != nil,
+		HasPassword:        !account.Passwordless && account.Password != "",
+		TwoFactorEnabled:   account.TwoFactorEnabled(),
+		CreatedAt:          account.CreatedAt,
+		HandleChangeableAt: s.handleChangeableAt(account),
+	}, nil
+}
+
+// sameURL reports whether two optional URLs are both unset or equal
+func sameURL(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// isHTTPURL rejects javascript: and other schemes that would be unsafe to render as links
+func isHTTPURL(raw string) bool {
+	u, err := url.Parse(raw)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
@@ -1,64 +1,86 @@
 package controller
 
 import (
-	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 
 	"github.com/gin-gonic/gin"
-	"golang.org/x/oauth2"
-	"golang.org/x/oauth2/google"
 
 	"api_go/internal/config"
 	"api_go/internal/domain"
+	"api_go/internal/modules/auth/middleware"
+	"api_go/internal/modules/auth/provider"
 )
 
-type AuthController struct {
-	config       *config.Config
-	authService  domain.AuthService
-	oauth2Config *oauth2.Config
-}
+const mfaChallengeCookie = "mfa_challenge"
 
-// GoogleUserInfo represents the response from Google's userinfo endpoint
-type GoogleUserInfo struct {
-	ID            string `json:"id"`
-	Email         string `json:"email"`
-	VerifiedEmail bool   `json:"verified_email"`
-	Name          string `json:"name"`
-	GivenName     string `json:"given_name"`
-	FamilyName    string `json:"family_name"`
-	Picture       string `json:"picture"`
+type AuthController struct {
+	config      *config.Config
+	authService domain.AuthService
+	providers   *provider.Registry
+	throttle    domain.LoginThrottle
 }
 
 // NewAuthController creates a new AuthController instance
-func NewAuthController(cfg *config.Config, authService domain.AuthService) *AuthController {
-	oauth2Config := &oauth2.Config{
-		ClientID:     cfg.GoogleClientID,
-		ClientSecret: cfg.GoogleSecret,
-		RedirectURL:  cfg.GoogleCallbackURL,
-		Scopes: []string{
-			"https://www.googleapis.com/auth/userinfo.email",
-			"https://www.googleapis.com/auth/userinfo.profile",
-		},
-		Endpoint: google.Endpoint,
-	}
-
+func NewAuthController(
+	cfg *config.Config,
+	authService domain.AuthService,
+	providers *provider.Registry,
+	throttle domain.LoginThrottle,
+) *AuthController {
 	return &AuthController{
-		config:       cfg,
-		authService:  authService,
-		oauth2Config: oauth2Config,
+		config:      cfg,
+		authService: authService,
+		providers:   providers,
+		throttle:    throttle,
 	}
 }
 
 // RegisterRoutes registers auth routes
-func (ctrl *AuthController) RegisterRoutes(r *gin.RouterGroup) {
+func (ctrl *AuthController) RegisterRoutes(r *gin.RouterGroup, policy *middleware.RoutePolicy) {
 	r.POST("/register", ctrl.Register)
 	r.POST("/login", ctrl.Login)
+	r.POST("/login/2fa", ctrl.LoginMFA)
+	r.POST("/refresh", ctrl.Refresh)
 	r.POST("/logout", ctrl.Logout)
-	r.GET("/me", ctrl.GetMe)
+	r.POST("/password/forgot", ctrl.ForgotPassword)
+	r.POST("/password/reset", ctrl.ResetPassword)
+	r.GET("/verify-email", ctrl.VerifyEmail)
+	r.POST("/verify-email/resend", ctrl.ResendVerification)
+	r.GET("/me", policy.Authenticated(), ctrl.GetMe)
+	r.GET("/.well-known/jwks.json", ctrl.JWKS)
+	r.GET("/auth/:provider", ctrl.OAuthStart)
+	r.GET("/auth/:provider/callback", ctrl.OAuthCallback)
+	r.GET("/auth/:provider/link", policy.Authenticated(), ctrl.OAuthLink)
+	r.GET("/me/identities", policy.Authenticated(), ctrl.ListIdentities)
+	r.DELETE("/me/identities/:provider", policy.Authenticated(), ctrl.UnlinkIdentity)
+	r.POST("/me/password", policy.Authenticated(), ctrl.ChangePassword)
+	r.DELETE("/me/password", policy.Authenticated(), ctrl.RemovePassword)
+	r.DELETE("/me", policy.Authenticated(), ctrl.DeleteMe)
+	r.GET("/me/tokens", policy.Authenticated(), ctrl.ListPersonalTokens)
+	r.POST("/me/tokens", policy.Authenticated(), ctrl.CreatePersonalToken)
+	r.DELETE("/me/tokens/:id", policy.Authenticated(), ctrl.RevokePersonalToken)
+
+	mfa := r.Group("/me/2fa", policy.Authenticated())
+	{
+		mfa.GET("", ctrl.GetMFAStatus)
+		mfa.POST("/setup", ctrl.SetupMFA)
+		mfa.POST("/enable", ctrl.EnableMFA)
+		mfa.POST("/disable", ctrl.DisableMFA)
+		mfa.POST("/recovery-codes", ctrl.RegenerateRecoveryCodes)
+	}
+
+	admin := r.Group("/admin", policy.Admins())
+	{
+		admin.GET("/lockouts", ctrl.ListLockouts)
+		admin.DELETE("/lockouts/:key", ctrl.ClearLockout)
+		admin.POST("/accounts/:id/ban", ctrl.BanAccount)
+		admin.DELETE("/accounts/:id/ban", ctrl.UnbanAccount)
+	}
 	r.GET("/google", ctrl.GoogleAuth)
 	r.GET("/google-redirect", ctrl.GoogleCallback)
 }
@@ -86,13 +108,13 @@ func (ctrl *AuthController) Register(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusCreated, gin.H{
-		"message": "Registration successful! Please login to continue.",
+		"message": "Registration successful! Please check your email to verify your account.",
 	})
 }
 
 // Login handles POST /login
 // @Summary Login user
-// @Description Authenticate user with email and password
+// @Description Authenticate user with email and password. Accounts with 2FA receive mfa_required and a challenge_token for POST /login/2fa.
 // @Tags auth
 // @Accept json
 // @Produce json
@@ -100,6 +122,7 @@ func (ctrl *AuthController) Register(c *gin.Context) {
 // @Success 200 {object} domain.AuthResponseDTO
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
+// @Failure 429 {object} map[string]string
 // @Router /login [post]
 func (ctrl *AuthController) Login(c *gin.Context) {
 	var dto domain.LoginDTO
@@ -112,9 +135,40 @@ func (ctrl *AuthController) Login(c *gin.Context) {
 	email := strings.ToLower(strings.TrimSpace(dto.Email))
 	password := strings.TrimSpace(dto.Password)
 
+	// Reject while the IP or the email is in backoff/lockout
+	if err := ctrl.throttle.Check(c.ClientIP(), email); err != nil {
+		ctrl.respondThrottleError(c, err)
+		return
+	}
+
 	result, err := ctrl.authService.ValidateUser(email, password)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		if respondBanned(c, err) {
+			return
+		}
+		switch err.Error() {
+		case "invalid credentials":
+			if err := ctrl.throttle.RecordFailure(c.ClientIP(), email); err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		case "email not verified":
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	if err := ctrl.throttle.RecordSuccess(email); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Second factor required: no session cookies until POST /login/2fa
+	if result.MFARequired {
+		c.JSON(http.StatusOK, result)
 		return
 	}
 
@@ -124,6 +178,456 @@ func (ctrl *AuthController) Login(c *gin.Context) {
 	c.JSON(http.StatusOK, result)
 }
 
+// ListLockouts handles GET /admin/lockouts
+// @Summary List login lockouts
+// @Description List IPs and emails currently blocked by login throttling (admin only)
+// @Tags auth
+// @Produce json
+// @Success 200 {array} domain.LoginLockoutDTO
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Security BearerAuth
+// @Router /admin/lockouts [get]
+func (ctrl *AuthController) ListLockouts(c *gin.Context) {
+	lockouts, err := ctrl.throttle.ListLockouts()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, lockouts)
+}
+
+// ClearLockout handles DELETE /admin/lockouts/:key
+// @Summary Clear a login lockout
+// @Description Unblock an IP or email (key as listed, e.g. "email:jane@example.com") (admin only)
+// @Tags auth
+// @Produce json
+// @Param key path string true "Throttle key"
+// @Success 200 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Security BearerAuth
+// @Router /admin/lockouts/{key} [delete]
+func (ctrl *AuthController) ClearLockout(c *gin.Context) {
+	if err := ctrl.throttle.Unblock(c.Param("key")); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Lockout cleared successfully"})
+}
+
+// BanAccount handles POST /admin/accounts/:id/ban
+// @Summary Ban an account
+// @Description Ban an account permanently or until expires_at, revoking its sessions and personal access tokens (admin only)
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param id path int true "Account ID"
+// @Param dto body domain.BanAccountDTO true "Reason and optional expiry"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Security BearerAuth
+// @Router /admin/accounts/{id}/ban [post]
+func (ctrl *AuthController) BanAccount(c *gin.Context) {
+	actor, ok := middleware.GetActor(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid account id"})
+		return
+	}
+
+	var dto domain.BanAccountDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := ctrl.authService.BanAccount(actor.ID, uint(id), dto); err != nil {
+		switch err.Error() {
+		case "cannot ban yourself", "ban expiry must be in the future":
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case "user not found":
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Account banned successfully"})
+}
+
+// UnbanAccount handles DELETE /admin/accounts/:id/ban
+// @Summary Unban an account
+// @Description Lift a ban (admin only). Revoked sessions and tokens are not restored.
+// @Tags auth
+// @Produce json
+// @Param id path int true "Account ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Security BearerAuth
+// @Router /admin/accounts/{id}/ban [delete]
+func (ctrl *AuthController) UnbanAccount(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid account id"})
+		return
+	}
+
+	if err := ctrl.authService.UnbanAccount(uint(id)); err != nil {
+		switch err.Error() {
+		case "user not found":
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case "account is not banned":
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Account unbanned successfully"})
+}
+
+// ForgotPassword handles POST /password/forgot
+// @Summary Request a password reset
+// @Description Email a password reset link (the response does not reveal whether the email exists)
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param dto body domain.ForgotPasswordDTO true "Forgot Password DTO"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Router /password/forgot [post]
+func (ctrl *AuthController) ForgotPassword(c *gin.Context) {
+	var dto domain.ForgotPasswordDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := ctrl.authService.RequestPasswordReset(dto.Email); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to send reset email"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "If an account exists for this email, a reset link has been sent.",
+	})
+}
+
+// ResetPassword handles POST /password/reset
+// @Summary Reset password
+// @Description Set a new password with a reset token; all sessions are signed out
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param dto body domain.ResetPasswordDTO true "Reset Password DTO"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Router /password/reset [post]
+func (ctrl *AuthController) ResetPassword(c *gin.Context) {
+	var dto domain.ResetPasswordDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := ctrl.authService.ResetPassword(dto); err != nil {
+		if err.Error() == "invalid or expired token" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctrl.clearAuthCookies(c)
+	c.JSON(http.StatusOK, gin.H{"message": "Password has been reset. Please login to continue."})
+}
+
+// VerifyEmail handles GET /verify-email
+// @Summary Verify email
+// @Description Consume the link sent by email and redirect to the frontend
+// @Tags auth
+// @Param token query string true "Verification token"
+// @Success 302
+// @Router /verify-email [get]
+func (ctrl *AuthController) VerifyEmail(c *gin.Context) {
+	if err := ctrl.authService.VerifyEmail(c.Query("token")); err != nil {
+		if err.Error() == "invalid or expired token" {
+			ctrl.redirectWithError(c, "invalid_verification_token")
+			return
+		}
+		ctrl.redirectWithError(c, "verification_failed")
+		return
+	}
+
+	c.Redirect(http.StatusTemporaryRedirect, fmt.Sprintf("%s?email_verified=true", ctrl.config.FrontendURL))
+}
+
+// ResendVerification handles POST /verify-email/resend
+// @Summary Resend verification email
+// @Description Email a new verification link (the response does not reveal whether the email exists)
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param dto body domain.ResendVerificationDTO true "Resend Verification DTO"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Router /verify-email/resend [post]
+func (ctrl *AuthController) ResendVerification(c *gin.Context) {
+	var dto domain.ResendVerificationDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := ctrl.authService.SendVerificationEmail(dto.Email); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to send verification email"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "If the email needs verification, a new link has been sent.",
+	})
+}
+
+// LoginMFA handles POST /login/2fa
+// @Summary Complete two-factor login
+// @Description Exchange the login challenge and a TOTP or recovery code for tokens
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param dto body domain.MFALoginDTO true "MFA Login DTO"
+// @Success 200 {object} domain.AuthResponseDTO
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 429 {object} map[string]string
+// @Router /login/2fa [post]
+func (ctrl *AuthController) LoginMFA(c *gin.Context) {
+	var dto domain.MFALoginDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if dto.ChallengeToken == "" {
+		dto.ChallengeToken, _ = c.Cookie(mfaChallengeCookie)
+	}
+
+	// Codes are short, so guessing is throttled per IP and per account like passwords
+	accountID, err := ctrl.authService.MFAChallengeAccount(dto.ChallengeToken)
+	if err != nil {
+		if err := ctrl.throttle.Check(c.ClientIP(), ""); err != nil {
+			ctrl.respondThrottleError(c, err)
+			return
+		}
+		if err := ctrl.throttle.RecordFailure(c.ClientIP(), ""); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid challenge"})
+		return
+	}
+	if err := ctrl.throttle.CheckMFA(c.ClientIP(), accountID); err != nil {
+		ctrl.respondThrottleError(c, err)
+		return
+	}
+
+	result, err := ctrl.authService.CompleteMFALogin(dto.ChallengeToken, dto.Code)
+	if err != nil {
+		if respondBanned(c, err) {
+			return
+		}
+		switch err.Error() {
+		case "invalid code", "invalid challenge":
+			if err := ctrl.throttle.RecordMFAFailure(c.ClientIP(), accountID); err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	if err := ctrl.throttle.RecordMFASuccess(accountID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.SetCookie(mfaChallengeCookie, "", -1, "/", "", ctrl.config.IsProduction(), true)
+	ctrl.setAuthCookies(c, result)
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetMFAStatus handles GET /me/2fa
+// @Summary Get two-factor status
+// @Tags auth
+// @Produce json
+// @Success 200 {object} domain.MFAStatusDTO
+// @Failure 401 {object} map[string]string
+// @Security BearerAuth
+// @Router /me/2fa [get]
+func (ctrl *AuthController) GetMFAStatus(c *gin.Context) {
+	actor, ok := middleware.GetActor(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	status, err := ctrl.authService.GetMFAStatus(actor.ID)
+	if err != nil {
+		ctrl.respondMFAError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
+
+// SetupMFA handles POST /me/2fa/setup
+// @Summary Start two-factor enrollment
+// @Description Generate a TOTP secret and otpauth:// provisioning URI (render it as a QR code)
+// @Tags auth
+// @Produce json
+// @Success 200 {object} domain.MFASetupResponseDTO
+// @Failure 401 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Security BearerAuth
+// @Router /me/2fa/setup [post]
+func (ctrl *AuthController) SetupMFA(c *gin.Context) {
+	actor, ok := middleware.GetActor(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	result, err := ctrl.authService.SetupMFA(actor.ID)
+	if err != nil {
+		ctrl.respondMFAError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// EnableMFA handles POST /me/2fa/enable
+// @Summary Enable two-factor authentication
+// @Description Confirm enrollment with a code from the authenticator app; returns recovery codes once
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param dto body domain.MFACodeDTO true "MFA Code DTO"
+// @Success 200 {object} domain.MFARecoveryCodesDTO
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Security BearerAuth
+// @Router /me/2fa/enable [post]
+func (ctrl *AuthController) EnableMFA(c *gin.Context) {
+	actor, ok := middleware.GetActor(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var dto domain.MFACodeDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := ctrl.authService.EnableMFA(actor.ID, dto.Code)
+	if err != nil {
+		ctrl.respondMFAError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// DisableMFA handles POST /me/2fa/disable
+// @Summary Disable two-factor authentication
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param dto body domain.MFACodeDTO true "MFA Code DTO"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Security BearerAuth
+// @Router /me/2fa/disable [post]
+func (ctrl *AuthController) DisableMFA(c *gin.Context) {
+	actor, ok := middleware.GetActor(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var dto domain.MFACodeDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := ctrl.authService.DisableMFA(actor.ID, dto.Code); err != nil {
+		ctrl.respondMFAError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Two-factor authentication disabled"})
+}
+
+// RegenerateRecoveryCodes handles POST /me/2fa/recovery-codes
+// @Summary Regenerate recovery codes
+// @Description Replace all recovery codes; returns the new codes once
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param dto body domain.MFACodeDTO true "MFA Code DTO"
+// @Success 200 {object} domain.MFARecoveryCodesDTO
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Security BearerAuth
+// @Router /me/2fa/recovery-codes [post]
+func (ctrl *AuthController) RegenerateRecoveryCodes(c *gin.Context) {
+	actor, ok := middleware.GetActor(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var dto domain.MFACodeDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := ctrl.authService.RegenerateRecoveryCodes(actor.ID, dto.Code)
+	if err != nil {
+		ctrl.respondMFAError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
 // GetMe handles GET /me
 // @Summary Get current user
 // @Description Get the currently authenticated user
@@ -154,83 +658,430 @@ func (ctrl *AuthController) GetMe(c *gin.Context) {
 	})
 }
 
-// GoogleAuth handles GET /google
-// @Summary Start Google OAuth
-// @Description Redirect to Google OAuth login page
+// OAuthStart handles GET /auth/:provider
+// @Summary Start OAuth login
+// @Description Redirect to the provider login page (state, PKCE and nonce are bound to a signed cookie)
 // @Tags auth
+// @Param provider path string true "Provider name (google, github, gitlab or the configured OIDC name)"
 // @Success 302
-// @Router /google [get]
-func (ctrl *AuthController) GoogleAuth(c *gin.Context) {
-	// Generate OAuth state token (in production, store this in session)
-	state := "random-state-string" // TODO: Use secure random state
-
-	url := ctrl.oauth2Config.AuthCodeURL(state, oauth2.AccessTypeOffline)
-	c.Redirect(http.StatusTemporaryRedirect, url)
+// @Failure 404 {object} map[string]string
+// @Router /auth/{provider} [get]
+func (ctrl *AuthController) OAuthStart(c *gin.Context) {
+	ctrl.startOAuth(c, c.Param("provider"), 0)
 }
 
-// GoogleCallback handles GET /google-redirect
-// @Summary Google OAuth callback
-// @Description Handle Google OAuth callback and authenticate user
+// OAuthCallback handles GET /auth/:provider/callback
+// @Summary OAuth callback
+// @Description Handle the provider callback and authenticate user
 // @Tags auth
+// @Param provider path string true "Provider name"
 // @Param code query string true "OAuth code"
 // @Param state query string true "OAuth state"
 // @Success 302
+// @Failure 404 {object} map[string]string
+// @Router /auth/{provider}/callback [get]
+func (ctrl *AuthController) OAuthCallback(c *gin.Context) {
+	ctrl.finishOAuth(c, c.Param("provider"))
+}
+
+// OAuthLink handles GET /auth/:provider/link
+// @Summary Link an OAuth identity
+// @Description Redirect to the provider to link its identity to the current account
+// @Tags auth
+// @Param provider path string true "Provider name"
+// @Success 302
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Security BearerAuth
+// @Router /auth/{provider}/link [get]
+func (ctrl *AuthController) OAuthLink(c *gin.Context) {
+	actor, ok := middleware.GetActor(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	ctrl.startOAuth(c, c.Param("provider"), actor.ID)
+}
+
+// ListIdentities handles GET /me/identities
+// @Summary List linked identities
+// @Description List the OAuth identities linked to the current account
+// @Tags auth
+// @Produce json
+// @Success 200 {object} domain.IdentitiesResponseDTO
+// @Failure 401 {object} map[string]string
+// @Security BearerAuth
+// @Router /me/identities [get]
+func (ctrl *AuthController) ListIdentities(c *gin.Context) {
+	actor, ok := middleware.GetActor(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	result, err := ctrl.authService.ListIdentities(actor.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// UnlinkIdentity handles DELETE /me/identities/:provider
+// @Summary Unlink an identity
+// @Description Remove a linked OAuth identity (the last login method cannot be removed)
+// @Tags auth
+// @Produce json
+// @Param provider path string true "Provider name"
+// @Success 200 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Security BearerAuth
+// @Router /me/identities/{provider} [delete]
+func (ctrl *AuthController) UnlinkIdentity(c *gin.Context) {
+	actor, ok := middleware.GetActor(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	if err := ctrl.authService.UnlinkIdentity(actor.ID, c.Param("provider")); err != nil {
+		switch err.Error() {
+		case "identity not found":
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case "cannot remove the last login method":
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Identity unlinked successfully"})
+}
+
+// ChangePassword handles POST /me/password
+// @Summary Change my password
+// @Description Set a new password after confirming the current one (passwordless accounts omit it). Other sessions are signed out.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param dto body domain.ChangePasswordDTO true "Current and new password"
+// @Success 200 {object} map[string]string
 // @Failure 400 {object} map[string]string
-// @Router /google-redirect [get]
-func (ctrl *AuthController) GoogleCallback(c *gin.Context) {
-	code := c.Query("code")
-	if code == "" {
-		ctrl.redirectWithError(c, "missing_code")
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 429 {object} map[string]string
+// @Security BearerAuth
+// @Router /me/password [post]
+func (ctrl *AuthController) ChangePassword(c *gin.Context) {
+	payload, ok := middleware.GetPayload(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var dto domain.ChangePasswordDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Wrong current passwords count like failed logins
+	if err := ctrl.throttle.Check(c.ClientIP(), payload.Email); err != nil {
+		ctrl.respondThrottleError(c, err)
+		return
+	}
+
+	if err := ctrl.authService.ChangePassword(payload.Sub, payload.SessionID, dto); err != nil {
+		if err.Error() == "current password is incorrect" {
+			if err := ctrl.throttle.RecordFailure(c.ClientIP(), payload.Email); err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Password changed successfully"})
+}
+
+// DeleteMe handles DELETE /me
+// @Summary Delete my account
+// @Description Anonymize and soft-delete the account, unlink identities and revoke all sessions and tokens. Accounts with a password must confirm it.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param dto body domain.DeleteAccountDTO false "Password confirmation"
+// @Success 200 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 429 {object} map[string]string
+// @Security BearerAuth
+// @Router /me [delete]
+func (ctrl *AuthController) DeleteMe(c *gin.Context) {
+	payload, ok := middleware.GetPayload(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var dto domain.DeleteAccountDTO
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&dto); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	if err := ctrl.throttle.Check(c.ClientIP(), payload.Email); err != nil {
+		ctrl.respondThrottleError(c, err)
+		return
+	}
+
+	if err := ctrl.authService.DeleteAccount(payload.Sub, dto); err != nil {
+		if err.Error() == "password is incorrect" {
+			if err := ctrl.throttle.RecordFailure(c.ClientIP(), payload.Email); err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctrl.clearAuthCookies(c)
+	c.JSON(http.StatusOK, gin.H{"message": "Account deleted successfully"})
+}
+
+// RemovePassword handles DELETE /me/password
+// @Summary Make account passwordless
+// @Description Remove the password so the account signs in only through linked identities
+// @Tags auth
+// @Produce json
+// @Success 200 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Security BearerAuth
+// @Router /me/password [delete]
+func (ctrl *AuthController) RemovePassword(c *gin.Context) {
+	actor, ok := middleware.GetActor(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	if err := ctrl.authService.RemovePassword(actor.ID); err != nil {
+		if err.Error() == "cannot remove the last login method" {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Password removed successfully"})
+}
+
+// ListPersonalTokens handles GET /me/tokens
+// @Summary List personal access tokens
+// @Description List the active personal access tokens of the current account (secrets are never returned)
+// @Tags auth
+// @Produce json
+// @Success 200 {array} domain.PersonalTokenResponseDTO
+// @Failure 401 {object} map[string]string
+// @Security BearerAuth
+// @Router /me/tokens [get]
+func (ctrl *AuthController) ListPersonalTokens(c *gin.Context) {
+	actor, ok := middleware.GetActor(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	result, err := ctrl.authService.ListPersonalTokens(actor.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// CreatePersonalToken handles POST /me/tokens
+// @Summary Create a personal access token
+// @Description Create a scoped token for scripts and CI. The token is shown only in this response.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param dto body domain.CreatePersonalTokenDTO true "Token name, scopes and optional expiry"
+// @Success 201 {object} domain.CreatedPersonalTokenDTO
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Security BearerAuth
+// @Router /me/tokens [post]
+func (ctrl *AuthController) CreatePersonalToken(c *gin.Context) {
+	payload, ok := middleware.GetPayload(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
 		return
 	}
 
-	// Exchange code for token
-	token, err := ctrl.oauth2Config.Exchange(context.Background(), code)
+	var dto domain.CreatePersonalTokenDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := ctrl.authService.CreatePersonalToken(payload.Sub, payload.MFA, dto)
 	if err != nil {
-		ctrl.redirectWithError(c, "token_exchange_failed")
+		if err.Error() == "token name is required" || strings.HasPrefix(err.Error(), "invalid scope") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+// RevokePersonalToken handles DELETE /me/tokens/:id
+// @Summary Revoke a personal access token
+// @Description Revoke one of the current account's personal access tokens
+// @Tags auth
+// @Produce json
+// @Param id path int true "Token ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Security BearerAuth
+// @Router /me/tokens/{id} [delete]
+func (ctrl *AuthController) RevokePersonalToken(c *gin.Context) {
+	actor, ok := middleware.GetActor(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
 		return
 	}
 
-	// Get user info from Google
-	userInfo, err := ctrl.getGoogleUserInfo(token.AccessToken)
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		ctrl.redirectWithError(c, "failed_to_get_user_info")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid token id"})
+		return
+	}
+
+	if err := ctrl.authService.RevokePersonalToken(actor.ID, uint(id)); err != nil {
+		if err.Error() == "token not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	// Create Google profile
-	profile := domain.GoogleProfile{
-		GoogleID:      userInfo.ID,
-		Email:         userInfo.Email,
-		Name:          userInfo.Name,
-		Avatar:        userInfo.Picture,
-		Provider:      "google",
-		EmailVerified: userInfo.VerifiedEmail,
+	c.JSON(http.StatusOK, gin.H{"message": "Token revoked successfully"})
+}
+
+// JWKS handles GET /.well-known/jwks.json
+// @Summary JSON Web Key Set
+// @Description Public keys that verify access tokens, selected by the token's kid header (empty when tokens use HS256)
+// @Tags auth
+// @Produce json
+// @Success 200 {object} domain.JWKSDTO
+// @Router /.well-known/jwks.json [get]
+func (ctrl *AuthController) JWKS(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, ctrl.authService.JWKS())
+}
+
+// GoogleAuth handles GET /google (kept for existing frontend links)
+// @Summary Start Google OAuth
+// @Description Deprecated alias of /auth/google
+// @Tags auth
+// @Success 302
+// @Router /google [get]
+func (ctrl *AuthController) GoogleAuth(c *gin.Context) {
+	ctrl.startOAuth(c, "google", 0)
+}
+
+// GoogleCallback handles GET /google-redirect (kept for already registered redirect URIs)
+// @Summary Google OAuth callback
+// @Description Deprecated alias of /auth/google/callback
+// @Tags auth
+// @Param code query string true "OAuth code"
+// @Param state query string true "OAuth state"
+// @Success 302
+// @Router /google-redirect [get]
+func (ctrl *AuthController) GoogleCallback(c *gin.Context) {
+	ctrl.finishOAuth(c, "google")
+}
+
+// Refresh handles POST /refresh
+// @Summary Refresh access token
+// @Description Rotate the refresh token (body or refresh_token cookie) and issue a new access token
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param dto body domain.RefreshDTO false "Refresh DTO"
+// @Success 200 {object} domain.AuthResponseDTO
+// @Failure 401 {object} map[string]string
+// @Router /refresh [post]
+func (ctrl *AuthController) Refresh(c *gin.Context) {
+	refreshToken := ctrl.extractRefreshToken(c)
+	if refreshToken == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "no refresh token provided"})
+		return
 	}
 
-	// Handle Google login (create user if not exists)
-	result, err := ctrl.authService.HandleGoogleLogin(profile)
+	result, err := ctrl.authService.RefreshSession(refreshToken)
 	if err != nil {
-		ctrl.redirectWithError(c, "login_failed")
+		ctrl.clearAuthCookies(c)
+		if respondBanned(c, err) {
+			return
+		}
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
 		return
 	}
 
 	// Set cookies
 	ctrl.setAuthCookies(c, result)
 
-	// Build redirect URL with user info
-	redirectURL := ctrl.buildGoogleRedirectURL(result.Account)
-	c.Redirect(http.StatusTemporaryRedirect, redirectURL)
+	c.JSON(http.StatusOK, result)
 }
 
 // Logout handles POST /logout
 // @Summary Logout user
-// @Description Clear authentication cookies
+// @Description Revoke the current session and clear authentication cookies
 // @Tags auth
+// @Accept json
 // @Produce json
+// @Param dto body domain.RefreshDTO false "Refresh DTO"
 // @Success 200 {object} map[string]string
 // @Router /logout [post]
 func (ctrl *AuthController) Logout(c *gin.Context) {
+	// Revoke by refresh token, falling back to the session of the access token
+	if refreshToken := ctrl.extractRefreshToken(c); refreshToken != "" {
+		if err := ctrl.authService.Logout(refreshToken); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	} else if token := ctrl.extractToken(c); token != "" {
+		if payload, err := ctrl.authService.ValidateJWT(token); err == nil {
+			if err := ctrl.authService.RevokeSession(payload.SessionID); err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+		}
+	}
+
 	ctrl.clearAuthCookies(c)
 	c.JSON(http.StatusOK, gin.H{"message": "Logged out successfully"})
 }
@@ -252,32 +1103,93 @@ func (ctrl *AuthController) extractToken(c *gin.Context) string {
 	return ""
 }
 
+func (ctrl *AuthController) extractRefreshToken(c *gin.Context) string {
+	// Try JSON body first
+	var dto domain.RefreshDTO
+	if err := c.ShouldBindJSON(&dto); err == nil && dto.RefreshToken != "" {
+		return dto.RefreshToken
+	}
+
+	// Try cookie
+	if token, err := c.Cookie("refresh_token"); err == nil && token != "" {
+		return token
+	}
+
+	return ""
+}
+
 func (ctrl *AuthController) setAuthCookies(c *gin.Context, result *domain.AuthResponseDTO) {
 	secure := ctrl.config.IsProduction()
-	sameSite := http.SameSiteLaxMode
-	maxAge := 7 * 24 * 60 * 60 // 7 days
+	accessMaxAge := int(ctrl.config.AccessTokenTTL.Seconds())
+	refreshMaxAge := int(ctrl.config.RefreshTokenTTL.Seconds())
+
+	c.SetSameSite(http.SameSiteLaxMode)
 
 	// Set JWT token cookie
-	c.SetCookie("token", result.AccessToken, maxAge, "/", "", secure, true)
+	c.SetCookie("token", result.AccessToken, accessMaxAge, "/", "", secure, true)
+
+	// Set refresh token cookie
+	if result.RefreshToken != "" {
+		c.SetCookie("refresh_token", result.RefreshToken, refreshMaxAge, "/", "", secure, true)
+	}
 
 	// Set role cookie (not httpOnly so frontend can read it)
-	c.SetSameSite(sameSite)
-	c.SetCookie("role", result.Account.Role, maxAge, "/", "", secure, false)
+	c.SetCookie("role", result.Account.Role, refreshMaxAge, "/", "", secure, false)
 }
 
 func (ctrl *AuthController) clearAuthCookies(c *gin.Context) {
 	c.SetCookie("token", "", -1, "/", "", false, true)
+	c.SetCookie("refresh_token", "", -1, "/", "", false, true)
 	c.SetCookie("role", "", -1, "/", "", false, false)
 }
 
+// respondMFAError maps 2FA service errors to status codes
+func (ctrl *AuthController) respondMFAError(c *gin.Context, err error) {
+	switch err.Error() {
+	case "invalid code":
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case "user not found":
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case "two-factor authentication already enabled",
+		"two-factor authentication not enabled",
+		"two-factor authentication not set up":
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case "two-factor authentication is required for your role":
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// respondBanned writes 403 with the ban details and returns true when err is an account ban
+func respondBanned(c *gin.Context, err error) bool {
+	var banned *domain.AccountBannedError
+	if !errors.As(err, &banned) {
+		return false
+	}
+	middleware.AbortBanned(c, banned)
+	return true
+}
+
+// respondThrottleError writes 429 with Retry-After for throttling errors
+func (ctrl *AuthController) respondThrottleError(c *gin.Context, err error) {
+	var retryErr *domain.RetryAfterError
+	if errors.As(err, &retryErr) {
+		c.Header("Retry-After", retryErr.RetryAfterSeconds())
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": retryErr.Error()})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+}
+
 func (ctrl *AuthController) redirectWithError(c *gin.Context, errorCode string) {
 	redirectURL := fmt.Sprintf("%s?error=%s", ctrl.config.FrontendURL, errorCode)
 	c.Redirect(http.StatusTemporaryRedirect, redirectURL)
 }
 
-func (ctrl *AuthController) buildGoogleRedirectURL(account domain.AuthAccountResponse) string {
+func (ctrl *AuthController) buildOAuthRedirectURL(provider string, account domain.AuthAccountResponse) string {
 	params := url.Values{}
-	params.Set("provider", "google")
+	params.Set("provider", provider)
 	params.Set("id", fmt.Sprintf("%d", account.ID))
 	params.Set("email", account.Email)
 	params.Set("role", account.Role)
@@ -291,17 +1203,119 @@ func (ctrl *AuthController) buildGoogleRedirectURL(account domain.AuthAccountRes
 	return fmt.Sprintf("%s?%s", ctrl.config.FrontendURL, params.Encode())
 }
 
-func (ctrl *AuthController) getGoogleUserInfo(accessToken string) (*GoogleUserInfo, error) {
-	resp, err := http.Get("https://www.googleapis.com/oauth2/v2/userinfo?access_token=" + accessToken)
+// startOAuth redirects to the provider; a non-zero linkAccountID links the identity instead of logging in
+func (ctrl *AuthController) startOAuth(c *gin.Context, name string, linkAccountID uint) {
+	p, ok := ctrl.providers.Get(name)
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "unknown oauth provider"})
+		return
+	}
+
+	// Generate state, PKCE verifier and nonce, and bind them to this browser
+	st, err := newOAuthState(p.Name())
+	if err != nil {
+		ctrl.redirectWithError(c, "state_generation_failed")
+		return
+	}
+	st.LinkAccountID = linkAccountID
+
+	authURL, err := p.AuthCodeURL(c.Request.Context(), st.State, st.Verifier, st.Nonce)
+	if err != nil {
+		ctrl.redirectWithError(c, "provider_unavailable")
+		return
+	}
+
+	if err := ctrl.setStateCookie(c, st); err != nil {
+		ctrl.redirectWithError(c, "state_generation_failed")
+		return
+	}
+
+	c.Redirect(http.StatusTemporaryRedirect, authURL)
+}
+
+func (ctrl *AuthController) finishOAuth(c *gin.Context, name string) {
+	p, ok := ctrl.providers.Get(name)
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "unknown oauth provider"})
+		return
+	}
+
+	// Verify state against the signed cookie (login CSRF protection); the cookie is single-use
+	st, err := ctrl.readStateCookie(c)
+	ctrl.clearStateCookie(c)
+	if err != nil || !verifyState(st, p.Name(), c.Query("state")) {
+		ctrl.redirectWithError(c, "invalid_state")
+		return
+	}
+
+	// The user denied access or the provider failed
+	if c.Query("error") != "" {
+		ctrl.redirectWithError(c, "access_denied")
+		return
+	}
+
+	code := c.Query("code")
+	if code == "" {
+		ctrl.redirectWithError(c, "missing_code")
+		return
+	}
+
+	// Exchange code for token (PKCE verifier, nonce) and map the provider profile
+	profile, err := p.Exchange(c.Request.Context(), code, st.Verifier, st.Nonce)
 	if err != nil {
-		return nil, err
+		ctrl.redirectWithError(c, "failed_to_get_user_info")
+		return
+	}
+
+	// Link flow: attach the identity to the account that started it
+	if st.LinkAccountID != 0 {
+		if err := ctrl.authService.LinkIdentity(st.LinkAccountID, *profile); err != nil {
+			switch err.Error() {
+			case "identity already linked to another account":
+				ctrl.redirectWithError(c, "identity_already_linked")
+			case "provider already linked":
+				ctrl.redirectWithError(c, "provider_already_linked")
+			default:
+				ctrl.redirectWithError(c, "link_failed")
+			}
+			return
+		}
+		redirectURL := fmt.Sprintf("%s?linked=%s", ctrl.config.FrontendURL, url.QueryEscape(p.Name()))
+		c.Redirect(http.StatusTemporaryRedirect, redirectURL)
+		return
 	}
-	defer resp.Body.Close()
 
-	var userInfo GoogleUserInfo
-	if err := json.NewDecoder(resp.Body).Decode(&userInfo); err != nil {
-		return nil, err
+	// Handle OAuth login (match linked identity or verified email, create user if not exists)
+	result, err := ctrl.authService.HandleOAuthLogin(*profile)
+	if err != nil {
+		var banned *domain.AccountBannedError
+		if errors.As(err, &banned) {
+			ctrl.redirectWithError(c, "account_banned")
+			return
+		}
+		switch err.Error() {
+		case "provider email is not verified", "provider did not return an email":
+			ctrl.redirectWithError(c, "email_not_verified")
+		case "account email is not verified":
+			ctrl.redirectWithError(c, "account_not_verified")
+		default:
+			ctrl.redirectWithError(c, "login_failed")
+		}
+		return
 	}
 
-	return &userInfo, nil
+	// Second factor required: keep the challenge in a cookie for POST /login/2fa
+	if result.MFARequired {
+		c.SetSameSite(http.SameSiteLaxMode)
+		c.SetCookie(mfaChallengeCookie, result.ChallengeToken, int(ctrl.config.MFAChallengeTTL.Seconds()), "/", "", ctrl.config.IsProduction(), true)
+		c.Redirect(http.StatusTemporaryRedirect, fmt.Sprintf("%s?mfa_required=true", ctrl.config.FrontendURL))
+		return
+	}
+
+	// Set cookies
+	ctrl.setAuthCookies(c, result)
+
+	// Build redirect URL with user info
+	redirectURL := ctrl.buildOAuthRedirectURL(p.Name(), result.Account)
+	c.Redirect(http.StatusTemporaryRedirect, redirectURL)
 }
@@ -0,0 +1,266 @@
+package controller
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"api_go/internal/config"
+	"api_go/internal/domain"
+	"api_go/internal/modules/auth/provider"
+)
+
+const testFrontendURL = "http://frontend.test/auth/callback"
+
+// fakeProvider records what the callback passes to Exchange and fails it, so no account is needed
+type fakeProvider struct {
+	name                           string
+	authState, authVerifier        string
+	authNonce                      string
+	exchanged                      bool
+	gotCode, gotVerifier, gotNonce string
+}
+
+func (p *fakeProvider) Name() string { return p.name }
+
+func (p *fakeProvider) AuthCodeURL(_ context.Context, state, verifier, nonce string) (string, error) {
+	p.authState, p.authVerifier, p.authNonce = state, verifier, nonce
+	return "https://provider.test/authorize?state=" + url.QueryEscape(state), nil
+}
+
+func (p *fakeProvider) Exchange(_ context.Context, code, verifier, nonce string) (*domain.OAuthProfile, error) {
+	p.exchanged = true
+	p.gotCode, p.gotVerifier, p.gotNonce = code, verifier, nonce
+	return nil, errors.New("exchange disabled in tests")
+}
+
+func newOAuthTestController(providers ...provider.Provider) *AuthController {
+	registry := provider.NewRegistry(&config.Config{}) // no providers configured
+	for _, p := range providers {
+		registry.Register(p)
+	}
+	return &AuthController{
+		config:    &config.Config{JWTSecret: "test-secret", FrontendURL: testFrontendURL},
+		providers: registry,
+	}
+}
+
+func newOAuthTestRouter(ctrl *AuthController) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/auth/:provider", ctrl.OAuthStart)
+	r.GET("/auth/:provider/callback", ctrl.OAuthCallback)
+	return r
+}
+
+// startLogin runs GET /auth/:provider and returns the state cookie it set
+func startLogin(t *testing.T, r *gin.Engine, name string) *http.Cookie {
+	t.Helper()
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/auth/"+name, nil))
+	if w.Code != http.StatusTemporaryRedirect {
+		t.Fatalf("start status = %d", w.Code)
+	}
+	for _, cookie := range w.Result().Cookies() {
+		if cookie.Name == oauthStateCookie {
+			return cookie
+		}
+	}
+	t.Fatal("no state cookie set")
+	return nil
+}
+
+// callback runs GET /auth/:provider/callback and returns the redirect location
+func callback(r *gin.Engine, name, query string, cookie *http.Cookie) string {
+	req := httptest.NewRequest(http.MethodGet, "/auth/"+name+"/callback?"+query, nil)
+	if cookie != nil {
+		req.AddCookie(cookie)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w.Header().Get("Location")
+}
+
+func TestOAuthCallbackPassesVerifierAndNonceFromCookie(t *testing.T) {
+	p := &fakeProvider{name: "google"}
+	r := newOAuthTestRouter(newOAuthTestController(p))
+
+	cookie := startLogin(t, r, "google")
+	if !cookie.HttpOnly || cookie.SameSite != http.SameSiteLaxMode {
+		t.Errorf("state cookie must be httpOnly and SameSite=Lax, got %+v", cookie)
+	}
+	if strings.Contains(cookie.Value, p.authVerifier) {
+		t.Error("state cookie payload is expected to be encoded, not plain")
+	}
+
+	location := callback(r, "google", "state="+url.QueryEscape(p.authState)+"&code=the-code", cookie)
+
+	if !p.exchanged {
+		t.Fatalf("Exchange was not called, redirected to %s", location)
+	}
+	if p.gotCode != "the-code" || p.gotVerifier != p.authVerifier || p.gotNonce != p.authNonce {
+		t.Errorf("Exchange got code=%q verifier=%q nonce=%q", p.gotCode, p.gotVerifier, p.gotNonce)
+	}
+	if location != testFrontendURL+"?error=failed_to_get_user_info" {
+		t.Errorf("location = %q", location)
+	}
+}
+
+func TestOAuthCallbackRejectsInvalidState(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func(p *fakeProvider, cookie *http.Cookie) (string, string, *http.Cookie)
+	}{
+		{
+			name: "missing cookie",
+			build: func(p *fakeProvider, _ *http.Cookie) (string, string, *http.Cookie) {
+				return "google", "state=" + url.QueryEscape(p.authState) + "&code=c", nil
+			},
+		},
+		{
+			name: "state mismatch",
+			build: func(_ *fakeProvider, cookie *http.Cookie) (string, string, *http.Cookie) {
+				return "google", "state=attacker-state&code=c", cookie
+			},
+		},
+		{
+			name: "missing state",
+			build: func(_ *fakeProvider, cookie *http.Cookie) (string, string, *http.Cookie) {
+				return "google", "code=c", cookie
+			},
+		},
+		{
+			name: "cookie issued for another provider",
+			build: func(p *fakeProvider, cookie *http.Cookie) (string, string, *http.Cookie) {
+				return "github", "state=" + url.QueryEscape(p.authState) + "&code=c", cookie
+			},
+		},
+		{
+			name: "tampered payload",
+			build: func(p *fakeProvider, cookie *http.Cookie) (string, string, *http.Cookie) {
+				encoded, signature, _ := strings.Cut(cookie.Value, ".")
+				payload, _ := base64.RawURLEncoding.DecodeString(encoded)
+				var st oauthState
+				_ = json.Unmarshal(payload, &st)
+				st.Verifier = "attacker-verifier"
+				payload, _ = json.Marshal(st)
+				tampered := *cookie
+				tampered.Value = base64.RawURLEncoding.EncodeToString(payload) + "." + signature
+				return "google", "state=" + url.QueryEscape(p.authState) + "&code=c", &tampered
+			},
+		},
+		{
+			name: "missing signature",
+			build: func(p *fakeProvider, cookie *http.Cookie) (string, string, *http.Cookie) {
+				encoded, _, _ := strings.Cut(cookie.Value, ".")
+				tampered := *cookie
+				tampered.Value = encoded
+				return "google", "state=" + url.QueryEscape(p.authState) + "&code=c", &tampered
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			google := &fakeProvider{name: "google"}
+			github := &fakeProvider{name: "github"}
+			r := newOAuthTestRouter(newOAuthTestController(google, github))
+
+			cookie := startLogin(t, r, "google")
+			name, query, sent := tt.build(google, cookie)
+			location := callback(r, name, query, sent)
+
+			if location != testFrontendURL+"?error=invalid_state" {
+				t.Errorf("location = %q, want invalid_state", location)
+			}
+			if google.exchanged || github.exchanged {
+				t.Error("the code must not be exchanged without a valid state")
+			}
+		})
+	}
+}
+
+func TestReadStateCookie(t *testing.T) {
+	ctrl := newOAuthTestController()
+
+	// issue signs a state with the controller's secret and returns the cookie value
+	issue := func(t *testing.T, c *AuthController, st *oauthState) string {
+		t.Helper()
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		if err := c.setStateCookie(ctx, st); err != nil {
+			t.Fatalf("setStateCookie: %v", err)
+		}
+		return w.Result().Cookies()[0].Value
+	}
+	read := func(c *AuthController, value string) (*oauthState, error) {
+		ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+		ctx.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+		ctx.Request.AddCookie(&http.Cookie{Name: oauthStateCookie, Value: value})
+		return c.readStateCookie(ctx)
+	}
+
+	st, err := newOAuthState("google")
+	if err != nil {
+		t.Fatalf("newOAuthState: %v", err)
+	}
+	st.LinkAccountID = 42
+
+	got, err := read(ctrl, issue(t, ctrl, st))
+	if err != nil {
+		t.Fatalf("readStateCookie: %v", err)
+	}
+	if *got != *st {
+		t.Errorf("round trip = %+v, want %+v", got, st)
+	}
+
+	t.Run("expired", func(t *testing.T) {
+		expired := *st
+		expired.ExpiresAt = time.Now().Add(-time.Second).Unix()
+		if _, err := read(ctrl, issue(t, ctrl, &expired)); err == nil || err.Error() != "oauth state expired" {
+			t.Errorf("err = %v, want oauth state expired", err)
+		}
+	})
+
+	t.Run("signed with another secret", func(t *testing.T) {
+		other := newOAuthTestController()
+		other.config.JWTSecret = "other-secret"
+		if _, err := read(ctrl, issue(t, other, st)); err == nil || err.Error() != "invalid oauth state" {
+			t.Errorf("err = %v, want invalid oauth state", err)
+		}
+	})
+
+	t.Run("garbage", func(t *testing.T) {
+		for _, value := range []string{"", "abc", "abc.def", "!!!." + ctrl.signState("!!!")} {
+			if _, err := read(ctrl, value); err == nil {
+				t.Errorf("value %q was accepted", value)
+			}
+		}
+	})
+}
+
+func TestNewOAuthStateIsRandom(t *testing.T) {
+	a, err := newOAuthState("google")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := newOAuthState("google")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.State == b.State || a.Nonce == b.Nonce || a.Verifier == b.Verifier {
+		t.Error("state, nonce and verifier must be unique per login")
+	}
+	if len(a.Verifier) < 43 {
+		t.Errorf("PKCE verifier too short: %d characters", len(a.Verifier))
+	}
+}
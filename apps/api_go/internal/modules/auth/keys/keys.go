@@ -0,0 +1,252 @@
+// Package keys holds the JWT signing and verification keys.
+//
+// Tokens are signed with RS256 or EdDSA when a private key file is configured, and carry the key's
+// "kid" (its RFC 7638 thumbprint) so other services can pick the right key from /.well-known/jwks.json.
+// Retired keys stay listed as verification-only until the tokens they signed have expired.
+// Without a key file, tokens fall back to HS256 with the shared JWT secret.
+package keys
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"api_go/internal/config"
+	"api_go/internal/domain"
+)
+
+// key is a public key accepted for verification
+type key struct {
+	id     string
+	method jwt.SigningMethod
+	public crypto.PublicKey
+	jwk    domain.JWKDTO
+}
+
+// KeySet signs tokens with the current key and verifies tokens signed by any listed key
+type KeySet struct {
+	signingKey   crypto.PrivateKey // nil in HS256 mode
+	signing      *key
+	verification map[string]*key
+	secret       []byte
+	published    []domain.JWKDTO
+}
+
+// minRSAKeySize rejects RSA keys too weak for RS256
+const minRSAKeySize = 2048
+
+// Load builds the key set from config (JWT_SIGNING_KEY_FILE and JWT_VERIFICATION_KEY_FILES)
+func Load(cfg *config.Config) (*KeySet, error) {
+	ks := &KeySet{
+		verification: make(map[string]*key),
+		secret:       []byte(cfg.JWTSecret),
+	}
+
+	if cfg.JWTSigningKeyFile != "" {
+		private, err := readPrivateKey(cfg.JWTSigningKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		signer, ok := private.(crypto.Signer)
+		if !ok {
+			return nil, fmt.Errorf("%s: key cannot sign", cfg.JWTSigningKeyFile)
+		}
+		k, err := newKey(signer.Public())
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", cfg.JWTSigningKeyFile, err)
+		}
+		ks.signingKey = private
+		ks.signing = k
+		ks.add(k)
+	}
+
+	for _, path := range strings.Split(cfg.JWTVerificationKeyFiles, ",") {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			continue
+		}
+		public, err := readPublicKey(path)
+		if err != nil {
+			return nil, err
+		}
+		k, err := newKey(public)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		ks.add(k)
+	}
+
+	return ks, nil
+}
+
+// Asymmetric reports whether tokens are signed with a private key (false in HS256 mode)
+func (ks *KeySet) Asymmetric() bool {
+	return ks.signing != nil
+}
+
+// Sign signs the claims with the current key, setting the kid header
+func (ks *KeySet) Sign(claims jwt.Claims) (string, error) {
+	if ks.signing == nil {
+		return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(ks.secret)
+	}
+
+	token := jwt.NewWithClaims(ks.signing.method, claims)
+	token.Header["kid"] = ks.signing.id
+	return token.SignedString(ks.signingKey)
+}
+
+// Parse verifies the token signature and standard claims and returns its claims.
+// Tokens with a kid must match a listed key and its algorithm; HS256 tokens are accepted only in HS256 mode.
+func (ks *KeySet) Parse(tokenString string) (jwt.MapClaims, error) {
+	token, err := jwt.Parse(tokenString, ks.keyFunc)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return nil, errors.New("invalid token")
+	}
+	return claims, nil
+}
+
+// JWKS returns the public keys in JSON Web Key Set format
+func (ks *KeySet) JWKS() domain.JWKSDTO {
+	return domain.JWKSDTO{Keys: append([]domain.JWKDTO{}, ks.published...)}
+}
+
+func (ks *KeySet) keyFunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok || ks.signing != nil {
+			return nil, errors.New("unexpected signing method")
+		}
+		return ks.secret, nil
+	}
+
+	k, ok := ks.verification[kid]
+	if !ok {
+		return nil, errors.New("unknown signing key")
+	}
+	if token.Method.Alg() != k.method.Alg() {
+		return nil, errors.New("unexpected signing method")
+	}
+	return k.public, nil
+}
+
+func (ks *KeySet) add(k *key) {
+	if _, exists := ks.verification[k.id]; exists {
+		return
+	}
+	ks.verification[k.id] = k
+	ks.published = append(ks.published, k.jwk)
+}
+
+// newKey maps a public key to its signing method and JWK (kid is the RFC 7638 thumbprint)
+func newKey(public crypto.PublicKey) (*key, error) {
+	var (
+		method     jwt.SigningMethod
+		jwk        domain.JWKDTO
+		thumbprint string
+	)
+
+	switch pub := public.(type) {
+	case *rsa.PublicKey:
+		if pub.N.BitLen() < minRSAKeySize {
+			return nil, fmt.Errorf("RSA key must be at least %d bits", minRSAKeySize)
+		}
+		method = jwt.SigningMethodRS256
+		jwk = domain.JWKDTO{
+			Kty: "RSA",
+			Alg: method.Alg(),
+			N:   b64(pub.N.Bytes()),
+			E:   b64(big.NewInt(int64(pub.E)).Bytes()),
+		}
+		thumbprint = fmt.Sprintf(`{"e":%q,"kty":"RSA","n":%q}`, jwk.E, jwk.N)
+	case ed25519.PublicKey:
+		method = jwt.SigningMethodEdDSA
+		jwk = domain.JWKDTO{
+			Kty: "OKP",
+			Alg: method.Alg(),
+			Crv: "Ed25519",
+			X:   b64(pub),
+		}
+		thumbprint = fmt.Sprintf(`{"crv":"Ed25519","kty":"OKP","x":%q}`, jwk.X)
+	default:
+		return nil, errors.New("unsupported key type (use RSA or Ed25519)")
+	}
+
+	sum := sha256.Sum256([]byte(thumbprint))
+	jwk.Kid = b64(sum[:])
+	jwk.Use = "sig"
+
+	return &key{id: jwk.Kid, method: method, public: public, jwk: jwk}, nil
+}
+
+func readPrivateKey(path string) (crypto.PrivateKey, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		return x509.ParsePKCS8PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("%s: expected a private key, found %q", path, block.Type)
+	}
+}
+
+// readPublicKey accepts a public key or a private key (whose public half is used)
+func readPublicKey(path string) (crypto.PublicKey, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch block.Type {
+	case "PUBLIC KEY":
+		return x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		return x509.ParsePKCS1PublicKey(block.Bytes)
+	}
+
+	private, err := readPrivateKey(path)
+	if err != nil {
+		return nil, err
+	}
+	signer, ok := private.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("%s: unsupported key", path)
+	}
+	return signer.Public(), nil
+}
+
+func readPEM(path string) (*pem.Block, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%s: no PEM data found", path)
+	}
+	return block, nil
+}
+
+func b64(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
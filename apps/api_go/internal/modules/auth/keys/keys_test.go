@@ -0,0 +1,225 @@
+package keys
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"api_go/internal/config"
+)
+
+// writeRSAKey writes a PKCS#1 RSA private key and returns its path
+func writeRSAKey(t *testing.T, bits int) string {
+	t.Helper()
+	private, err := rsa.GenerateKey(rand.Reader, bits)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return writePEM(t, "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(private))
+}
+
+// writeEd25519Key writes a PKCS#8 Ed25519 private key and returns its path
+func writeEd25519Key(t *testing.T) string {
+	t.Helper()
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return writePEM(t, "PRIVATE KEY", der)
+}
+
+func writePEM(t *testing.T, blockType string, der []byte) string {
+	t.Helper()
+	f, err := os.CreateTemp(t.TempDir(), "key-*.pem")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if err := pem.Encode(f, &pem.Block{Type: blockType, Bytes: der}); err != nil {
+		t.Fatal(err)
+	}
+	return f.Name()
+}
+
+func load(t *testing.T, cfg *config.Config) *KeySet {
+	t.Helper()
+	ks, err := Load(cfg)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	return ks
+}
+
+func claims() jwt.MapClaims {
+	return jwt.MapClaims{"sub": 1, "exp": time.Now().Add(time.Minute).Unix()}
+}
+
+func TestHS256Mode(t *testing.T) {
+	ks := load(t, &config.Config{JWTSecret: "secret"})
+	if ks.Asymmetric() || len(ks.JWKS().Keys) != 0 {
+		t.Fatal("HS256 mode must not publish keys")
+	}
+
+	token, err := ks.Sign(claims())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ks.Parse(token); err != nil {
+		t.Errorf("Parse: %v", err)
+	}
+
+	other := load(t, &config.Config{JWTSecret: "other"})
+	if _, err := other.Parse(token); err == nil {
+		t.Error("a token signed with another secret was accepted")
+	}
+}
+
+func TestAsymmetricModes(t *testing.T) {
+	tests := map[string]struct {
+		path string
+		alg  string
+		kty  string
+	}{
+		"RS256": {writeRSAKey(t, 2048), "RS256", "RSA"},
+		"EdDSA": {writeEd25519Key(t), "EdDSA", "OKP"},
+	}
+	for name, tt := range tests {
+		ks := load(t, &config.Config{JWTSecret: "secret", JWTSigningKeyFile: tt.path})
+		if !ks.Asymmetric() {
+			t.Fatalf("%s: key set is not asymmetric", name)
+		}
+
+		jwks := ks.JWKS().Keys
+		if len(jwks) != 1 || jwks[0].Alg != tt.alg || jwks[0].Kty != tt.kty || jwks[0].Use != "sig" {
+			t.Fatalf("%s: jwks = %+v", name, jwks)
+		}
+
+		signed, err := ks.Sign(claims())
+		if err != nil {
+			t.Fatalf("%s: Sign: %v", name, err)
+		}
+		parsed, _, err := jwt.NewParser().ParseUnverified(signed, jwt.MapClaims{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if parsed.Header["kid"] != jwks[0].Kid || parsed.Method.Alg() != tt.alg {
+			t.Errorf("%s: header = %v, want kid %s", name, parsed.Header, jwks[0].Kid)
+		}
+		if _, err := ks.Parse(signed); err != nil {
+			t.Errorf("%s: Parse: %v", name, err)
+		}
+	}
+}
+
+func TestKeyRotation(t *testing.T) {
+	oldKey, newKey := writeRSAKey(t, 2048), writeEd25519Key(t)
+
+	before := load(t, &config.Config{JWTSigningKeyFile: oldKey})
+	token, err := before.Sign(claims())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// After rotation the old key is verification-only
+	after := load(t, &config.Config{JWTSigningKeyFile: newKey, JWTVerificationKeyFiles: " " + oldKey + ", "})
+	if _, err := after.Parse(token); err != nil {
+		t.Errorf("token of the retired key: %v", err)
+	}
+	if got := len(after.JWKS().Keys); got != 2 {
+		t.Errorf("published %d keys, want 2", got)
+	}
+
+	// Once the old key is dropped its tokens are rejected
+	dropped := load(t, &config.Config{JWTSigningKeyFile: newKey})
+	if _, err := dropped.Parse(token); err == nil || !strings.Contains(err.Error(), "unknown signing key") {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func TestParseRejectsForeignTokens(t *testing.T) {
+	ks := load(t, &config.Config{JWTSecret: "secret", JWTSigningKeyFile: writeRSAKey(t, 2048)})
+	kid := ks.JWKS().Keys[0].Kid
+
+	// An HS256 token signed with the (possibly leaked) shared secret
+	hs256, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims()).SignedString([]byte("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// An HS256 token claiming the RSA key's kid (algorithm confusion)
+	confused := jwt.NewWithClaims(jwt.SigningMethodHS256, claims())
+	confused.Header["kid"] = kid
+	confusedToken, err := confused.SignedString([]byte("anything"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A token signed by a key that is not listed
+	stranger := load(t, &config.Config{JWTSigningKeyFile: writeRSAKey(t, 2048)})
+	strangerToken, err := stranger.Sign(claims())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]string{
+		"hs256 without kid": hs256,
+		"alg mismatch":      confusedToken,
+		"unknown kid":       strangerToken,
+	}
+	for name, token := range tests {
+		if _, err := ks.Parse(token); err == nil {
+			t.Errorf("%s: token accepted", name)
+		}
+	}
+}
+
+func TestLoadRejectsWeakAndInvalidKeys(t *testing.T) {
+	missing := filepath.Join(t.TempDir(), "missing.pem")
+	notPEM := filepath.Join(t.TempDir(), "key.pem")
+	if err := os.WriteFile(notPEM, []byte("not a key"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]*config.Config{
+		"rsa 1024":             {JWTSigningKeyFile: writeRSAKey(t, 1024)},
+		"rsa 1024 verify only": {JWTVerificationKeyFiles: writeRSAKey(t, 1024)},
+		"missing file":         {JWTSigningKeyFile: missing},
+		"not pem":              {JWTSigningKeyFile: notPEM},
+	}
+	for name, cfg := range tests {
+		if _, err := Load(cfg); err == nil {
+			t.Errorf("%s: Load succeeded", name)
+		}
+	}
+}
+
+// TestThumbprint checks the kid against the example of RFC 7638 section 3.1
+func TestThumbprint(t *testing.T) {
+	n, err := base64.RawURLEncoding.DecodeString("0vx7agoebGcQSuuPiLJXZptN9nndrQmbXEps2aiAFbWhM78LhWx4cbbfAAtVT86zwu1RK7aPFFxuhDR1L6tSoc_BJECPebWKRXjBZCiFV4n3oknjhMstn64tZ_2W-5JsGY4Hc5n9yBXArwl93lqt7_RN5w6Cf0h4QyQ5v-65YGjQR0_FDW2QvzqY368QQMicAtaSqzs8KJZgnYb9c7d0zgdAZHzu6qMQvRL5hajrn1n91CbOpbISD08qNLyrdkt-bFTWhAI4vMQFh6WeZu0fM4lFd2NcRwr3XPksINHaQ-G_xBniIqbw0Ls1jF44-csFCur-kEgU8awapJzKnqDKgw")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	k, err := newKey(&rsa.PublicKey{N: new(big.Int).SetBytes(n), E: 65537})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs"; k.id != want {
+		t.Errorf("kid = %s, want %s", k.id, want)
+	}
+}
@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"errors"
 	"net/http"
 	"strings"
 
@@ -9,33 +10,17 @@ import (
 	"api_go/internal/domain"
 )
 
-// JWTMiddleware creates a JWT authentication middleware
-func JWTMiddleware(authService domain.AuthService) gin.HandlerFunc {
+// JWTMiddleware creates a JWT authentication middleware.
+// Personal access tokens are accepted only when they carry every listed scope;
+// without scopes the route is limited to browser sessions.
+func JWTMiddleware(authService domain.AuthService, scopes ...domain.TokenScope) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// 1. Extract token from Authorization header or cookie
-		token := extractToken(c)
-		if token == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "no token provided"})
-			c.Abort()
+		if !authenticate(c, authService) {
 			return
 		}
-
-		// 2. Validate token
-		payload, err := authService.ValidateJWT(token)
-		if err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid token"})
-			c.Abort()
+		if !authorizeScopes(c, scopes) {
 			return
 		}
-
-		// 3. Set user info in context
-		c.Set("user_id", payload.Sub)
-		c.Set("user_email", payload.Email)
-		c.Set("user_role", payload.Role)
-		c.Set("user_name", payload.Name)
-		c.Set("user_provider", payload.Provider)
-		c.Set("jwt_payload", payload)
-
 		c.Next()
 	}
 }
@@ -49,18 +34,13 @@ func OptionalJWTMiddleware(authService domain.AuthService) gin.HandlerFunc {
 			return
 		}
 
-		payload, err := authService.ValidateJWT(token)
+		payload, err := authService.AuthenticateToken(token)
 		if err != nil {
 			c.Next()
 			return
 		}
 
-		c.Set("user_id", payload.Sub)
-		c.Set("user_email", payload.Email)
-		c.Set("user_role", payload.Role)
-		c.Set("user_name", payload.Name)
-		c.Set("user_provider", payload.Provider)
-		c.Set("jwt_payload", payload)
+		setPayload(c, payload)
 
 		c.Next()
 	}
@@ -69,24 +49,111 @@ func OptionalJWTMiddleware(authService domain.AuthService) gin.HandlerFunc {
 // RoleMiddleware creates a role-based authorization middleware
 func RoleMiddleware(allowedRoles ...string) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		role, exists := c.Get("user_role")
-		if !exists {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
-			c.Abort()
+		if !authorizeRoles(c, allowedRoles) {
 			return
 		}
+		c.Next()
+	}
+}
 
-		userRole := role.(string)
-		for _, allowedRole := range allowedRoles {
-			if userRole == allowedRole {
-				c.Next()
-				return
-			}
+// authenticate validates the request token and stores the payload in context.
+// It aborts with 401 and returns false when the request is not authenticated.
+func authenticate(c *gin.Context, authService domain.AuthService) bool {
+	// 1. Extract token from Authorization header or cookie
+	token := extractToken(c)
+	if token == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "no token provided"})
+		c.Abort()
+		return false
+	}
+
+	// 2. Validate token (signature, expiry and session revocation)
+	payload, err := authService.AuthenticateToken(token)
+	if err != nil {
+		var banned *domain.AccountBannedError
+		if errors.As(err, &banned) {
+			AbortBanned(c, banned)
+			return false
 		}
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid token"})
+		c.Abort()
+		return false
+	}
+
+	// 3. Set user info in context
+	setPayload(c, payload)
+	return true
+}
 
-		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+// AbortBanned responds 403 with the reason and end of the ban
+func AbortBanned(c *gin.Context, banned *domain.AccountBannedError) {
+	body := gin.H{"error": banned.Error(), "reason": banned.Reason}
+	if banned.Until != nil {
+		body["banned_until"] = banned.Until
+	}
+	c.JSON(http.StatusForbidden, body)
+	c.Abort()
+}
+
+// authorizeRoles checks the role stored by authenticate.
+// It aborts with 401/403 and returns false when the role is not allowed.
+func authorizeRoles(c *gin.Context, allowedRoles []string) bool {
+	role, exists := c.Get("user_role")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
 		c.Abort()
+		return false
 	}
+
+	userRole := role.(string)
+	for _, allowedRole := range allowedRoles {
+		if userRole == allowedRole {
+			return true
+		}
+	}
+
+	// The account has the role, but this session has not passed the required second factor
+	if payload, ok := GetPayload(c); ok && payload.MFARequired {
+		c.JSON(http.StatusForbidden, gin.H{"error": "two-factor authentication required"})
+		c.Abort()
+		return false
+	}
+
+	c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+	c.Abort()
+	return false
+}
+
+// authorizeScopes checks personal access tokens against the scopes declared by the route.
+// It aborts with 403 and returns false when the token may not be used here.
+func authorizeScopes(c *gin.Context, scopes []domain.TokenScope) bool {
+	payload, ok := GetPayload(c)
+	if !ok || !payload.IsPersonalToken() {
+		return true
+	}
+
+	if len(scopes) == 0 {
+		c.JSON(http.StatusForbidden, gin.H{"error": "personal access tokens are not allowed on this route"})
+		c.Abort()
+		return false
+	}
+	for _, scope := range scopes {
+		if !payload.HasScope(scope) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "token is missing scope " + string(scope)})
+			c.Abort()
+			return false
+		}
+	}
+	return true
+}
+
+func setPayload(c *gin.Context, payload *domain.JWTPayload) {
+	c.Set("user_id", payload.Sub)
+	c.Set("user_email", payload.Email)
+	c.Set("user_role", payload.Role)
+	c.Set("user_name", payload.Name)
+	c.Set("user_provider", payload.Provider)
+	c.Set("jwt_payload", payload)
 }
 
 // extractToken extracts JWT token from Authorization header or cookie
@@ -122,3 +189,22 @@ func GetUserRole(c *gin.Context) (string, bool) {
 	}
 	return role.(string), true
 }
+
+// GetPayload helper to get the JWT payload from context
+func GetPayload(c *gin.Context) (*domain.JWTPayload, bool) {
+	value, exists := c.Get("jwt_payload")
+	if !exists {
+		return nil, false
+	}
+	payload, ok := value.(*domain.JWTPayload)
+	return payload, ok
+}
+
+// GetActor helper to get the acting account from the JWT payload in context
+func GetActor(c *gin.Context) (domain.Actor, bool) {
+	payload, ok := GetPayload(c)
+	if !ok || payload.Sub == 0 {
+		return domain.Actor{}, false
+	}
+	return domain.Actor{ID: payload.Sub, Role: domain.AccountRole(payload.Role)}, true
+}
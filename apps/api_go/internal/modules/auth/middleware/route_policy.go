@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"api_go/internal/domain"
+)
+
+// RoutePolicy builds the per-route middleware used by module controllers.
+// Controllers receive it in RegisterRoutes and attach one policy to each route.
+type RoutePolicy struct {
+	authService domain.AuthService
+}
+
+// NewRoutePolicy creates a new RoutePolicy instance
+func NewRoutePolicy(authService domain.AuthService) *RoutePolicy {
+	return &RoutePolicy{authService: authService}
+}
+
+// Public allows anonymous access but still identifies the caller when a valid token is sent
+func (p *RoutePolicy) Public() gin.HandlerFunc {
+	return OptionalJWTMiddleware(p.authService)
+}
+
+// Authenticated requires a valid access token. Personal access tokens are accepted only
+// when the route lists scopes and the token has all of them.
+func (p *RoutePolicy) Authenticated(scopes ...domain.TokenScope) gin.HandlerFunc {
+	return JWTMiddleware(p.authService, scopes...)
+}
+
+// RequireRoles requires a valid access token belonging to one of the given roles
+// (personal access tokens are not accepted)
+func (p *RoutePolicy) RequireRoles(roles ...domain.AccountRole) gin.HandlerFunc {
+	return p.requireRoles(roles, nil)
+}
+
+// Moderators requires an admin or mod account; personal access tokens need the given scopes
+func (p *RoutePolicy) Moderators(scopes ...domain.TokenScope) gin.HandlerFunc {
+	return p.requireRoles([]domain.AccountRole{domain.AccountRoleAdmin, domain.AccountRoleMod}, scopes)
+}
+
+// Admins requires an admin account; personal access tokens need the given scopes
+func (p *RoutePolicy) Admins(scopes ...domain.TokenScope) gin.HandlerFunc {
+	return p.requireRoles([]domain.AccountRole{domain.AccountRoleAdmin}, scopes)
+}
+
+func (p *RoutePolicy) requireRoles(roles []domain.AccountRole, scopes []domain.TokenScope) gin.HandlerFunc {
+	allowedRoles := make([]string, len(roles))
+	for i, role := range roles {
+		allowedRoles[i] = string(role)
+	}
+
+	return func(c *gin.Context) {
+		if !authenticate(c, p.authService) {
+			return
+		}
+		if !authorizeScopes(c, scopes) {
+			return
+		}
+		if !authorizeRoles(c, allowedRoles) {
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,102 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/github"
+
+	"api_go/internal/config"
+	"api_go/internal/domain"
+)
+
+const githubAPIURL = "https://api.github.com"
+
+// githubUser represents the response from GET /user
+type githubUser struct {
+	ID        int64  `json:"id"`
+	Login     string `json:"login"`
+	Name      string `json:"name"`
+	AvatarURL string `json:"avatar_url"`
+}
+
+// githubEmail represents an entry of GET /user/emails
+type githubEmail struct {
+	Email    string `json:"email"`
+	Primary  bool   `json:"primary"`
+	Verified bool   `json:"verified"`
+}
+
+// githubProvider implements GitHub OAuth (plain OAuth2, no OpenID Connect, so there is no nonce)
+type githubProvider struct {
+	config *oauth2.Config
+	apiURL string
+}
+
+// newGitHubProvider creates the GitHub provider
+func newGitHubProvider(cfg *config.Config, redirectURL string) *githubProvider {
+	return &githubProvider{
+		config: &oauth2.Config{
+			ClientID:     cfg.GitHubClientID,
+			ClientSecret: cfg.GitHubSecret,
+			RedirectURL:  redirectURL,
+			Scopes:       []string{"read:user", "user:email"},
+			Endpoint:     github.Endpoint,
+		},
+		apiURL: githubAPIURL,
+	}
+}
+
+// Name returns the provider name
+func (p *githubProvider) Name() string {
+	return "github"
+}
+
+// AuthCodeURL builds the authorization URL with PKCE challenge
+func (p *githubProvider) AuthCodeURL(ctx context.Context, state, verifier, nonce string) (string, error) {
+	return p.config.AuthCodeURL(state, oauth2.S256ChallengeOption(verifier)), nil
+}
+
+// Exchange trades the code for a token and reads the user and their primary email
+func (p *githubProvider) Exchange(ctx context.Context, code, verifier, nonce string) (*domain.OAuthProfile, error) {
+	token, err := p.config.Exchange(ctx, code, oauth2.VerifierOption(verifier))
+	if err != nil {
+		return nil, err
+	}
+	client := p.config.Client(ctx, token)
+
+	var user githubUser
+	if err := getJSON(ctx, client, p.apiURL+"/user", &user); err != nil {
+		return nil, err
+	}
+	if user.ID == 0 {
+		return nil, errors.New("github user has no id")
+	}
+
+	// The public profile email may be empty or unverified; use the primary address instead
+	var emails []githubEmail
+	if err := getJSON(ctx, client, p.apiURL+"/user/emails", &emails); err != nil {
+		return nil, err
+	}
+
+	profile := &domain.OAuthProfile{
+		Provider: p.Name(),
+		Subject:  strconv.FormatInt(user.ID, 10),
+		Name:     user.Name,
+		Avatar:   user.AvatarURL,
+	}
+	if profile.Name == "" {
+		profile.Name = user.Login
+	}
+	for _, e := range emails {
+		if e.Primary {
+			profile.Email = e.Email
+			profile.EmailVerified = e.Verified
+			break
+		}
+	}
+
+	return profile, nil
+}
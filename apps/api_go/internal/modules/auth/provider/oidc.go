@@ -0,0 +1,272 @@
+package provider
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+
+	"api_go/internal/config"
+	"api_go/internal/domain"
+)
+
+// oidcEndpoints are the provider endpoints, either static or read from the discovery document
+type oidcEndpoints struct {
+	Issuer      string `json:"issuer"`
+	AuthURL     string `json:"authorization_endpoint"`
+	TokenURL    string `json:"token_endpoint"`
+	UserInfoURL string `json:"userinfo_endpoint"`
+}
+
+// oidcClaims holds the ID token and userinfo claims mapped to a profile
+type oidcClaims struct {
+	Nonce             string   `json:"nonce"`
+	Email             string   `json:"email"`
+	EmailVerified     flexBool `json:"email_verified"`
+	Name              string   `json:"name"`
+	PreferredUsername string   `json:"preferred_username"`
+	Picture           string   `json:"picture"`
+	jwt.RegisteredClaims
+}
+
+// flexBool accepts both true and "true" (some providers send email_verified as a string)
+type flexBool bool
+
+func (b *flexBool) UnmarshalJSON(data []byte) error {
+	*b = flexBool(strings.Trim(string(data), `"`) == "true")
+	return nil
+}
+
+// oidcProvider implements OpenID Connect authorization code flow with PKCE and nonce
+type oidcProvider struct {
+	name         string
+	clientID     string
+	clientSecret string
+	redirectURL  string
+	scopes       []string
+	issuers      []string // accepted "iss" values
+
+	discoveryURL string // empty when endpoints are static
+	mu           sync.Mutex
+	endpoints    *oidcEndpoints
+}
+
+// newGoogleProvider creates the Google provider (static endpoints)
+func newGoogleProvider(cfg *config.Config, redirectURL string) *oidcProvider {
+	return &oidcProvider{
+		name:         "google",
+		clientID:     cfg.GoogleClientID,
+		clientSecret: cfg.GoogleSecret,
+		redirectURL:  redirectURL,
+		scopes:       []string{"openid", "email", "profile"},
+		issuers:      []string{"https://accounts.google.com", "accounts.google.com"},
+		endpoints: &oidcEndpoints{
+			AuthURL:     google.Endpoint.AuthURL,
+			TokenURL:    google.Endpoint.TokenURL,
+			UserInfoURL: "https://openidconnect.googleapis.com/v1/userinfo",
+		},
+	}
+}
+
+// newGitLabProvider creates the GitLab provider; the base URL allows self-hosted instances
+func newGitLabProvider(cfg *config.Config, redirectURL string) *oidcProvider {
+	baseURL := strings.TrimRight(cfg.GitLabBaseURL, "/")
+	return &oidcProvider{
+		name:         "gitlab",
+		clientID:     cfg.GitLabClientID,
+		clientSecret: cfg.GitLabSecret,
+		redirectURL:  redirectURL,
+		scopes:       []string{"openid", "email", "profile"},
+		issuers:      []string{baseURL},
+		endpoints: &oidcEndpoints{
+			AuthURL:     baseURL + "/oauth/authorize",
+			TokenURL:    baseURL + "/oauth/token",
+			UserInfoURL: baseURL + "/oauth/userinfo",
+		},
+	}
+}
+
+// newDiscoveryProvider creates a generic OpenID Connect provider whose endpoints are discovered from the issuer
+func newDiscoveryProvider(cfg *config.Config, redirectURL string) *oidcProvider {
+	issuer := strings.TrimRight(cfg.OIDCIssuerURL, "/")
+	return &oidcProvider{
+		name:         cfg.OIDCName,
+		clientID:     cfg.OIDCClientID,
+		clientSecret: cfg.OIDCSecret,
+		redirectURL:  redirectURL,
+		scopes:       strings.Fields(cfg.OIDCScopes),
+		issuers:      []string{issuer},
+		discoveryURL: issuer + "/.well-known/openid-configuration",
+	}
+}
+
+// Name returns the provider name
+func (p *oidcProvider) Name() string {
+	return p.name
+}
+
+// AuthCodeURL builds the authorization URL with PKCE challenge and nonce
+func (p *oidcProvider) AuthCodeURL(ctx context.Context, state, verifier, nonce string) (string, error) {
+	cfg, _, err := p.oauth2Config(ctx)
+	if err != nil {
+		return "", err
+	}
+	return cfg.AuthCodeURL(
+		state,
+		oauth2.S256ChallengeOption(verifier),
+		oauth2.SetAuthURLParam("nonce", nonce),
+	), nil
+}
+
+// Exchange trades the code for tokens, verifies the ID token and reads the userinfo endpoint
+func (p *oidcProvider) Exchange(ctx context.Context, code, verifier, nonce string) (*domain.OAuthProfile, error) {
+	cfg, endpoints, err := p.oauth2Config(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := cfg.Exchange(ctx, code, oauth2.VerifierOption(verifier))
+	if err != nil {
+		return nil, err
+	}
+
+	claims, err := verifyIDToken(token, p.clientID, nonce, p.issuers...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Userinfo is authoritative for profile data when available, but must describe the same subject
+	if endpoints.UserInfoURL != "" {
+		var info oidcClaims
+		if err := getJSON(ctx, cfg.Client(ctx, token), endpoints.UserInfoURL, &info); err != nil {
+			return nil, err
+		}
+		if info.Subject != claims.Subject {
+			return nil, errors.New("userinfo subject mismatch")
+		}
+		claims.Email = info.Email
+		claims.EmailVerified = info.EmailVerified
+		claims.Name = info.Name
+		claims.PreferredUsername = info.PreferredUsername
+		claims.Picture = info.Picture
+	}
+
+	name := claims.Name
+	if name == "" {
+		name = claims.PreferredUsername
+	}
+
+	return &domain.OAuthProfile{
+		Provider:      p.name,
+		Subject:       claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: bool(claims.EmailVerified),
+		Name:          name,
+		Avatar:        claims.Picture,
+	}, nil
+}
+
+// oauth2Config returns the client config, running discovery on first use
+func (p *oidcProvider) oauth2Config(ctx context.Context) (*oauth2.Config, *oidcEndpoints, error) {
+	endpoints, err := p.resolveEndpoints(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &oauth2.Config{
+		ClientID:     p.clientID,
+		ClientSecret: p.clientSecret,
+		RedirectURL:  p.redirectURL,
+		Scopes:       p.scopes,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  endpoints.AuthURL,
+			TokenURL: endpoints.TokenURL,
+		},
+	}, endpoints, nil
+}
+
+// resolveEndpoints fetches and caches the discovery document; failures are retried on the next call
+func (p *oidcProvider) resolveEndpoints(ctx context.Context) (*oidcEndpoints, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.endpoints != nil {
+		return p.endpoints, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	var doc oidcEndpoints
+	if err := getJSON(ctx, http.DefaultClient, p.discoveryURL, &doc); err != nil {
+		return nil, fmt.Errorf("oidc discovery failed: %w", err)
+	}
+	if strings.TrimRight(doc.Issuer, "/") != p.issuers[0] {
+		return nil, errors.New("oidc discovery issuer mismatch")
+	}
+	if doc.AuthURL == "" || doc.TokenURL == "" {
+		return nil, errors.New("oidc discovery document is missing endpoints")
+	}
+
+	// Accept the issuer exactly as advertised (with or without trailing slash)
+	p.issuers = append(p.issuers, doc.Issuer)
+	p.endpoints = &doc
+	return p.endpoints, nil
+}
+
+// verifyIDToken checks nonce, audience, issuer and expiry of the ID token returned with the access token.
+// The signature is not re-verified: the token comes straight from the token endpoint over TLS
+// (OpenID Connect Core 3.1.3.7).
+func verifyIDToken(token *oauth2.Token, clientID, nonce string, issuers ...string) (*oidcClaims, error) {
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok || rawIDToken == "" {
+		return nil, errors.New("missing id_token")
+	}
+
+	var claims oidcClaims
+	if _, _, err := jwt.NewParser().ParseUnverified(rawIDToken, &claims); err != nil {
+		return nil, err
+	}
+
+	if subtle.ConstantTimeCompare([]byte(claims.Nonce), []byte(nonce)) != 1 {
+		return nil, errors.New("nonce mismatch")
+	}
+
+	audienceOK := false
+	for _, aud := range claims.Audience {
+		if aud == clientID {
+			audienceOK = true
+			break
+		}
+	}
+	if !audienceOK {
+		return nil, errors.New("audience mismatch")
+	}
+
+	issuerOK := false
+	for _, iss := range issuers {
+		if claims.Issuer == iss {
+			issuerOK = true
+			break
+		}
+	}
+	if !issuerOK {
+		return nil, errors.New("issuer mismatch")
+	}
+
+	if claims.ExpiresAt != nil && time.Now().After(claims.ExpiresAt.Time) {
+		return nil, errors.New("id_token expired")
+	}
+	if claims.Subject == "" {
+		return nil, errors.New("id_token has no subject")
+	}
+
+	return &claims, nil
+}
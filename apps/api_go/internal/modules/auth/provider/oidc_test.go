@@ -0,0 +1,256 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/oauth2"
+)
+
+const (
+	testClientID = "client-id"
+	testIssuer   = "https://accounts.google.com"
+	testCode     = "auth-code"
+	testVerifier = "verifier-0123456789-0123456789-0123456789"
+	testNonce    = "nonce-value"
+)
+
+// fakeGoogle stands in for the token and userinfo endpoints
+type fakeGoogle struct {
+	server       *httptest.Server
+	idClaims     jwt.MapClaims
+	userinfo     map[string]interface{}
+	gotVerifier  string
+	userinfoAuth string
+}
+
+func newFakeGoogle(t *testing.T) *fakeGoogle {
+	t.Helper()
+	f := &fakeGoogle{
+		idClaims: jwt.MapClaims{
+			"iss":   testIssuer,
+			"aud":   testClientID,
+			"sub":   "google-123",
+			"nonce": testNonce,
+			"exp":   time.Now().Add(time.Hour).Unix(),
+			"email": "id-token@example.com",
+		},
+		userinfo: map[string]interface{}{
+			"sub":            "google-123",
+			"email":          "ada@example.com",
+			"email_verified": "true",
+			"name":           "Ada Lovelace",
+			"picture":        "https://example.com/ada.png",
+		},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil || r.Form.Get("code") != testCode {
+			http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+			return
+		}
+		f.gotVerifier = r.Form.Get("code_verifier")
+
+		idToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, f.idClaims).SignedString([]byte("unused"))
+		if err != nil {
+			t.Errorf("sign id_token: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": "access-token",
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+			"id_token":     idToken,
+		})
+	})
+	mux.HandleFunc("/userinfo", func(w http.ResponseWriter, r *http.Request) {
+		f.userinfoAuth = r.Header.Get("Authorization")
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(f.userinfo)
+	})
+
+	f.server = httptest.NewServer(mux)
+	t.Cleanup(f.server.Close)
+	return f
+}
+
+func (f *fakeGoogle) provider() *oidcProvider {
+	return &oidcProvider{
+		name:        "google",
+		clientID:    testClientID,
+		redirectURL: "http://localhost/auth/google/callback",
+		scopes:      []string{"openid", "email", "profile"},
+		issuers:     []string{testIssuer, "accounts.google.com"},
+		endpoints: &oidcEndpoints{
+			AuthURL:     f.server.URL + "/auth",
+			TokenURL:    f.server.URL + "/token",
+			UserInfoURL: f.server.URL + "/userinfo",
+		},
+	}
+}
+
+func TestOIDCAuthCodeURLSendsPKCEAndNonce(t *testing.T) {
+	p := newFakeGoogle(t).provider()
+
+	raw, err := p.AuthCodeURL(context.Background(), "state-value", testVerifier, testNonce)
+	if err != nil {
+		t.Fatalf("AuthCodeURL: %v", err)
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		t.Fatalf("parse url: %v", err)
+	}
+
+	q := u.Query()
+	if q.Get("state") != "state-value" {
+		t.Errorf("state = %q", q.Get("state"))
+	}
+	if q.Get("nonce") != testNonce {
+		t.Errorf("nonce = %q", q.Get("nonce"))
+	}
+	if q.Get("code_challenge_method") != "S256" {
+		t.Errorf("code_challenge_method = %q", q.Get("code_challenge_method"))
+	}
+	if q.Get("code_challenge") != oauth2.S256ChallengeFromVerifier(testVerifier) {
+		t.Errorf("code_challenge = %q", q.Get("code_challenge"))
+	}
+	if strings.Contains(raw, testVerifier) {
+		t.Error("the verifier must not be sent in the authorization URL")
+	}
+}
+
+func TestOIDCExchange(t *testing.T) {
+	f := newFakeGoogle(t)
+
+	profile, err := f.provider().Exchange(context.Background(), testCode, testVerifier, testNonce)
+	if err != nil {
+		t.Fatalf("Exchange: %v", err)
+	}
+
+	if f.gotVerifier != testVerifier {
+		t.Errorf("token endpoint got code_verifier %q", f.gotVerifier)
+	}
+	if f.userinfoAuth != "Bearer access-token" {
+		t.Errorf("userinfo Authorization = %q", f.userinfoAuth)
+	}
+	if profile.Provider != "google" || profile.Subject != "google-123" {
+		t.Errorf("profile identity = %s/%s", profile.Provider, profile.Subject)
+	}
+	// Userinfo wins over the ID token for profile data
+	if profile.Email != "ada@example.com" || !profile.EmailVerified {
+		t.Errorf("profile email = %q verified=%v", profile.Email, profile.EmailVerified)
+	}
+	if profile.Name != "Ada Lovelace" || profile.Avatar != "https://example.com/ada.png" {
+		t.Errorf("profile = %+v", profile)
+	}
+}
+
+func TestOIDCExchangeRejectsBadIDTokens(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(jwt.MapClaims)
+		wantErr string
+	}{
+		{"nonce mismatch", func(c jwt.MapClaims) { c["nonce"] = "other-nonce" }, "nonce mismatch"},
+		{"missing nonce", func(c jwt.MapClaims) { delete(c, "nonce") }, "nonce mismatch"},
+		{"audience mismatch", func(c jwt.MapClaims) { c["aud"] = "someone-else" }, "audience mismatch"},
+		{"issuer mismatch", func(c jwt.MapClaims) { c["iss"] = "https://evil.example.com" }, "issuer mismatch"},
+		{"expired", func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Minute).Unix() }, "expired"},
+		{"no subject", func(c jwt.MapClaims) { delete(c, "sub") }, "no subject"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFakeGoogle(t)
+			tt.mutate(f.idClaims)
+
+			_, err := f.provider().Exchange(context.Background(), testCode, testVerifier, testNonce)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("err = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOIDCExchangeRejectsUserinfoForAnotherSubject(t *testing.T) {
+	f := newFakeGoogle(t)
+	f.userinfo["sub"] = "google-456"
+
+	_, err := f.provider().Exchange(context.Background(), testCode, testVerifier, testNonce)
+	if err == nil || err.Error() != "userinfo subject mismatch" {
+		t.Fatalf("err = %v, want userinfo subject mismatch", err)
+	}
+}
+
+func TestOIDCExchangeRejectsUnknownCode(t *testing.T) {
+	f := newFakeGoogle(t)
+
+	if _, err := f.provider().Exchange(context.Background(), "wrong-code", testVerifier, testNonce); err == nil {
+		t.Fatal("expected the token endpoint error to be returned")
+	}
+}
+
+func TestOIDCDiscovery(t *testing.T) {
+	var issuer string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/.well-known/openid-configuration" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 issuer,
+			"authorization_endpoint": issuer + "/authorize",
+			"token_endpoint":         issuer + "/token",
+		})
+	}))
+	defer server.Close()
+
+	newProvider := func() *oidcProvider {
+		return &oidcProvider{
+			name:         "sso",
+			clientID:     testClientID,
+			issuers:      []string{server.URL},
+			discoveryURL: server.URL + "/.well-known/openid-configuration",
+		}
+	}
+
+	issuer = server.URL + "/"
+	p := newProvider()
+	endpoints, err := p.resolveEndpoints(context.Background())
+	if err != nil {
+		t.Fatalf("resolveEndpoints: %v", err)
+	}
+	if endpoints.TokenURL != issuer+"/token" {
+		t.Errorf("token endpoint = %q", endpoints.TokenURL)
+	}
+	if p.issuers[len(p.issuers)-1] != issuer {
+		t.Errorf("the advertised issuer %q should be accepted, got %v", issuer, p.issuers)
+	}
+
+	issuer = "https://evil.example.com"
+	if _, err := newProvider().resolveEndpoints(context.Background()); err == nil {
+		t.Fatal("expected an issuer mismatch error")
+	}
+}
+
+func TestFlexBool(t *testing.T) {
+	tests := map[string]bool{`true`: true, `"true"`: true, `false`: false, `"false"`: false, `null`: false}
+	for input, want := range tests {
+		var b flexBool
+		if err := json.Unmarshal([]byte(input), &b); err != nil {
+			t.Fatalf("unmarshal %s: %v", input, err)
+		}
+		if bool(b) != want {
+			t.Errorf("flexBool(%s) = %v, want %v", input, b, want)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"api_go/internal/config"
+	"api_go/internal/domain"
+)
+
+// Provider is an OAuth2 identity provider that maps its user data to a domain.OAuthProfile
+type Provider interface {
+	// Name is the provider key used in routes (/auth/:provider) and recorded on sessions
+	Name() string
+
+	// AuthCodeURL builds the authorization URL for the given state, PKCE verifier and nonce
+	AuthCodeURL(ctx context.Context, state, verifier, nonce string) (string, error)
+
+	// Exchange trades the authorization code for tokens and fetches the user's profile
+	Exchange(ctx context.Context, code, verifier, nonce string) (*domain.OAuthProfile, error)
+}
+
+// Registry holds the providers enabled in config
+type Registry struct {
+	providers map[string]Provider
+	names     []string
+}
+
+// NewRegistry creates a Registry with every provider whose client ID is configured
+func NewRegistry(cfg *config.Config) *Registry {
+	r := &Registry{providers: make(map[string]Provider)}
+
+	if cfg.GoogleClientID != "" {
+		r.Register(newGoogleProvider(cfg, callbackURL(cfg, "google", cfg.GoogleCallbackURL)))
+	}
+	if cfg.GitHubClientID != "" {
+		r.Register(newGitHubProvider(cfg, callbackURL(cfg, "github", cfg.GitHubCallbackURL)))
+	}
+	if cfg.GitLabClientID != "" {
+		r.Register(newGitLabProvider(cfg, callbackURL(cfg, "gitlab", cfg.GitLabCallbackURL)))
+	}
+	if cfg.OIDCClientID != "" && cfg.OIDCIssuerURL != "" && cfg.OIDCName != "" {
+		r.Register(newDiscoveryProvider(cfg, callbackURL(cfg, cfg.OIDCName, cfg.OIDCCallbackURL)))
+	}
+
+	return r
+}
+
+// Register adds a provider, replacing any provider with the same name
+func (r *Registry) Register(p Provider) {
+	if _, exists := r.providers[p.Name()]; !exists {
+		r.names = append(r.names, p.Name())
+	}
+	r.providers[p.Name()] = p
+}
+
+// Get returns the provider with the given name
+func (r *Registry) Get(name string) (Provider, bool) {
+	p, ok := r.providers[name]
+	return p, ok
+}
+
+// Names returns the enabled provider names in registration order
+func (r *Registry) Names() []string {
+	return append([]string(nil), r.names...)
+}
+
+// callbackURL returns the configured callback URL or the default route for the provider
+func callbackURL(cfg *config.Config, name, configured string) string {
+	if configured != "" {
+		return configured
+	}
+	return strings.TrimRight(cfg.APIBaseURL, "/") + "/auth/" + name + "/callback"
+}
+
+// getJSON performs a GET with the given (usually token-bearing) client and decodes the JSON response
+func getJSON(ctx context.Context, client *http.Client, url string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s failed with status %d", url, resp.StatusCode)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
@@ -0,0 +1,67 @@
+package repo
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"api_go/internal/domain"
+)
+
+type accountTokenRepository struct {
+	db *gorm.DB
+}
+
+// NewAccountTokenRepository creates a new AccountTokenRepository instance
+func NewAccountTokenRepository(db *gorm.DB) domain.AccountTokenRepository {
+	return &accountTokenRepository{db: db}
+}
+
+// Create inserts a new account token
+func (r *accountTokenRepository) Create(token *domain.AccountToken) error {
+	return r.db.Create(token).Error
+}
+
+// FindByTokenHash retrieves an account token by its hash
+func (r *accountTokenRepository) FindByTokenHash(tokenHash string) (*domain.AccountToken, error) {
+	var token domain.AccountToken
+	err := r.db.Where("token_hash = ?", tokenHash).First(&token).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// MarkUsed consumes a token (atomic, only one caller can win)
+func (r *accountTokenRepository) MarkUsed(id uint) (bool, error) {
+	result := r.db.Model(&domain.AccountToken{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// RecordAttempt increments the attempt counter atomically
+func (r *accountTokenRepository) RecordAttempt(id uint) (int, error) {
+	var attempts int
+	err := r.db.Raw(
+		`UPDATE account_tokens SET attempts = attempts + 1 WHERE id = ? RETURNING attempts`, id,
+	).Scan(&attempts).Error
+	if err != nil {
+		return 0, err
+	}
+	return attempts, nil
+}
+
+// InvalidateForAccount consumes all outstanding tokens of a purpose for an account
+func (r *accountTokenRepository) InvalidateForAccount(accountID uint, purpose domain.AccountTokenPurpose) error {
+	return r.db.Model(&domain.AccountToken{}).
+		Where("account_id = ? AND purpose = ? AND used_at IS NULL", accountID, purpose).
+		Update("used_at", time.Now()).Error
+}
@@ -0,0 +1,110 @@
+package repo
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"api_go/internal/domain"
+)
+
+// maxMemoryAttempts bounds the in-memory store; stale entries are pruned when it is reached
+const maxMemoryAttempts = 10000
+
+type memoryLoginAttemptStore struct {
+	mu       sync.Mutex
+	attempts map[string]*domain.LoginAttempt
+	window   time.Duration // last window passed to RecordFailure, used for pruning
+}
+
+// NewMemoryLoginAttemptStore creates an in-process LoginAttemptStore (single instance deployments)
+func NewMemoryLoginAttemptStore() domain.LoginAttemptStore {
+	return &memoryLoginAttemptStore{attempts: make(map[string]*domain.LoginAttempt)}
+}
+
+// Get returns a copy of the counter of a key
+func (s *memoryLoginAttemptStore) Get(key string) (*domain.LoginAttempt, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	attempt, ok := s.attempts[key]
+	if !ok {
+		return nil, nil
+	}
+	copied := *attempt
+	return &copied, nil
+}
+
+// RecordFailure increments the counter of a key
+func (s *memoryLoginAttemptStore) RecordFailure(key string, window time.Duration) (*domain.LoginAttempt, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.window = window
+	if len(s.attempts) >= maxMemoryAttempts {
+		s.prune(now)
+	}
+
+	attempt, ok := s.attempts[key]
+	if !ok {
+		attempt = &domain.LoginAttempt{Key: key}
+		s.attempts[key] = attempt
+	}
+	if attempt.LastFailureAt.Before(now.Add(-window)) {
+		attempt.Failures = 0
+	}
+	attempt.Failures++
+	attempt.LastFailureAt = now
+
+	copied := *attempt
+	return &copied, nil
+}
+
+// BlockUntil sets the block expiry of a key
+func (s *memoryLoginAttemptStore) BlockUntil(key string, until time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if attempt, ok := s.attempts[key]; ok {
+		attempt.BlockedUntil = &until
+	}
+	return nil
+}
+
+// Reset removes the counter of a key
+func (s *memoryLoginAttemptStore) Reset(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.attempts, key)
+	return nil
+}
+
+// ListBlocked returns keys whose block has not expired
+func (s *memoryLoginAttemptStore) ListBlocked() ([]domain.LoginAttempt, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	result := make([]domain.LoginAttempt, 0)
+	for _, attempt := range s.attempts {
+		if attempt.BlockedUntil != nil && attempt.BlockedUntil.After(now) {
+			result = append(result, *attempt)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].BlockedUntil.After(*result[j].BlockedUntil)
+	})
+	return result, nil
+}
+
+// prune drops entries that are neither blocked nor within the failure window
+func (s *memoryLoginAttemptStore) prune(now time.Time) {
+	for key, attempt := range s.attempts {
+		blocked := attempt.BlockedUntil != nil && attempt.BlockedUntil.After(now)
+		if !blocked && attempt.LastFailureAt.Before(now.Add(-s.window)) {
+			delete(s.attempts, key)
+		}
+	}
+}
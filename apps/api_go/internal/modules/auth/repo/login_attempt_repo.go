@@ -0,0 +1,72 @@
+package repo
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"api_go/internal/domain"
+)
+
+type loginAttemptRepository struct {
+	db *gorm.DB
+}
+
+// NewLoginAttemptRepository creates a Postgres-backed LoginAttemptStore (shared across instances)
+func NewLoginAttemptRepository(db *gorm.DB) domain.LoginAttemptStore {
+	return &loginAttemptRepository{db: db}
+}
+
+// Get retrieves the counter of a key
+func (r *loginAttemptRepository) Get(key string) (*domain.LoginAttempt, error) {
+	var attempt domain.LoginAttempt
+	err := r.db.Where("throttle_key = ?", key).First(&attempt).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &attempt, nil
+}
+
+// RecordFailure increments the counter atomically (upsert)
+func (r *loginAttemptRepository) RecordFailure(key string, window time.Duration) (*domain.LoginAttempt, error) {
+	now := time.Now()
+	var attempt domain.LoginAttempt
+	err := r.db.Raw(`
+		INSERT INTO login_attempts (throttle_key, failures, last_failure_at)
+		VALUES (?, 1, ?)
+		ON CONFLICT (throttle_key) DO UPDATE SET
+			failures = CASE WHEN login_attempts.last_failure_at < ? THEN 1 ELSE login_attempts.failures + 1 END,
+			last_failure_at = EXCLUDED.last_failure_at
+		RETURNING throttle_key, failures, last_failure_at, blocked_until`,
+		key, now, now.Add(-window),
+	).Scan(&attempt).Error
+	if err != nil {
+		return nil, err
+	}
+	return &attempt, nil
+}
+
+// BlockUntil sets the block expiry of a key
+func (r *loginAttemptRepository) BlockUntil(key string, until time.Time) error {
+	return r.db.Model(&domain.LoginAttempt{}).
+		Where("throttle_key = ?", key).
+		Update("blocked_until", until).Error
+}
+
+// Reset removes the counter of a key
+func (r *loginAttemptRepository) Reset(key string) error {
+	return r.db.Where("throttle_key = ?", key).Delete(&domain.LoginAttempt{}).Error
+}
+
+// ListBlocked retrieves keys whose block has not expired
+func (r *loginAttemptRepository) ListBlocked() ([]domain.LoginAttempt, error) {
+	var attempts []domain.LoginAttempt
+	err := r.db.Where("blocked_until > ?", time.Now()).
+		Order("blocked_until DESC").
+		Find(&attempts).Error
+	return attempts, err
+}
@@ -0,0 +1,71 @@
+package repo
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"api_go/internal/domain"
+)
+
+type personalTokenRepository struct {
+	db *gorm.DB
+}
+
+// NewPersonalTokenRepository creates a new PersonalTokenRepository instance
+func NewPersonalTokenRepository(db *gorm.DB) domain.PersonalTokenRepository {
+	return &personalTokenRepository{db: db}
+}
+
+// Create inserts a new personal access token
+func (r *personalTokenRepository) Create(token *domain.PersonalAccessToken) error {
+	return r.db.Create(token).Error
+}
+
+// FindByTokenHash retrieves a token by its hash
+func (r *personalTokenRepository) FindByTokenHash(tokenHash string) (*domain.PersonalAccessToken, error) {
+	var token domain.PersonalAccessToken
+	err := r.db.Where("token_hash = ?", tokenHash).First(&token).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// FindByAccount retrieves the active (unrevoked) tokens of an account
+func (r *personalTokenRepository) FindByAccount(accountID uint) ([]domain.PersonalAccessToken, error) {
+	var tokens []domain.PersonalAccessToken
+	err := r.db.Where("account_id = ? AND revoked_at IS NULL", accountID).
+		Order("created_at DESC").
+		Find(&tokens).Error
+	return tokens, err
+}
+
+// Revoke revokes one token, scoped to its owner
+func (r *personalTokenRepository) Revoke(id, accountID uint) (bool, error) {
+	result := r.db.Model(&domain.PersonalAccessToken{}).
+		Where("id = ? AND account_id = ? AND revoked_at IS NULL", id, accountID).
+		Update("revoked_at", time.Now())
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// RevokeAllForAccount revokes every token of an account
+func (r *personalTokenRepository) RevokeAllForAccount(accountID uint) error {
+	return r.db.Model(&domain.PersonalAccessToken{}).
+		Where("account_id = ? AND revoked_at IS NULL", accountID).
+		Update("revoked_at", time.Now()).Error
+}
+
+// TouchLastUsed records when the token was last used
+func (r *personalTokenRepository) TouchLastUsed(id uint, at time.Time) error {
+	return r.db.Model(&domain.PersonalAccessToken{}).
+		Where("id = ?", id).
+		Update("last_used_at", at).Error
+}
@@ -0,0 +1,60 @@
+package repo
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"api_go/internal/domain"
+)
+
+type recoveryCodeRepository struct {
+	db *gorm.DB
+}
+
+// NewRecoveryCodeRepository creates a new RecoveryCodeRepository instance
+func NewRecoveryCodeRepository(db *gorm.DB) domain.RecoveryCodeRepository {
+	return &recoveryCodeRepository{db: db}
+}
+
+// ReplaceForAccount swaps the account's codes in one transaction
+func (r *recoveryCodeRepository) ReplaceForAccount(accountID uint, codeHashes []string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("account_id = ?", accountID).Delete(&domain.RecoveryCode{}).Error; err != nil {
+			return err
+		}
+		if len(codeHashes) == 0 {
+			return nil
+		}
+		codes := make([]domain.RecoveryCode, len(codeHashes))
+		for i, hash := range codeHashes {
+			codes[i] = domain.RecoveryCode{AccountID: accountID, CodeHash: hash}
+		}
+		return tx.Create(&codes).Error
+	})
+}
+
+// Consume marks a matching unused code as used (atomic, only one caller can win)
+func (r *recoveryCodeRepository) Consume(accountID uint, codeHash string) (bool, error) {
+	result := r.db.Model(&domain.RecoveryCode{}).
+		Where("account_id = ? AND code_hash = ? AND used_at IS NULL", accountID, codeHash).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// CountUnused counts the codes still available
+func (r *recoveryCodeRepository) CountUnused(accountID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&domain.RecoveryCode{}).
+		Where("account_id = ? AND used_at IS NULL", accountID).
+		Count(&count).Error
+	return count, err
+}
+
+// DeleteForAccount removes all codes of an account
+func (r *recoveryCodeRepository) DeleteForAccount(accountID uint) error {
+	return r.db.Where("account_id = ?", accountID).Delete(&domain.RecoveryCode{}).Error
+}
@@ -0,0 +1,78 @@
+package repo
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"api_go/internal/domain"
+)
+
+type sessionRepository struct {
+	db *gorm.DB
+}
+
+// NewSessionRepository creates a new SessionRepository instance
+func NewSessionRepository(db *gorm.DB) domain.SessionRepository {
+	return &sessionRepository{db: db}
+}
+
+// Create inserts a new session (refresh token) into the database
+func (r *sessionRepository) Create(session *domain.Session) error {
+	return r.db.Create(session).Error
+}
+
+// FindByTokenHash retrieves a session by its refresh token hash
+func (r *sessionRepository) FindByTokenHash(tokenHash string) (*domain.Session, error) {
+	var session domain.Session
+	err := r.db.Where("token_hash = ?", tokenHash).First(&session).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// MarkRotated flags a refresh token as used (atomic, only one caller can win)
+func (r *sessionRepository) MarkRotated(id uint) (bool, error) {
+	result := r.db.Model(&domain.Session{}).
+		Where("id = ? AND rotated_at IS NULL AND revoked_at IS NULL", id).
+		Update("rotated_at", time.Now())
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// RevokeFamily revokes every refresh token issued for a login session
+func (r *sessionRepository) RevokeFamily(familyID string) error {
+	return r.db.Model(&domain.Session{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", time.Now()).Error
+}
+
+// RevokeAllForAccount revokes every session of an account
+func (r *sessionRepository) RevokeAllForAccount(accountID uint) error {
+	return r.db.Model(&domain.Session{}).
+		Where("account_id = ? AND revoked_at IS NULL", accountID).
+		Update("revoked_at", time.Now()).Error
+}
+
+// RevokeOthersForAccount revokes all sessions of an account except one family (the caller's own)
+func (r *sessionRepository) RevokeOthersForAccount(accountID uint, keepFamilyID string) error {
+	return r.db.Model(&domain.Session{}).
+		Where("account_id = ? AND family_id <> ? AND revoked_at IS NULL", accountID, keepFamilyID).
+		Update("revoked_at", time.Now()).Error
+}
+
+// IsFamilyActive checks whether a session family has a usable refresh token left
+func (r *sessionRepository) IsFamilyActive(familyID string) (bool, error) {
+	var count int64
+	err := r.db.Model(&domain.Session{}).
+		Where("family_id = ? AND revoked_at IS NULL AND expires_at > ?", familyID, time.Now()).
+		Count(&count).Error
+	return count > 0, err
+}
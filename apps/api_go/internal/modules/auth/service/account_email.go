@@ -0,0 +1,179 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"api_go/internal/domain"
+)
+
+const accountTokenBytes = 32
+
+// RequestPasswordReset emails a single-use reset link; unknown emails are ignored so the
+// endpoint does not reveal which addresses have accounts
+func (s *authService) RequestPasswordReset(email string) error {
+	account, err := s.accountRepo.FindByEmail(strings.ToLower(strings.TrimSpace(email)))
+	if err != nil {
+		return err
+	}
+	if account == nil {
+		return nil
+	}
+
+	// Only the newest link stays valid
+	if err := s.tokenRepo.InvalidateForAccount(account.ID, domain.AccountTokenPasswordReset); err != nil {
+		return err
+	}
+	token, err := s.createAccountToken(account.ID, domain.AccountTokenPasswordReset, s.config.PasswordResetTTL)
+	if err != nil {
+		return err
+	}
+
+	link := fmt.Sprintf("%s/reset-password?token=%s", strings.TrimRight(s.config.FrontendURL, "/"), token)
+	return s.mailer.Send(domain.MailMessage{
+		To:      account.Email,
+		Subject: "Reset your Dev Wiki password",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nSomeone asked to reset the password of your Dev Wiki account.\n"+
+				"Open the link below to choose a new password (valid for %s):\n\n%s\n\n"+
+				"If this wasn't you, you can ignore this email.\n",
+			displayName(account), s.config.PasswordResetTTL, link,
+		),
+	})
+}
+
+// ResetPassword consumes a reset token, stores the new password and signs out every session
+// and personal access token
+func (s *authService) ResetPassword(dto domain.ResetPasswordDTO) error {
+	token, err := s.consumeAccountToken(dto.Token, domain.AccountTokenPasswordReset)
+	if err != nil {
+		return err
+	}
+
+	hashed, err := bcrypt.GenerateFromPassword([]byte(dto.Password), s.saltRounds())
+	if err != nil {
+		return err
+	}
+	if err := s.accountRepo.UpdatePassword(token.AccountID, string(hashed)); err != nil {
+		return err
+	}
+
+	// The reset link proves ownership of the mailbox
+	if err := s.accountRepo.MarkEmailVerified(token.AccountID); err != nil {
+		return err
+	}
+	if err := s.tokenRepo.InvalidateForAccount(token.AccountID, domain.AccountTokenPasswordReset); err != nil {
+		return err
+	}
+	if err := s.patRepo.RevokeAllForAccount(token.AccountID); err != nil {
+		return err
+	}
+	return s.sessionRepo.RevokeAllForAccount(token.AccountID)
+}
+
+// SendVerificationEmail emails a verification link; verified and unknown emails are ignored
+func (s *authService) SendVerificationEmail(email string) error {
+	account, err := s.accountRepo.FindByEmail(strings.ToLower(strings.TrimSpace(email)))
+	if err != nil {
+		return err
+	}
+	if account == nil || account.EmailVerifiedAt != nil {
+		return nil
+	}
+
+	if err := s.tokenRepo.InvalidateForAccount(account.ID, domain.AccountTokenVerifyEmail); err != nil {
+		return err
+	}
+	token, err := s.createAccountToken(account.ID, domain.AccountTokenVerifyEmail, s.config.EmailVerificationTTL)
+	if err != nil {
+		return err
+	}
+
+	link := fmt.Sprintf("%s/verify-email?token=%s", strings.TrimRight(s.config.APIBaseURL, "/"), token)
+	return s.mailer.Send(domain.MailMessage{
+		To:      account.Email,
+		Subject: "Verify your Dev Wiki email",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nPlease confirm your email address by opening the link below (valid for %s):\n\n%s\n",
+			displayName(account), s.config.EmailVerificationTTL, link,
+		),
+	})
+}
+
+// VerifyEmail consumes a verification token and marks the email as verified
+func (s *authService) VerifyEmail(token string) error {
+	accountToken, err := s.consumeAccountToken(token, domain.AccountTokenVerifyEmail)
+	if err != nil {
+		return err
+	}
+	return s.accountRepo.MarkEmailVerified(accountToken.AccountID)
+}
+
+// createAccountToken stores the hash of a new random token and returns the raw token
+func (s *authService) createAccountToken(accountID uint, purpose domain.AccountTokenPurpose, ttl time.Duration) (string, error) {
+	raw, err := generateOpaqueToken(accountTokenBytes)
+	if err != nil {
+		return "", err
+	}
+	if err := s.tokenRepo.Create(&domain.AccountToken{
+		AccountID: accountID,
+		Purpose:   purpose,
+		TokenHash: hashToken(raw),
+		ExpiresAt: time.Now().Add(ttl),
+	}); err != nil {
+		return "", err
+	}
+	return raw, nil
+}
+
+// consumeAccountToken validates purpose and expiry and marks the token used (single use)
+func (s *authService) consumeAccountToken(raw string, purpose domain.AccountTokenPurpose) (*domain.AccountToken, error) {
+	if raw == "" {
+		return nil, errors.New("invalid or expired token")
+	}
+
+	token, err := s.tokenRepo.FindByTokenHash(hashToken(raw))
+	if err != nil {
+		return nil, err
+	}
+	if token == nil || token.Purpose != purpose || token.UsedAt != nil || time.Now().After(token.ExpiresAt) {
+		return nil, errors.New("invalid or expired token")
+	}
+
+	used, err := s.tokenRepo.MarkUsed(token.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !used {
+		return nil, errors.New("invalid or expired token")
+	}
+	return token, nil
+}
+
+// sendVerificationEmailAsync is used after registration; a mail failure must not fail the signup
+func (s *authService) sendVerificationEmailAsync(email string) {
+	go func() {
+		if err := s.SendVerificationEmail(email); err != nil {
+			log.Printf("failed to send verification email to %s: %v", email, err)
+		}
+	}()
+}
+
+func (s *authService) saltRounds() int {
+	if s.config.HashSaltRounds < bcrypt.MinCost {
+		return bcrypt.DefaultCost
+	}
+	return s.config.HashSaltRounds
+}
+
+func displayName(account *domain.Account) string {
+	if account.Name != "" {
+		return account.Name
+	}
+	return account.Email
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"errors"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"api_go/internal/domain"
+)
+
+// ChangePassword checks the current password (skipped for passwordless accounts setting their first one),
+// stores the new one and signs out every other session
+func (s *authService) ChangePassword(accountID uint, sessionID string, dto domain.ChangePasswordDTO) error {
+	account, err := s.findAccount(accountID)
+	if err != nil {
+		return err
+	}
+
+	if hasPassword(account) {
+		if err := bcrypt.CompareHashAndPassword([]byte(account.Password), []byte(dto.CurrentPassword)); err != nil {
+			return errors.New("current password is incorrect")
+		}
+	}
+
+	hashed, err := bcrypt.GenerateFromPassword([]byte(dto.NewPassword), s.saltRounds())
+	if err != nil {
+		return err
+	}
+	if err := s.accountRepo.UpdatePassword(accountID, string(hashed)); err != nil {
+		return err
+	}
+	if err := s.tokenRepo.InvalidateForAccount(accountID, domain.AccountTokenPasswordReset); err != nil {
+		return err
+	}
+
+	if sessionID == "" {
+		return s.sessionRepo.RevokeAllForAccount(accountID)
+	}
+	return s.sessionRepo.RevokeOthersForAccount(accountID, sessionID)
+}
+
+// DeleteAccount confirms the password, removes every way to sign in and anonymizes the account.
+// Authored content is kept and shows a deleted author.
+func (s *authService) DeleteAccount(accountID uint, dto domain.DeleteAccountDTO) error {
+	account, err := s.findAccount(accountID)
+	if err != nil {
+		return err
+	}
+
+	if hasPassword(account) {
+		if err := bcrypt.CompareHashAndPassword([]byte(account.Password), []byte(dto.Password)); err != nil {
+			return errors.New("password is incorrect")
+		}
+	}
+
+	if err := s.identityRepo.DeleteForAccount(accountID); err != nil {
+		return err
+	}
+	if err := s.recoveryRepo.DeleteForAccount(accountID); err != nil {
+		return err
+	}
+	for _, purpose := range []domain.AccountTokenPurpose{domain.AccountTokenVerifyEmail, domain.AccountTokenPasswordReset} {
+		if err := s.tokenRepo.InvalidateForAccount(accountID, purpose); err != nil {
+			return err
+		}
+	}
+	if err := s.patRepo.RevokeAllForAccount(accountID); err != nil {
+		return err
+	}
+	if err := s.sessionRepo.RevokeAllForAccount(accountID); err != nil {
+		return err
+	}
+
+	// Uploaded avatar files are personal data too
+	if err := s.avatars.Remove(accountID); err != nil {
+		return err
+	}
+	if err := s.accountRepo.Anonymize(accountID); err != nil {
+		return err
+	}
+	s.statusCache.invalidate(accountID)
+	return nil
+}
+
+func hasPassword(account *domain.Account) bool {
+	return !account.Passwordless && account.Password != ""
+}
@@ -0,0 +1,182 @@
+package service
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"api_go/internal/domain"
+)
+
+// statusEntry is a cached account status check with the account's current role
+type statusEntry struct {
+	role      string
+	err       error
+	expiresAt time.Time
+}
+
+// maxStatusEntries caps the status cache; at the cap, entries are dropped to make room
+const maxStatusEntries = 10000
+
+// statusKey scopes a cached check to one session family of an account
+type statusKey struct {
+	accountID uint
+	sessionID string
+}
+
+// statusCache remembers recent status checks so authenticated requests don't each load the account.
+// Bans made on this instance invalidate the entries at once; other instances pick them up within the TTL
+// (their sessions are revoked immediately either way). Role changes revoke the account's sessions, and
+// entries are per session family, so the new session after a role change never sees the old role.
+// Sessions that are never used again are pruned once their entry expires.
+type statusCache struct {
+	ttl        time.Duration
+	maxEntries int
+	mu         sync.Mutex
+	entries    map[statusKey]statusEntry
+	prunedAt   time.Time
+}
+
+func newStatusCache(ttl time.Duration) *statusCache {
+	return &statusCache{ttl: ttl, maxEntries: maxStatusEntries, entries: make(map[statusKey]statusEntry)}
+}
+
+func (c *statusCache) get(key statusKey) (statusEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok || time.Now().After(entry.expiresAt) {
+		delete(c.entries, key)
+		return statusEntry{}, false
+	}
+	return entry, true
+}
+
+// set caches the result, but no longer than a temporary ban lasts
+func (c *statusCache) set(key statusKey, role string, err error) {
+	if c.ttl <= 0 {
+		return
+	}
+	expiresAt := time.Now().Add(c.ttl)
+	var banned *domain.AccountBannedError
+	if errors.As(err, &banned) && banned.Until != nil && banned.Until.Before(expiresAt) {
+		expiresAt = *banned.Until
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	if _, ok := c.entries[key]; !ok && (len(c.entries) >= c.maxEntries || now.Sub(c.prunedAt) >= c.ttl) {
+		c.prune(now)
+	}
+	c.entries[key] = statusEntry{role: role, err: err, expiresAt: expiresAt}
+}
+
+// prune drops expired entries and, if the cache is still full, arbitrary others; a dropped
+// entry only costs a reload of the account (caller holds mu)
+func (c *statusCache) prune(now time.Time) {
+	c.prunedAt = now
+	for key, entry := range c.entries {
+		if now.After(entry.expiresAt) {
+			delete(c.entries, key)
+		}
+	}
+	for key := range c.entries {
+		if len(c.entries) < c.maxEntries {
+			return
+		}
+		delete(c.entries, key)
+	}
+}
+
+// invalidate drops the entries of every session of the account
+func (c *statusCache) invalidate(accountID uint) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key := range c.entries {
+		if key.accountID == accountID {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// BanAccount bans an account (permanently when ExpiresAt is nil) and revokes its sessions and tokens
+func (s *authService) BanAccount(actorID, accountID uint, dto domain.BanAccountDTO) error {
+	if actorID == accountID {
+		return errors.New("cannot ban yourself")
+	}
+	if dto.ExpiresAt != nil && !dto.ExpiresAt.After(time.Now()) {
+		return errors.New("ban expiry must be in the future")
+	}
+
+	if _, err := s.findAccount(accountID); err != nil {
+		return err
+	}
+
+	if err := s.accountRepo.SetStatus(accountID, domain.AccountStatusBanned, dto.Reason, dto.ExpiresAt); err != nil {
+		return err
+	}
+	s.statusCache.invalidate(accountID)
+
+	// Outstanding access tokens die with their session; refresh and personal tokens are revoked
+	if err := s.sessionRepo.RevokeAllForAccount(accountID); err != nil {
+		return err
+	}
+	return s.patRepo.RevokeAllForAccount(accountID)
+}
+
+// UnbanAccount lifts a ban; revoked sessions and tokens stay revoked
+func (s *authService) UnbanAccount(accountID uint) error {
+	account, err := s.findAccount(accountID)
+	if err != nil {
+		return err
+	}
+	if domain.AccountStatus(account.Status) != domain.AccountStatusBanned {
+		return errors.New("account is not banned")
+	}
+
+	if err := s.accountRepo.SetStatus(accountID, domain.AccountStatusActive, "", nil); err != nil {
+		return err
+	}
+	s.statusCache.invalidate(accountID)
+	return nil
+}
+
+// checkAccountStatus rejects banned and deleted accounts
+func checkAccountStatus(account *domain.Account) error {
+	switch account.StatusAt(time.Now()) {
+	case domain.AccountStatusBanned:
+		return &domain.AccountBannedError{Reason: account.BanReason, Until: account.BannedUntil}
+	case domain.AccountStatusDeleted:
+		return errors.New("account is deleted")
+	}
+	return nil
+}
+
+// currentRole is checkAccountStatus for authenticated requests, backed by the status cache.
+// It returns the account's current role, which replaces the (possibly stale) role in the token.
+func (s *authService) currentRole(accountID uint, sessionID string) (string, error) {
+	key := statusKey{accountID: accountID, sessionID: sessionID}
+	if entry, ok := s.statusCache.get(key); ok {
+		return entry.role, entry.err
+	}
+
+	account, err := s.accountRepo.FindOne(accountID)
+	if err != nil {
+		return "", err
+	}
+
+	var role string
+	var statusErr error
+	if account == nil {
+		statusErr = errors.New("user not found")
+	} else {
+		role = account.Role
+		statusErr = checkAccountStatus(account)
+	}
+
+	s.statusCache.set(key, role, statusErr)
+	return role, statusErr
+}
@@ -0,0 +1,41 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestStatusCachePrunesExpiredSessions(t *testing.T) {
+	c := newStatusCache(time.Minute)
+	c.set(statusKey{accountID: 1, sessionID: "old"}, "user", nil)
+
+	// The session is never used again; its entry expires and the next set drops it
+	expired := c.entries[statusKey{accountID: 1, sessionID: "old"}]
+	expired.expiresAt = time.Now().Add(-time.Second)
+	c.entries[statusKey{accountID: 1, sessionID: "old"}] = expired
+	c.prunedAt = time.Now().Add(-2 * time.Minute)
+
+	c.set(statusKey{accountID: 1, sessionID: "new"}, "user", nil)
+	if _, ok := c.entries[statusKey{accountID: 1, sessionID: "old"}]; ok {
+		t.Error("the expired entry must be pruned")
+	}
+	if len(c.entries) != 1 {
+		t.Errorf("entries = %d, want 1", len(c.entries))
+	}
+}
+
+func TestStatusCacheIsCapped(t *testing.T) {
+	c := newStatusCache(time.Hour)
+	c.maxEntries = 5
+
+	for i := 0; i < 50; i++ {
+		c.set(statusKey{accountID: uint(i), sessionID: fmt.Sprintf("s%d", i)}, "user", nil)
+		if len(c.entries) > c.maxEntries {
+			t.Fatalf("entries = %d after %d sets, cap is %d", len(c.entries), i+1, c.maxEntries)
+		}
+	}
+	if _, ok := c.get(statusKey{accountID: 49, sessionID: "s49"}); !ok {
+		t.Error("the latest entry must be cached")
+	}
+}
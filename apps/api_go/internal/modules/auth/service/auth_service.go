@@ -2,7 +2,6 @@ package service
 
 import (
 	"errors"
-	"math/rand"
 	"strings"
 	"time"
 
@@ -11,20 +10,53 @@ import (
 
 	"api_go/internal/config"
 	"api_go/internal/domain"
+	"api_go/internal/modules/auth/keys"
 )
 
+const refreshTokenBytes = 32
+
 type authService struct {
-	config      *config.Config
-	accountSvc  domain.AccountService
-	accountRepo domain.AccountRepository
+	config       *config.Config
+	keys         *keys.KeySet
+	accountSvc   domain.AccountService
+	accountRepo  domain.AccountRepository
+	sessionRepo  domain.SessionRepository
+	identityRepo domain.AccountIdentityRepository
+	tokenRepo    domain.AccountTokenRepository
+	recoveryRepo domain.RecoveryCodeRepository
+	patRepo      domain.PersonalTokenRepository
+	avatars      domain.AvatarService
+	mailer       domain.Mailer
+	statusCache  *statusCache
 }
 
 // NewAuthService creates a new AuthService instance
-func NewAuthService(cfg *config.Config, accountSvc domain.AccountService, accountRepo domain.AccountRepository) domain.AuthService {
+func NewAuthService(
+	cfg *config.Config,
+	keySet *keys.KeySet,
+	accountSvc domain.AccountService,
+	accountRepo domain.AccountRepository,
+	sessionRepo domain.SessionRepository,
+	identityRepo domain.AccountIdentityRepository,
+	tokenRepo domain.AccountTokenRepository,
+	recoveryRepo domain.RecoveryCodeRepository,
+	patRepo domain.PersonalTokenRepository,
+	avatars domain.AvatarService,
+	mailer domain.Mailer,
+) domain.AuthService {
 	return &authService{
-		config:      cfg,
-		accountSvc:  accountSvc,
-		accountRepo: accountRepo,
+		config:       cfg,
+		keys:         keySet,
+		accountSvc:   accountSvc,
+		accountRepo:  accountRepo,
+		sessionRepo:  sessionRepo,
+		identityRepo: identityRepo,
+		tokenRepo:    tokenRepo,
+		recoveryRepo: recoveryRepo,
+		patRepo:      patRepo,
+		avatars:      avatars,
+		mailer:       mailer,
+		statusCache:  newStatusCache(cfg.AccountStatusCacheTTL),
 	}
 }
 
@@ -38,7 +70,7 @@ func (s *authService) ValidateUser(email, password string) (*domain.AuthResponse
 	if err != nil {
 		return nil, err
 	}
-	if account == nil {
+	if account == nil || account.Passwordless {
 		return nil, errors.New("invalid credentials")
 	}
 
@@ -47,34 +79,13 @@ func (s *authService) ValidateUser(email, password string) (*domain.AuthResponse
 		return nil, errors.New("invalid credentials")
 	}
 
-	// 4. Generate JWT token
-	payload := domain.JWTPayload{
-		Sub:      account.ID,
-		Email:    account.Email,
-		Role:     account.Role,
-		Name:     account.Name,
-		Provider: "local",
-	}
-	if account.AvatarURL != nil {
-		payload.Avatar = *account.AvatarURL
+	// 4. Optionally require a verified email
+	if s.config.RequireEmailVerification && account.EmailVerifiedAt == nil {
+		return nil, errors.New("email not verified")
 	}
 
-	token, err := s.GenerateJWT(payload)
-	if err != nil {
-		return nil, err
-	}
-
-	// 5. Build response
-	return &domain.AuthResponseDTO{
-		AccessToken: token,
-		Account: domain.AuthAccountResponse{
-			ID:        account.ID,
-			Email:     account.Email,
-			Role:      account.Role,
-			Name:      account.Name,
-			AvatarURL: account.AvatarURL,
-		},
-	}, nil
+	// 5. Ask for the second factor, or start a new session (access + refresh token)
+	return s.startSession(account, "local")
 }
 
 // Register creates a new user account
@@ -85,54 +96,336 @@ func (s *authService) Register(dto domain.RegisterDTO) error {
 		Password: dto.Password,
 		Role:     string(domain.AccountRoleUser), // Default role for registration
 	})
-	return err
+	if err != nil {
+		return err
+	}
+
+	s.sendVerificationEmailAsync(dto.Email)
+	return nil
 }
 
-// HandleGoogleLogin handles Google OAuth login (creates user if not exists)
-func (s *authService) HandleGoogleLogin(profile domain.GoogleProfile) (*domain.AuthResponseDTO, error) {
-	// 1. Check if user exists by email
-	account, err := s.accountRepo.FindByEmail(profile.Email)
+// HandleOAuthLogin handles OAuth login: a linked identity signs in directly, otherwise a verified
+// email is linked to the matching account or a new passwordless account is created.
+// Accounts whose own email is unverified are never linked automatically: whoever registered
+// them may not own the address, and linking would share the account with them.
+func (s *authService) HandleOAuthLogin(profile domain.OAuthProfile) (*domain.AuthResponseDTO, error) {
+	if profile.Provider == "" || profile.Subject == "" {
+		return nil, errors.New("invalid provider profile")
+	}
+
+	// 1. Known identity
+	identity, err := s.identityRepo.FindByProviderSubject(profile.Provider, profile.Subject)
 	if err != nil {
 		return nil, err
 	}
+	if identity != nil {
+		account, err := s.accountRepo.FindOne(identity.AccountID)
+		if err != nil {
+			return nil, err
+		}
+		if account == nil {
+			return nil, errors.New("user not found")
+		}
+		return s.startSession(account, profile.Provider)
+	}
+
+	// 2. Unknown identity: the email decides, so it must be verified by the provider
+	if profile.Email == "" {
+		return nil, errors.New("provider did not return an email")
+	}
+	if !profile.EmailVerified {
+		return nil, errors.New("provider email is not verified")
+	}
+	email := strings.ToLower(strings.TrimSpace(profile.Email))
 
-	// 2. If user doesn't exist, create a new one
+	account, err := s.accountRepo.FindByEmail(email)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. No account yet: create a passwordless one
 	if account == nil {
-		// Generate a random password for Google users (they won't use it)
-		randomPassword := generateRandomPassword(24)
-
-		// Create account via service (handles password hashing)
-		created, err := s.accountSvc.Create(domain.CreateAccountDTO{
-			Email:    profile.Email,
-			Name:     profile.Name,
-			Password: randomPassword,
-			Role:     string(domain.AccountRoleUser),
-		})
+		handleSource := profile.Name
+		if handleSource == "" {
+			handleSource, _, _ = strings.Cut(email, "@")
+		}
+		handle, err := s.accountSvc.GenerateHandle(handleSource)
 		if err != nil {
 			return nil, err
 		}
+		verifiedAt := time.Now()
+		account = &domain.Account{
+			Email:           email,
+			Name:            profile.Name,
+			Handle:          handle,
+			Role:            string(domain.AccountRoleUser),
+			Status:          string(domain.AccountStatusActive),
+			Passwordless:    true,
+			EmailVerifiedAt: &verifiedAt,
+		}
+		if profile.Avatar != "" {
+			account.AvatarURL = &profile.Avatar
+		}
+		if err := s.accountRepo.Create(account); err != nil {
+			return nil, err
+		}
+	} else if err := checkAccountStatus(account); err != nil {
+		return nil, err
+	} else if account.EmailVerifiedAt == nil {
+		// The owner signs in with the password (or resets it) and links the provider from /me/identities
+		return nil, errors.New("account email is not verified")
+	}
+
+	// 4. Link the identity so later logins no longer depend on the email
+	if err := s.identityRepo.Create(&domain.AccountIdentity{
+		AccountID: account.ID,
+		Provider:  profile.Provider,
+		Subject:   profile.Subject,
+		Email:     email,
+	}); err != nil {
+		return nil, err
+	}
+
+	// 5. Ask for the second factor, or start a new session (access + refresh token)
+	return s.startSession(account, profile.Provider)
+}
+
+// LinkIdentity links an OAuth identity to a signed-in account
+func (s *authService) LinkIdentity(accountID uint, profile domain.OAuthProfile) error {
+	if profile.Provider == "" || profile.Subject == "" {
+		return errors.New("invalid provider profile")
+	}
 
-		// Update avatar if provided
-		if profile.Avatar != "" && created.ID != 0 {
-			_, _ = s.accountSvc.Update(created.ID, domain.UpdateAccountDTO{
-				AvatarURL: &profile.Avatar,
-			})
+	existing, err := s.identityRepo.FindByProviderSubject(profile.Provider, profile.Subject)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		if existing.AccountID == accountID {
+			return nil
 		}
+		return errors.New("identity already linked to another account")
+	}
+
+	linked, err := s.identityRepo.FindByAccountAndProvider(accountID, profile.Provider)
+	if err != nil {
+		return err
+	}
+	if linked != nil {
+		return errors.New("provider already linked")
+	}
 
-		// Fetch the created account
-		account, err = s.accountRepo.FindByEmail(profile.Email)
+	return s.identityRepo.Create(&domain.AccountIdentity{
+		AccountID: accountID,
+		Provider:  profile.Provider,
+		Subject:   profile.Subject,
+		Email:     strings.ToLower(strings.TrimSpace(profile.Email)),
+	})
+}
+
+// UnlinkIdentity removes a linked identity unless it is the account's last login method
+func (s *authService) UnlinkIdentity(accountID uint, provider string) error {
+	identity, err := s.identityRepo.FindByAccountAndProvider(accountID, provider)
+	if err != nil {
+		return err
+	}
+	if identity == nil {
+		return errors.New("identity not found")
+	}
+
+	account, err := s.accountRepo.FindOne(accountID)
+	if err != nil {
+		return err
+	}
+	if account == nil {
+		return errors.New("user not found")
+	}
+
+	if account.Passwordless {
+		identities, err := s.identityRepo.FindByAccount(accountID)
+		if err != nil {
+			return err
+		}
+		if len(identities) <= 1 {
+			return errors.New("cannot remove the last login method")
+		}
+	}
+
+	return s.identityRepo.Delete(identity.ID)
+}
+
+// ListIdentities lists the identities linked to an account
+func (s *authService) ListIdentities(accountID uint) (*domain.IdentitiesResponseDTO, error) {
+	account, err := s.accountRepo.FindOne(accountID)
+	if err != nil {
+		return nil, err
+	}
+	if account == nil {
+		return nil, errors.New("user not found")
+	}
+
+	identities, err := s.identityRepo.FindByAccount(accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &domain.IdentitiesResponseDTO{
+		Passwordless: account.Passwordless,
+		Identities:   make([]domain.IdentityResponseDTO, len(identities)),
+	}
+	for i, identity := range identities {
+		result.Identities[i] = domain.IdentityResponseDTO{
+			Provider:  identity.Provider,
+			Email:     identity.Email,
+			CreatedAt: identity.CreatedAt,
+		}
+	}
+	return result, nil
+}
+
+// RemovePassword makes an account passwordless; it needs a linked identity to remain reachable
+func (s *authService) RemovePassword(accountID uint) error {
+	identities, err := s.identityRepo.FindByAccount(accountID)
+	if err != nil {
+		return err
+	}
+	if len(identities) == 0 {
+		return errors.New("cannot remove the last login method")
+	}
+	return s.accountRepo.MarkPasswordless(accountID)
+}
+
+// RefreshSession rotates a refresh token: the presented token is consumed and a new pair is issued.
+// Presenting an already rotated token is treated as theft and revokes the whole session family.
+func (s *authService) RefreshSession(refreshToken string) (*domain.AuthResponseDTO, error) {
+	if refreshToken == "" {
+		return nil, errors.New("invalid refresh token")
+	}
+
+	// 1. Look up the token by hash
+	session, err := s.sessionRepo.FindByTokenHash(hashToken(refreshToken))
+	if err != nil {
+		return nil, err
+	}
+	if session == nil || session.RevokedAt != nil {
+		return nil, errors.New("invalid refresh token")
+	}
+
+	// 2. Reuse detection
+	if session.RotatedAt != nil {
+		if err := s.sessionRepo.RevokeFamily(session.FamilyID); err != nil {
+			return nil, err
+		}
+		return nil, errors.New("refresh token reuse detected")
+	}
+	if time.Now().After(session.ExpiresAt) {
+		return nil, errors.New("refresh token expired")
+	}
+
+	// 3. Consume the token (guards against concurrent use of the same token)
+	rotated, err := s.sessionRepo.MarkRotated(session.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !rotated {
+		if err := s.sessionRepo.RevokeFamily(session.FamilyID); err != nil {
+			return nil, err
+		}
+		return nil, errors.New("refresh token reuse detected")
+	}
+
+	// 4. Reload the account so the new access token carries current data
+	account, err := s.accountRepo.FindOne(session.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	if account == nil {
+		_ = s.sessionRepo.RevokeFamily(session.FamilyID)
+		return nil, errors.New("user not found")
+	}
+	if err := checkAccountStatus(account); err != nil {
+		_ = s.sessionRepo.RevokeFamily(session.FamilyID)
+		return nil, err
+	}
+
+	// 5. Issue the next token pair in the same family
+	return s.issueTokens(account, session.Provider, session.FamilyID, session.MFA)
+}
+
+// Logout revokes the session family of a refresh token
+func (s *authService) Logout(refreshToken string) error {
+	if refreshToken == "" {
+		return nil
+	}
+	session, err := s.sessionRepo.FindByTokenHash(hashToken(refreshToken))
+	if err != nil {
+		return err
+	}
+	if session == nil {
+		return nil
+	}
+	return s.sessionRepo.RevokeFamily(session.FamilyID)
+}
+
+// RevokeSession revokes a session family by ID
+func (s *authService) RevokeSession(sessionID string) error {
+	if sessionID == "" {
+		return nil
+	}
+	return s.sessionRepo.RevokeFamily(sessionID)
+}
+
+// startSession completes the first login step: accounts with 2FA get a challenge instead of tokens
+func (s *authService) startSession(account *domain.Account, provider string) (*domain.AuthResponseDTO, error) {
+	if err := checkAccountStatus(account); err != nil {
+		return nil, err
+	}
+	if account.TwoFactorEnabled() {
+		return s.issueMFAChallenge(account, provider)
+	}
+	return s.issueTokens(account, provider, "", false)
+}
+
+// issueTokens stores a new refresh token and signs an access token bound to its session family.
+// An empty familyID starts a new session; mfa records that the login passed a second factor.
+func (s *authService) issueTokens(account *domain.Account, provider, familyID string, mfa bool) (*domain.AuthResponseDTO, error) {
+	if familyID == "" {
+		id, err := generateID()
 		if err != nil {
 			return nil, err
 		}
+		familyID = id
+		if err := s.accountRepo.TouchLastLogin(account.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	// 1. Persist refresh token (hash only)
+	refreshToken, err := generateOpaqueToken(refreshTokenBytes)
+	if err != nil {
+		return nil, err
+	}
+	session := &domain.Session{
+		AccountID: account.ID,
+		FamilyID:  familyID,
+		TokenHash: hashToken(refreshToken),
+		Provider:  provider,
+		MFA:       mfa,
+		ExpiresAt: time.Now().Add(s.config.RefreshTokenTTL),
+	}
+	if err := s.sessionRepo.Create(session); err != nil {
+		return nil, err
 	}
 
-	// 3. Generate JWT token
+	// 2. Generate JWT token
 	payload := domain.JWTPayload{
-		Sub:      account.ID,
-		Email:    account.Email,
-		Role:     account.Role,
-		Name:     account.Name,
-		Provider: "google",
+		Sub:       account.ID,
+		Email:     account.Email,
+		Role:      account.Role,
+		Name:      account.Name,
+		Provider:  provider,
+		SessionID: familyID,
+		MFA:       mfa,
 	}
 	if account.AvatarURL != nil {
 		payload.Avatar = *account.AvatarURL
@@ -143,8 +436,11 @@ func (s *authService) HandleGoogleLogin(profile domain.GoogleProfile) (*domain.A
 		return nil, err
 	}
 
+	// 3. Build response
 	return &domain.AuthResponseDTO{
-		AccessToken: token,
+		AccessToken:  token,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int64(s.config.AccessTokenTTL.Seconds()),
 		Account: domain.AuthAccountResponse{
 			ID:        account.ID,
 			Email:     account.Email,
@@ -164,54 +460,117 @@ func (s *authService) GenerateJWT(payload domain.JWTPayload) (string, error) {
 		"name":     payload.Name,
 		"avatar":   payload.Avatar,
 		"provider": payload.Provider,
+		"sid":      payload.SessionID,
+		"mfa":      payload.MFA,
 		"iat":      time.Now().Unix(),
-		"exp":      time.Now().Add(7 * 24 * time.Hour).Unix(), // 7 days
+		"exp":      time.Now().Add(s.config.AccessTokenTTL).Unix(),
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(s.config.JWTSecret))
+	return s.keys.Sign(claims)
 }
 
 // ValidateJWT validates a JWT token and returns the payload
 func (s *authService) ValidateJWT(tokenString string) (*domain.JWTPayload, error) {
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		// Validate signing method
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, errors.New("unexpected signing method")
-		}
-		return []byte(s.config.JWTSecret), nil
-	})
-
+	claims, err := s.keys.Parse(tokenString)
 	if err != nil {
 		return nil, err
 	}
 
-	if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
-		payload := &domain.JWTPayload{
-			Email:    claims["email"].(string),
-			Role:     claims["role"].(string),
-			Name:     claims["name"].(string),
-			Provider: claims["provider"].(string),
-		}
+	// Only access tokens are accepted here (MFA challenges carry a typ claim)
+	if _, typed := claims["typ"]; typed {
+		return nil, errors.New("invalid token")
+	}
 
-		// Handle sub as float64 (JSON numbers are decoded as float64)
-		if sub, ok := claims["sub"].(float64); ok {
-			payload.Sub = uint(sub)
-		}
+	payload := &domain.JWTPayload{}
+	payload.Email, _ = claims["email"].(string)
+	payload.Role, _ = claims["role"].(string)
+	payload.Name, _ = claims["name"].(string)
+	payload.Provider, _ = claims["provider"].(string)
+
+	// Handle sub as float64 (JSON numbers are decoded as float64)
+	if sub, ok := claims["sub"].(float64); ok {
+		payload.Sub = uint(sub)
+	}
 
-		if avatar, ok := claims["avatar"].(string); ok {
-			payload.Avatar = avatar
+	if avatar, ok := claims["avatar"].(string); ok {
+		payload.Avatar = avatar
+	}
+
+	if sid, ok := claims["sid"].(string); ok {
+		payload.SessionID = sid
+	}
+
+	if mfa, ok := claims["mfa"].(bool); ok {
+		payload.MFA = mfa
+	}
+
+	return payload, nil
+}
+
+// JWKS returns the public keys that verify access tokens
+func (s *authService) JWKS() domain.JWKSDTO {
+	return s.keys.JWKS()
+}
+
+// AuthenticateToken validates an access token and rejects it if its session was revoked
+// (or, for "dwp_" tokens, that the personal access token is still valid)
+func (s *authService) AuthenticateToken(tokenString string) (*domain.JWTPayload, error) {
+	var payload *domain.JWTPayload
+	if isPersonalToken(tokenString) {
+		p, err := s.authenticatePersonalToken(tokenString)
+		if err != nil {
+			return nil, err
+		}
+		payload = p
+	} else {
+		p, err := s.authenticateAccessToken(tokenString)
+		if err != nil {
+			return nil, err
 		}
+		payload = p
+	}
 
-		return payload, nil
+	// Privileged roles only apply to sessions that passed 2FA when required
+	if s.config.RequireMFAForPrivileged && domain.AccountRole(payload.Role).IsPrivileged() && !payload.MFA {
+		payload.Role = string(domain.AccountRoleUser)
+		payload.MFARequired = true
 	}
 
-	return nil, errors.New("invalid token")
+	return payload, nil
+}
+
+// authenticateAccessToken validates a JWT and checks that its session family is still active
+func (s *authService) authenticateAccessToken(tokenString string) (*domain.JWTPayload, error) {
+	payload, err := s.ValidateJWT(tokenString)
+	if err != nil {
+		return nil, err
+	}
+
+	// Tokens issued before sessions existed carry no sid and are no longer accepted
+	if payload.SessionID == "" {
+		return nil, errors.New("invalid token")
+	}
+
+	active, err := s.sessionRepo.IsFamilyActive(payload.SessionID)
+	if err != nil {
+		return nil, err
+	}
+	if !active {
+		return nil, errors.New("session revoked")
+	}
+
+	role, err := s.currentRole(payload.Sub, payload.SessionID)
+	if err != nil {
+		return nil, err
+	}
+	payload.Role = role
+
+	return payload, nil
 }
 
 // GetUserFromToken extracts user info from JWT token
 func (s *authService) GetUserFromToken(tokenString string) (*domain.AccountResponseDTO, error) {
-	payload, err := s.ValidateJWT(tokenString)
+	payload, err := s.AuthenticateToken(tokenString)
 	if err != nil {
 		return nil, err
 	}
@@ -226,20 +585,10 @@ func (s *authService) GetUserFromToken(tokenString string) (*domain.AccountRespo
 	}
 
 	return &domain.AccountResponseDTO{
-		ID:        account.ID,
-		Email:     account.Email,
-		Name:      account.Name,
-		AvatarURL: account.AvatarURL,
+		ID:            account.ID,
+		Email:         account.Email,
+		Name:          account.Name,
+		AvatarURL:     account.AvatarURL,
+		EmailVerified: account.EmailVerifiedAt != nil,
 	}, nil
 }
-
-// generateRandomPassword generates a random password
-func generateRandomPassword(length int) string {
-	const charset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789!@#$%^&*"
-	seededRand := rand.New(rand.NewSource(time.Now().UnixNano()))
-	b := make([]byte, length)
-	for i := range b {
-		b[i] = charset[seededRand.Intn(len(charset))]
-	}
-	return string(b)
-}
@@ -0,0 +1,304 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"api_go/internal/config"
+	"api_go/internal/domain"
+	"api_go/internal/modules/auth/keys"
+)
+
+// In-memory repositories for the auth service tests. Each fake embeds its interface so that
+// methods a test does not expect to be called panic instead of silently succeeding.
+
+type fakeAccountRepo struct {
+	domain.AccountRepository
+	accounts map[uint]*domain.Account
+}
+
+func (r *fakeAccountRepo) FindOne(id uint) (*domain.Account, error) {
+	account, ok := r.accounts[id]
+	if !ok {
+		return nil, nil
+	}
+	copied := *account
+	return &copied, nil
+}
+
+func (r *fakeAccountRepo) FindByEmail(email string) (*domain.Account, error) {
+	for _, account := range r.accounts {
+		if account.Email == email {
+			copied := *account
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeAccountRepo) TouchLastLogin(id uint) error {
+	return nil
+}
+
+func (r *fakeAccountRepo) SetTOTP(id uint, secret string, enabledAt *time.Time) error {
+	r.accounts[id].TOTPSecret = secret
+	r.accounts[id].TOTPEnabledAt = enabledAt
+	return nil
+}
+
+func (r *fakeAccountRepo) AdvanceTOTPCounter(id uint, counter int64) (bool, error) {
+	account := r.accounts[id]
+	if account.TOTPLastCounter >= counter {
+		return false, nil
+	}
+	account.TOTPLastCounter = counter
+	return true, nil
+}
+
+type fakeIdentityRepo struct {
+	domain.AccountIdentityRepository
+	identities []*domain.AccountIdentity
+}
+
+func (r *fakeIdentityRepo) Create(identity *domain.AccountIdentity) error {
+	identity.ID = uint(len(r.identities) + 1)
+	r.identities = append(r.identities, identity)
+	return nil
+}
+
+func (r *fakeIdentityRepo) FindByProviderSubject(provider, subject string) (*domain.AccountIdentity, error) {
+	for _, identity := range r.identities {
+		if identity.Provider == provider && identity.Subject == subject {
+			copied := *identity
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+type fakeSessionRepo struct {
+	domain.SessionRepository
+	sessions []*domain.Session
+}
+
+func (r *fakeSessionRepo) Create(session *domain.Session) error {
+	session.ID = uint(len(r.sessions) + 1)
+	r.sessions = append(r.sessions, session)
+	return nil
+}
+
+func (r *fakeSessionRepo) FindByTokenHash(tokenHash string) (*domain.Session, error) {
+	for _, session := range r.sessions {
+		if session.TokenHash == tokenHash {
+			copied := *session
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeSessionRepo) MarkRotated(id uint) (bool, error) {
+	session := r.sessions[id-1]
+	if session.RotatedAt != nil || session.RevokedAt != nil {
+		return false, nil
+	}
+	now := time.Now()
+	session.RotatedAt = &now
+	return true, nil
+}
+
+func (r *fakeSessionRepo) RevokeFamily(familyID string) error {
+	now := time.Now()
+	for _, session := range r.sessions {
+		if session.FamilyID == familyID && session.RevokedAt == nil {
+			session.RevokedAt = &now
+		}
+	}
+	return nil
+}
+
+func (r *fakeSessionRepo) RevokeAllForAccount(accountID uint) error {
+	now := time.Now()
+	for _, session := range r.sessions {
+		if session.AccountID == accountID && session.RevokedAt == nil {
+			session.RevokedAt = &now
+		}
+	}
+	return nil
+}
+
+func (r *fakeSessionRepo) IsFamilyActive(familyID string) (bool, error) {
+	for _, session := range r.sessions {
+		if session.FamilyID == familyID && session.RevokedAt == nil && session.ExpiresAt.After(time.Now()) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+type fakeAccountTokenRepo struct {
+	domain.AccountTokenRepository
+	tokens []*domain.AccountToken
+}
+
+func (r *fakeAccountTokenRepo) Create(token *domain.AccountToken) error {
+	token.ID = uint(len(r.tokens) + 1)
+	r.tokens = append(r.tokens, token)
+	return nil
+}
+
+func (r *fakeAccountTokenRepo) FindByTokenHash(tokenHash string) (*domain.AccountToken, error) {
+	for _, token := range r.tokens {
+		if token.TokenHash == tokenHash {
+			copied := *token
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeAccountTokenRepo) MarkUsed(id uint) (bool, error) {
+	token := r.tokens[id-1]
+	if token.UsedAt != nil {
+		return false, nil
+	}
+	now := time.Now()
+	token.UsedAt = &now
+	return true, nil
+}
+
+func (r *fakeAccountTokenRepo) RecordAttempt(id uint) (int, error) {
+	r.tokens[id-1].Attempts++
+	return r.tokens[id-1].Attempts, nil
+}
+
+type fakeRecoveryRepo struct {
+	domain.RecoveryCodeRepository
+	unused map[uint]map[string]bool // account -> code hash -> unused
+}
+
+func (r *fakeRecoveryRepo) ReplaceForAccount(accountID uint, codeHashes []string) error {
+	r.unused[accountID] = make(map[string]bool, len(codeHashes))
+	for _, hash := range codeHashes {
+		r.unused[accountID][hash] = true
+	}
+	return nil
+}
+
+func (r *fakeRecoveryRepo) Consume(accountID uint, codeHash string) (bool, error) {
+	if !r.unused[accountID][codeHash] {
+		return false, nil
+	}
+	r.unused[accountID][codeHash] = false
+	return true, nil
+}
+
+func (r *fakeRecoveryRepo) CountUnused(accountID uint) (int64, error) {
+	var count int64
+	for _, unused := range r.unused[accountID] {
+		if unused {
+			count++
+		}
+	}
+	return count, nil
+}
+
+type fakePersonalTokenRepo struct {
+	domain.PersonalTokenRepository
+	tokens []*domain.PersonalAccessToken
+}
+
+func (r *fakePersonalTokenRepo) Create(token *domain.PersonalAccessToken) error {
+	token.ID = uint(len(r.tokens) + 1)
+	token.CreatedAt = time.Now()
+	r.tokens = append(r.tokens, token)
+	return nil
+}
+
+func (r *fakePersonalTokenRepo) FindByTokenHash(tokenHash string) (*domain.PersonalAccessToken, error) {
+	for _, token := range r.tokens {
+		if token.TokenHash == tokenHash {
+			copied := *token
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakePersonalTokenRepo) Revoke(id, accountID uint) (bool, error) {
+	if id == 0 || int(id) > len(r.tokens) {
+		return false, nil
+	}
+	token := r.tokens[id-1]
+	if token.AccountID != accountID || token.RevokedAt != nil {
+		return false, nil
+	}
+	now := time.Now()
+	token.RevokedAt = &now
+	return true, nil
+}
+
+func (r *fakePersonalTokenRepo) TouchLastUsed(id uint, at time.Time) error {
+	r.tokens[id-1].LastUsedAt = &at
+	return nil
+}
+
+// testAuth bundles the service under test with its fakes
+type testAuth struct {
+	*authService
+	accounts   *fakeAccountRepo
+	identities *fakeIdentityRepo
+	sessions   *fakeSessionRepo
+	tokens     *fakeAccountTokenRepo
+	recovery   *fakeRecoveryRepo
+	personal   *fakePersonalTokenRepo
+	alice, bob *domain.Account
+}
+
+func newTestAuth(t *testing.T) *testAuth {
+	t.Helper()
+
+	cfg := &config.Config{
+		JWTSecret:               "test-secret",
+		AccessTokenTTL:          15 * time.Minute,
+		RefreshTokenTTL:         24 * time.Hour,
+		MFAIssuer:               "Dev Wiki",
+		MFAChallengeTTL:         5 * time.Minute,
+		MFAChallengeMaxAttempts: 3,
+	}
+	keySet, err := keys.Load(cfg)
+	if err != nil {
+		t.Fatalf("keys.Load: %v", err)
+	}
+
+	ta := &testAuth{
+		accounts:   &fakeAccountRepo{accounts: make(map[uint]*domain.Account)},
+		identities: &fakeIdentityRepo{},
+		sessions:   &fakeSessionRepo{},
+		tokens:     &fakeAccountTokenRepo{},
+		recovery:   &fakeRecoveryRepo{unused: make(map[uint]map[string]bool)},
+		personal:   &fakePersonalTokenRepo{},
+	}
+	ta.alice = ta.addAccount(1, "alice@example.com", string(domain.AccountRoleUser))
+	ta.bob = ta.addAccount(2, "bob@example.com", string(domain.AccountRoleAdmin))
+
+	ta.authService = &authService{
+		config:       cfg,
+		keys:         keySet,
+		accountRepo:  ta.accounts,
+		identityRepo: ta.identities,
+		sessionRepo:  ta.sessions,
+		tokenRepo:    ta.tokens,
+		recoveryRepo: ta.recovery,
+		patRepo:      ta.personal,
+		statusCache:  newStatusCache(0),
+	}
+	return ta
+}
+
+func (ta *testAuth) addAccount(id uint, email, role string) *domain.Account {
+	account := &domain.Account{Email: email, Name: email, Role: role, Status: string(domain.AccountStatusActive)}
+	account.ID = id
+	ta.accounts.accounts[id] = account
+	return account
+}
@@ -0,0 +1,193 @@
+package service
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"api_go/internal/config"
+	"api_go/internal/domain"
+)
+
+const (
+	ipKeyPrefix    = "ip:"
+	emailKeyPrefix = "email:"
+	mfaKeyPrefix   = "mfa:"
+)
+
+type loginThrottle struct {
+	store           domain.LoginAttemptStore
+	freeAttempts    int
+	backoffBase     time.Duration
+	window          time.Duration
+	emailThreshold  int
+	ipThreshold     int
+	lockoutDuration time.Duration
+}
+
+// NewLoginThrottle creates a LoginThrottle. Failures are counted per IP and per email (or per
+// account for 2FA codes): after LOGIN_FREE_ATTEMPTS the key is delayed with exponential backoff,
+// and at the lockout threshold it is blocked for LOGIN_LOCKOUT_DURATION.
+func NewLoginThrottle(cfg *config.Config, store domain.LoginAttemptStore) domain.LoginThrottle {
+	return &loginThrottle{
+		store:           store,
+		freeAttempts:    cfg.LoginFreeAttempts,
+		backoffBase:     cfg.LoginBackoffBase,
+		window:          cfg.LoginFailureWindow,
+		emailThreshold:  cfg.LoginLockoutThreshold,
+		ipThreshold:     cfg.LoginIPLockoutThreshold,
+		lockoutDuration: cfg.LoginLockoutDuration,
+	}
+}
+
+// Check rejects the attempt while the IP or the email is blocked
+func (t *loginThrottle) Check(ip, email string) error {
+	return t.check(t.keys(ip, email))
+}
+
+// RecordFailure counts a failed login for both keys and blocks them as needed
+func (t *loginThrottle) RecordFailure(ip, email string) error {
+	return t.recordFailure(ip, t.keys("", email))
+}
+
+// CheckMFA rejects a 2FA attempt while the IP or the account is blocked
+func (t *loginThrottle) CheckMFA(ip string, accountID uint) error {
+	return t.check(append(t.keys(ip, ""), mfaKey(accountID)))
+}
+
+// RecordMFAFailure counts a wrong 2FA code for the IP and the account. The account key is
+// separate from the email key so a successful password login does not reset it.
+func (t *loginThrottle) RecordMFAFailure(ip string, accountID uint) error {
+	return t.recordFailure(ip, []string{mfaKey(accountID)})
+}
+
+// RecordMFASuccess clears the 2FA counter of the account
+func (t *loginThrottle) RecordMFASuccess(accountID uint) error {
+	return t.store.Reset(mfaKey(accountID))
+}
+
+// RecordSuccess clears the email counter. The IP counter is kept so one valid
+// account cannot be used to reset an attacker's IP budget.
+func (t *loginThrottle) RecordSuccess(email string) error {
+	if email = normalizeEmail(email); email == "" {
+		return nil
+	}
+	return t.store.Reset(emailKeyPrefix + email)
+}
+
+// ListLockouts lists currently blocked keys
+func (t *loginThrottle) ListLockouts() ([]domain.LoginLockoutDTO, error) {
+	attempts, err := t.store.ListBlocked()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.LoginLockoutDTO, len(attempts))
+	for i, attempt := range attempts {
+		threshold := t.emailThreshold
+		if strings.HasPrefix(attempt.Key, ipKeyPrefix) {
+			threshold = t.ipThreshold
+		}
+		result[i] = domain.LoginLockoutDTO{
+			Key:           attempt.Key,
+			Failures:      attempt.Failures,
+			LastFailureAt: attempt.LastFailureAt,
+			BlockedUntil:  *attempt.BlockedUntil,
+			Locked:        attempt.Failures >= threshold,
+		}
+	}
+	return result, nil
+}
+
+// Unblock clears the counter of a key
+func (t *loginThrottle) Unblock(key string) error {
+	return t.store.Reset(key)
+}
+
+func (t *loginThrottle) check(keys []string) error {
+	now := time.Now()
+	var wait time.Duration
+
+	for _, key := range keys {
+		attempt, err := t.store.Get(key)
+		if err != nil {
+			return err
+		}
+		if attempt == nil || attempt.BlockedUntil == nil {
+			continue
+		}
+		if d := attempt.BlockedUntil.Sub(now); d > wait {
+			wait = d
+		}
+	}
+
+	if wait > 0 {
+		return &domain.RetryAfterError{RetryAfter: wait}
+	}
+	return nil
+}
+
+// recordFailure counts a failure for the IP and the per-account keys
+func (t *loginThrottle) recordFailure(ip string, accountKeys []string) error {
+	if ip != "" {
+		if err := t.recordKey(ipKeyPrefix+ip, t.ipThreshold); err != nil {
+			return err
+		}
+	}
+	for _, key := range accountKeys {
+		if err := t.recordKey(key, t.emailThreshold); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *loginThrottle) recordKey(key string, threshold int) error {
+	attempt, err := t.store.RecordFailure(key, t.window)
+	if err != nil {
+		return err
+	}
+	if delay := t.delay(attempt.Failures, threshold); delay > 0 {
+		return t.store.BlockUntil(key, time.Now().Add(delay))
+	}
+	return nil
+}
+
+// delay returns the block duration after the given number of consecutive failures:
+// none for the free attempts, then base * 2^n capped at the lockout duration
+func (t *loginThrottle) delay(failures, threshold int) time.Duration {
+	if failures >= threshold {
+		return t.lockoutDuration
+	}
+	if failures <= t.freeAttempts {
+		return 0
+	}
+
+	delay := t.backoffBase
+	for i := t.freeAttempts + 1; i < failures && delay < t.lockoutDuration; i++ {
+		delay *= 2
+	}
+	if delay > t.lockoutDuration {
+		delay = t.lockoutDuration
+	}
+	return delay
+}
+
+func (t *loginThrottle) keys(ip, email string) []string {
+	keys := make([]string, 0, 2)
+	if ip != "" {
+		keys = append(keys, ipKeyPrefix+ip)
+	}
+	if email = normalizeEmail(email); email != "" {
+		keys = append(keys, emailKeyPrefix+email)
+	}
+	return keys
+}
+
+func mfaKey(accountID uint) string {
+	return mfaKeyPrefix + strconv.FormatUint(uint64(accountID), 10)
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
@@ -0,0 +1,179 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"api_go/internal/config"
+	"api_go/internal/domain"
+	"api_go/internal/modules/auth/repo"
+)
+
+func newTestThrottle() *loginThrottle {
+	cfg := &config.Config{
+		LoginFreeAttempts:       3,
+		LoginBackoffBase:        time.Second,
+		LoginFailureWindow:      15 * time.Minute,
+		LoginLockoutThreshold:   10,
+		LoginIPLockoutThreshold: 50,
+		LoginLockoutDuration:    15 * time.Minute,
+	}
+	return NewLoginThrottle(cfg, repo.NewMemoryLoginAttemptStore()).(*loginThrottle)
+}
+
+// retryAfter returns the wait of a throttling error, failing the test for any other error
+func retryAfter(t *testing.T, err error) time.Duration {
+	t.Helper()
+	if err == nil {
+		return 0
+	}
+	var retry *domain.RetryAfterError
+	if !errors.As(err, &retry) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return retry.RetryAfter
+}
+
+func TestLoginThrottleDelay(t *testing.T) {
+	throttle := newTestThrottle()
+
+	tests := []struct {
+		failures int
+		want     time.Duration
+	}{
+		{1, 0},
+		{3, 0},
+		{4, time.Second},
+		{5, 2 * time.Second},
+		{6, 4 * time.Second},
+		{9, 32 * time.Second},
+		{10, 15 * time.Minute},
+		{25, 15 * time.Minute},
+	}
+	for _, tt := range tests {
+		if got := throttle.delay(tt.failures, throttle.emailThreshold); got != tt.want {
+			t.Errorf("delay(%d) = %v, want %v", tt.failures, got, tt.want)
+		}
+	}
+
+	// The backoff never exceeds the lockout duration
+	throttle.backoffBase = time.Minute
+	throttle.lockoutDuration = 5 * time.Minute
+	if got := throttle.delay(9, throttle.emailThreshold); got != 5*time.Minute {
+		t.Errorf("capped delay = %v", got)
+	}
+}
+
+func TestLoginThrottleBacksOffAfterFreeAttempts(t *testing.T) {
+	throttle := newTestThrottle()
+	const ip, email = "203.0.113.7", "alice@example.com"
+
+	for i := 0; i < 3; i++ {
+		if err := throttle.RecordFailure(ip, email); err != nil {
+			t.Fatal(err)
+		}
+		if wait := retryAfter(t, throttle.Check(ip, email)); wait != 0 {
+			t.Fatalf("blocked after %d failures for %v", i+1, wait)
+		}
+	}
+
+	if err := throttle.RecordFailure(ip, email); err != nil {
+		t.Fatal(err)
+	}
+	if wait := retryAfter(t, throttle.Check(ip, email)); wait <= 0 || wait > time.Second {
+		t.Errorf("wait after 4 failures = %v, want up to 1s", wait)
+	}
+	// The email is blocked from any IP, with any capitalization
+	if wait := retryAfter(t, throttle.Check("198.51.100.1", " Alice@Example.COM ")); wait <= 0 {
+		t.Error("the email must be blocked from another IP")
+	}
+}
+
+func TestLoginThrottleLocksOutAtThreshold(t *testing.T) {
+	throttle := newTestThrottle()
+	const email = "alice@example.com"
+
+	for i := 0; i < 10; i++ {
+		if err := throttle.RecordFailure("", email); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if wait := retryAfter(t, throttle.Check("", email)); wait < 14*time.Minute {
+		t.Errorf("wait at threshold = %v, want the lockout duration", wait)
+	}
+
+	lockouts, err := throttle.ListLockouts()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lockouts) != 1 || lockouts[0].Key != "email:"+email || !lockouts[0].Locked || lockouts[0].Failures != 10 {
+		t.Fatalf("lockouts = %+v", lockouts)
+	}
+
+	if err := throttle.Unblock("email:" + email); err != nil {
+		t.Fatal(err)
+	}
+	if err := throttle.Check("", email); err != nil {
+		t.Errorf("after unblock: %v", err)
+	}
+}
+
+func TestLoginThrottleSuccessKeepsIPCounter(t *testing.T) {
+	throttle := newTestThrottle()
+	const ip = "203.0.113.7"
+
+	for i := 0; i < 4; i++ {
+		if err := throttle.RecordFailure(ip, "alice@example.com"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := throttle.RecordSuccess("alice@example.com"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := throttle.Check("", "alice@example.com"); err != nil {
+		t.Errorf("email still blocked after success: %v", err)
+	}
+	if wait := retryAfter(t, throttle.Check(ip, "")); wait <= 0 {
+		t.Error("a successful login must not reset the IP counter")
+	}
+}
+
+func TestLoginThrottleMFAIsPerAccount(t *testing.T) {
+	throttle := newTestThrottle()
+	const accountID = 42
+
+	// Guesses spread over many IPs still count against the account
+	for i := 0; i < 4; i++ {
+		ip := fmt.Sprintf("203.0.113.%d", i+1)
+		if err := throttle.CheckMFA(ip, accountID); err != nil {
+			t.Fatalf("attempt %d: %v", i+1, err)
+		}
+		if err := throttle.RecordMFAFailure(ip, accountID); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if wait := retryAfter(t, throttle.CheckMFA("198.51.100.1", accountID)); wait <= 0 {
+		t.Fatal("the account must be blocked for a fresh IP")
+	}
+	if err := throttle.CheckMFA("198.51.100.1", accountID+1); err != nil {
+		t.Errorf("another account is blocked: %v", err)
+	}
+
+	// A successful password login (email key) does not reset the 2FA budget
+	if err := throttle.RecordSuccess("alice@example.com"); err != nil {
+		t.Fatal(err)
+	}
+	if wait := retryAfter(t, throttle.CheckMFA("198.51.100.1", accountID)); wait <= 0 {
+		t.Error("the 2FA counter must survive a password login")
+	}
+
+	if err := throttle.RecordMFASuccess(accountID); err != nil {
+		t.Fatal(err)
+	}
+	if err := throttle.CheckMFA("198.51.100.1", accountID); err != nil {
+		t.Errorf("after a successful 2FA login: %v", err)
+	}
+}
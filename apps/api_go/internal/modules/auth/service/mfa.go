@@ -0,0 +1,338 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"api_go/internal/domain"
+	"api_go/internal/modules/auth/totp"
+)
+
+const (
+	mfaChallengeType  = "mfa_challenge"
+	recoveryCodeCount = 10
+	// totpSkew accepts codes from one step before/after the current one (clock drift)
+	totpSkew = 1
+)
+
+var recoveryEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// issueMFAChallenge returns a short-lived token proving the first factor. Its jti is stored as
+// a single-use account token so the challenge can be consumed and its wrong codes counted.
+func (s *authService) issueMFAChallenge(account *domain.Account, provider string) (*domain.AuthResponseDTO, error) {
+	jti, err := s.createAccountToken(account.ID, domain.AccountTokenMFAChallenge, s.config.MFAChallengeTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"typ":      mfaChallengeType,
+		"sub":      account.ID,
+		"jti":      jti,
+		"provider": provider,
+		"iat":      now.Unix(),
+		"exp":      now.Add(s.config.MFAChallengeTTL).Unix(),
+	}
+	challenge, err := s.keys.Sign(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.AuthResponseDTO{
+		MFARequired:    true,
+		ChallengeToken: challenge,
+		Account: domain.AuthAccountResponse{
+			ID:    account.ID,
+			Email: account.Email,
+			Role:  account.Role,
+			Name:  account.Name,
+		},
+	}, nil
+}
+
+// MFAChallengeAccount returns the account a login challenge was issued for, so callers can
+// throttle per account
+func (s *authService) MFAChallengeAccount(challengeToken string) (uint, error) {
+	accountID, _, _, err := s.parseMFAChallenge(challengeToken)
+	return accountID, err
+}
+
+// CompleteMFALogin verifies the challenge and the second factor, then starts the session.
+// A challenge is single use and is invalidated after MFA_CHALLENGE_MAX_ATTEMPTS wrong codes.
+func (s *authService) CompleteMFALogin(challengeToken, code string) (*domain.AuthResponseDTO, error) {
+	accountID, provider, jti, err := s.parseMFAChallenge(challengeToken)
+	if err != nil {
+		return nil, err
+	}
+	challenge, err := s.findMFAChallenge(accountID, jti)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := s.accountRepo.FindOne(accountID)
+	if err != nil {
+		return nil, err
+	}
+	if account == nil || !account.TwoFactorEnabled() {
+		return nil, errors.New("invalid challenge")
+	}
+
+	if err := checkAccountStatus(account); err != nil {
+		return nil, err
+	}
+	if err := s.verifySecondFactor(account, code); err != nil {
+		if err.Error() == "invalid code" {
+			if err := s.recordMFAChallengeFailure(challenge.ID); err != nil {
+				return nil, err
+			}
+		}
+		return nil, err
+	}
+
+	used, err := s.tokenRepo.MarkUsed(challenge.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !used {
+		return nil, errors.New("invalid challenge")
+	}
+
+	return s.issueTokens(account, provider, "", true)
+}
+
+// GetMFAStatus returns whether 2FA is enabled or required and how many recovery codes are left
+func (s *authService) GetMFAStatus(accountID uint) (*domain.MFAStatusDTO, error) {
+	account, err := s.findAccount(accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	remaining, err := s.recoveryRepo.CountUnused(accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.MFAStatusDTO{
+		Enabled:                account.TwoFactorEnabled(),
+		Required:               s.mfaRequiredFor(account),
+		RecoveryCodesRemaining: remaining,
+	}, nil
+}
+
+// SetupMFA generates a new pending secret; it only takes effect after EnableMFA
+func (s *authService) SetupMFA(accountID uint) (*domain.MFASetupResponseDTO, error) {
+	account, err := s.findAccount(accountID)
+	if err != nil {
+		return nil, err
+	}
+	if account.TwoFactorEnabled() {
+		return nil, errors.New("two-factor authentication already enabled")
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.accountRepo.SetTOTP(accountID, secret, nil); err != nil {
+		return nil, err
+	}
+
+	return &domain.MFASetupResponseDTO{
+		Secret:          secret,
+		ProvisioningURI: totp.ProvisioningURI(s.config.MFAIssuer, account.Email, secret),
+	}, nil
+}
+
+// EnableMFA confirms the pending secret with a code and issues recovery codes
+func (s *authService) EnableMFA(accountID uint, code string) (*domain.MFARecoveryCodesDTO, error) {
+	account, err := s.findAccount(accountID)
+	if err != nil {
+		return nil, err
+	}
+	if account.TwoFactorEnabled() {
+		return nil, errors.New("two-factor authentication already enabled")
+	}
+	if account.TOTPSecret == "" {
+		return nil, errors.New("two-factor authentication not set up")
+	}
+
+	counter, ok := totp.Validate(account.TOTPSecret, code, time.Now(), totpSkew)
+	if !ok {
+		return nil, errors.New("invalid code")
+	}
+
+	enabledAt := time.Now()
+	if err := s.accountRepo.SetTOTP(accountID, account.TOTPSecret, &enabledAt); err != nil {
+		return nil, err
+	}
+	if _, err := s.accountRepo.AdvanceTOTPCounter(accountID, counter); err != nil {
+		return nil, err
+	}
+
+	return s.replaceRecoveryCodes(accountID)
+}
+
+// DisableMFA turns 2FA off; not allowed when the account's role requires it
+func (s *authService) DisableMFA(accountID uint, code string) error {
+	account, err := s.findAccount(accountID)
+	if err != nil {
+		return err
+	}
+	if !account.TwoFactorEnabled() {
+		return errors.New("two-factor authentication not enabled")
+	}
+	if s.mfaRequiredFor(account) {
+		return errors.New("two-factor authentication is required for your role")
+	}
+
+	if err := s.verifySecondFactor(account, code); err != nil {
+		return err
+	}
+
+	if err := s.accountRepo.SetTOTP(accountID, "", nil); err != nil {
+		return err
+	}
+	return s.recoveryRepo.DeleteForAccount(accountID)
+}
+
+// RegenerateRecoveryCodes invalidates the old recovery codes and returns a new set
+func (s *authService) RegenerateRecoveryCodes(accountID uint, code string) (*domain.MFARecoveryCodesDTO, error) {
+	account, err := s.findAccount(accountID)
+	if err != nil {
+		return nil, err
+	}
+	if !account.TwoFactorEnabled() {
+		return nil, errors.New("two-factor authentication not enabled")
+	}
+
+	if err := s.verifySecondFactor(account, code); err != nil {
+		return nil, err
+	}
+
+	return s.replaceRecoveryCodes(accountID)
+}
+
+// verifySecondFactor accepts a TOTP code (each time step once) or an unused recovery code
+func (s *authService) verifySecondFactor(account *domain.Account, code string) error {
+	code = strings.TrimSpace(code)
+
+	if counter, ok := totp.Validate(account.TOTPSecret, code, time.Now(), totpSkew); ok {
+		advanced, err := s.accountRepo.AdvanceTOTPCounter(account.ID, counter)
+		if err != nil {
+			return err
+		}
+		if !advanced {
+			return errors.New("invalid code")
+		}
+		return nil
+	}
+
+	normalized := normalizeRecoveryCode(code)
+	if normalized == "" {
+		return errors.New("invalid code")
+	}
+	consumed, err := s.recoveryRepo.Consume(account.ID, hashToken(normalized))
+	if err != nil {
+		return err
+	}
+	if !consumed {
+		return errors.New("invalid code")
+	}
+	return nil
+}
+
+func (s *authService) replaceRecoveryCodes(accountID uint) (*domain.MFARecoveryCodesDTO, error) {
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+	for i := range codes {
+		b := make([]byte, 6)
+		if _, err := rand.Read(b); err != nil {
+			return nil, err
+		}
+		raw := strings.ToLower(recoveryEncoding.EncodeToString(b)) // 10 characters
+		codes[i] = raw[:5] + "-" + raw[5:]
+		hashes[i] = hashToken(raw)
+	}
+
+	if err := s.recoveryRepo.ReplaceForAccount(accountID, hashes); err != nil {
+		return nil, err
+	}
+	return &domain.MFARecoveryCodesDTO{RecoveryCodes: codes}, nil
+}
+
+func (s *authService) parseMFAChallenge(challengeToken string) (uint, string, string, error) {
+	claims, err := s.keys.Parse(challengeToken)
+	if err != nil || claims["typ"] != mfaChallengeType {
+		return 0, "", "", errors.New("invalid challenge")
+	}
+	sub, ok := claims["sub"].(float64)
+	if !ok || sub <= 0 {
+		return 0, "", "", errors.New("invalid challenge")
+	}
+	jti, _ := claims["jti"].(string)
+	if jti == "" {
+		return 0, "", "", errors.New("invalid challenge")
+	}
+	provider, _ := claims["provider"].(string)
+	return uint(sub), provider, jti, nil
+}
+
+// findMFAChallenge returns the stored challenge while it is unused, unexpired and has attempts left
+func (s *authService) findMFAChallenge(accountID uint, jti string) (*domain.AccountToken, error) {
+	challenge, err := s.tokenRepo.FindByTokenHash(hashToken(jti))
+	if err != nil {
+		return nil, err
+	}
+	if challenge == nil ||
+		challenge.Purpose != domain.AccountTokenMFAChallenge ||
+		challenge.AccountID != accountID ||
+		challenge.UsedAt != nil ||
+		challenge.Attempts >= s.config.MFAChallengeMaxAttempts ||
+		time.Now().After(challenge.ExpiresAt) {
+		return nil, errors.New("invalid challenge")
+	}
+	return challenge, nil
+}
+
+// recordMFAChallengeFailure counts a wrong code and consumes the challenge once the limit is hit
+func (s *authService) recordMFAChallengeFailure(id uint) error {
+	attempts, err := s.tokenRepo.RecordAttempt(id)
+	if err != nil {
+		return err
+	}
+	if attempts >= s.config.MFAChallengeMaxAttempts {
+		_, err := s.tokenRepo.MarkUsed(id)
+		return err
+	}
+	return nil
+}
+
+func (s *authService) mfaRequiredFor(account *domain.Account) bool {
+	return s.config.RequireMFAForPrivileged && domain.AccountRole(account.Role).IsPrivileged()
+}
+
+func (s *authService) findAccount(accountID uint) (*domain.Account, error) {
+	account, err := s.accountRepo.FindOne(accountID)
+	if err != nil {
+		return nil, err
+	}
+	if account == nil {
+		return nil, errors.New("user not found")
+	}
+	return account, nil
+}
+
+// normalizeRecoveryCode strips separators and case so "ABCDE-FGHIJ" matches "abcdefghij"
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	if len(code) != 10 {
+		return ""
+	}
+	return code
+}
@@ -0,0 +1,198 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"api_go/internal/domain"
+	"api_go/internal/modules/auth/totp"
+)
+
+// enableMFA runs the setup flow for an account and returns its secret and recovery codes.
+// The enrollment code uses the current time step, so tests log in with codes of other steps.
+func enableMFA(t *testing.T, ta *testAuth, account *domain.Account) (string, []string) {
+	t.Helper()
+
+	setup, err := ta.SetupMFA(account.ID)
+	if err != nil {
+		t.Fatalf("SetupMFA: %v", err)
+	}
+	code := codeAt(t, setup.Secret, 0)
+	recovery, err := ta.EnableMFA(account.ID, code)
+	if err != nil {
+		t.Fatalf("EnableMFA: %v", err)
+	}
+	return setup.Secret, recovery.RecoveryCodes
+}
+
+// codeAt returns the code of the time step offset from the current one
+func codeAt(t *testing.T, secret string, offset int64) string {
+	t.Helper()
+	code, err := totp.Code(secret, totp.Counter(time.Now())+offset)
+	if err != nil {
+		t.Fatalf("totp.Code: %v", err)
+	}
+	return code
+}
+
+// challenge runs the first login step and returns the MFA challenge token
+func challenge(t *testing.T, ta *testAuth, account *domain.Account) string {
+	t.Helper()
+	current, err := ta.accounts.FindOne(account.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := ta.startSession(current, "local")
+	if err != nil {
+		t.Fatalf("startSession: %v", err)
+	}
+	if !result.MFARequired || result.ChallengeToken == "" || result.AccessToken != "" || result.RefreshToken != "" {
+		t.Fatalf("first step must only return a challenge, got %+v", result)
+	}
+	return result.ChallengeToken
+}
+
+func TestMFALogin(t *testing.T) {
+	ta := newTestAuth(t)
+	secret, _ := enableMFA(t, ta, ta.alice)
+
+	token := challenge(t, ta, ta.alice)
+	if id, err := ta.MFAChallengeAccount(token); err != nil || id != ta.alice.ID {
+		t.Errorf("MFAChallengeAccount = %d, %v", id, err)
+	}
+
+	result, err := ta.CompleteMFALogin(token, codeAt(t, secret, 1))
+	if err != nil {
+		t.Fatalf("CompleteMFALogin: %v", err)
+	}
+	payload, err := ta.AuthenticateToken(result.AccessToken)
+	if err != nil {
+		t.Fatalf("AuthenticateToken: %v", err)
+	}
+	if !payload.MFA || payload.Sub != ta.alice.ID {
+		t.Errorf("payload = %+v", payload)
+	}
+}
+
+func TestMFAChallengeIsSingleUse(t *testing.T) {
+	ta := newTestAuth(t)
+	secret, _ := enableMFA(t, ta, ta.alice)
+
+	token := challenge(t, ta, ta.alice)
+	if _, err := ta.CompleteMFALogin(token, codeAt(t, secret, 1)); err != nil {
+		t.Fatalf("CompleteMFALogin: %v", err)
+	}
+	if _, err := ta.CompleteMFALogin(token, codeAt(t, secret, -1)); err == nil || err.Error() != "invalid challenge" {
+		t.Errorf("second use err = %v, want invalid challenge", err)
+	}
+}
+
+func TestMFAChallengeIsInvalidatedAfterMaxAttempts(t *testing.T) {
+	ta := newTestAuth(t)
+	secret, _ := enableMFA(t, ta, ta.alice)
+	max := ta.config.MFAChallengeMaxAttempts
+
+	token := challenge(t, ta, ta.alice)
+	for i := 0; i < max; i++ {
+		if _, err := ta.CompleteMFALogin(token, "000000"); err == nil || err.Error() != "invalid code" {
+			t.Fatalf("attempt %d err = %v", i+1, err)
+		}
+	}
+	if _, err := ta.CompleteMFALogin(token, codeAt(t, secret, 1)); err == nil || err.Error() != "invalid challenge" {
+		t.Errorf("correct code after %d failures: err = %v, want invalid challenge", max, err)
+	}
+
+	// A new challenge (which requires the password again) works
+	if _, err := ta.CompleteMFALogin(challenge(t, ta, ta.alice), codeAt(t, secret, 1)); err != nil {
+		t.Errorf("fresh challenge: %v", err)
+	}
+}
+
+func TestMFARejectsReplayedCode(t *testing.T) {
+	ta := newTestAuth(t)
+	secret, _ := enableMFA(t, ta, ta.alice)
+	code := codeAt(t, secret, 1)
+
+	if _, err := ta.CompleteMFALogin(challenge(t, ta, ta.alice), code); err != nil {
+		t.Fatalf("CompleteMFALogin: %v", err)
+	}
+	if _, err := ta.CompleteMFALogin(challenge(t, ta, ta.alice), code); err == nil || err.Error() != "invalid code" {
+		t.Errorf("replayed code err = %v, want invalid code", err)
+	}
+	// Codes of earlier steps are rejected too once a later one was used
+	if _, err := ta.CompleteMFALogin(challenge(t, ta, ta.alice), codeAt(t, secret, -1)); err == nil {
+		t.Error("an older code was accepted")
+	}
+}
+
+func TestMFARecoveryCodesAreSingleUse(t *testing.T) {
+	ta := newTestAuth(t)
+	_, codes := enableMFA(t, ta, ta.alice)
+	if len(codes) != recoveryCodeCount {
+		t.Fatalf("got %d recovery codes", len(codes))
+	}
+
+	// Recovery codes are accepted without the dash and in upper case
+	typed := strings.ToUpper(strings.ReplaceAll(codes[0], "-", ""))
+	if _, err := ta.CompleteMFALogin(challenge(t, ta, ta.alice), typed); err != nil {
+		t.Fatalf("recovery code: %v", err)
+	}
+	if _, err := ta.CompleteMFALogin(challenge(t, ta, ta.alice), codes[0]); err == nil || err.Error() != "invalid code" {
+		t.Errorf("reused recovery code err = %v", err)
+	}
+
+	status, err := ta.GetMFAStatus(ta.alice.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !status.Enabled || status.RecoveryCodesRemaining != int64(recoveryCodeCount-1) {
+		t.Errorf("status = %+v", status)
+	}
+}
+
+func TestMFARejectsForgedChallenges(t *testing.T) {
+	ta := newTestAuth(t)
+	secret, _ := enableMFA(t, ta, ta.alice)
+	enableMFA(t, ta, ta.bob)
+
+	token := challenge(t, ta, ta.alice)
+	claims, err := ta.keys.Parse(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sign := func(mutate func(jwt.MapClaims)) string {
+		forged := jwt.MapClaims{}
+		for k, v := range claims {
+			forged[k] = v
+		}
+		mutate(forged)
+		signed, err := ta.keys.Sign(forged)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return signed
+	}
+
+	tests := map[string]string{
+		"other account's jti": sign(func(c jwt.MapClaims) { c["sub"] = ta.bob.ID }),
+		"unknown jti":         sign(func(c jwt.MapClaims) { c["jti"] = "made-up" }),
+		"no jti":              sign(func(c jwt.MapClaims) { delete(c, "jti") }),
+		"access token type":   sign(func(c jwt.MapClaims) { c["typ"] = "access" }),
+		"expired":             sign(func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Second).Unix() }),
+		"garbage":             "not-a-jwt",
+	}
+	for name, forged := range tests {
+		if _, err := ta.CompleteMFALogin(forged, codeAt(t, secret, 1)); err == nil || err.Error() != "invalid challenge" {
+			t.Errorf("%s: err = %v, want invalid challenge", name, err)
+		}
+	}
+
+	// The genuine challenge is untouched by the forged attempts
+	if _, err := ta.CompleteMFALogin(token, codeAt(t, secret, 1)); err != nil {
+		t.Errorf("genuine challenge: %v", err)
+	}
+}
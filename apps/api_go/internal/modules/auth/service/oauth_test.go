@@ -0,0 +1,63 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"api_go/internal/domain"
+)
+
+func googleProfile(email string) domain.OAuthProfile {
+	return domain.OAuthProfile{Provider: "google", Subject: "google-123", Email: email, EmailVerified: true, Name: "Alice"}
+}
+
+func TestOAuthLoginRefusesUnverifiedAccount(t *testing.T) {
+	ta := newTestAuth(t)
+
+	// Someone registered alice's address with a password and never verified it
+	ta.alice.Password = "attacker-hash"
+	squatter, err := ta.startSession(ta.alice, "local")
+	if err != nil {
+		t.Fatalf("startSession: %v", err)
+	}
+
+	_, err = ta.HandleOAuthLogin(googleProfile(ta.alice.Email))
+	if err == nil || err.Error() != "account email is not verified" {
+		t.Fatalf("err = %v, want account email is not verified", err)
+	}
+	if len(ta.identities.identities) != 0 {
+		t.Error("the provider identity must not be linked to an unverified account")
+	}
+	if ta.alice.EmailVerifiedAt != nil {
+		t.Error("the account email must not be marked verified")
+	}
+	if len(ta.sessions.sessions) != 1 {
+		t.Errorf("sessions = %d, no session may be started for the provider login", len(ta.sessions.sessions))
+	}
+	if _, err := ta.AuthenticateToken(squatter.AccessToken); err != nil {
+		t.Errorf("existing session of the account: err = %v", err)
+	}
+}
+
+func TestOAuthLoginLinksVerifiedAccount(t *testing.T) {
+	ta := newTestAuth(t)
+	verifiedAt := time.Now()
+	ta.alice.EmailVerifiedAt = &verifiedAt
+
+	result, err := ta.HandleOAuthLogin(googleProfile(ta.alice.Email))
+	if err != nil {
+		t.Fatalf("HandleOAuthLogin: %v", err)
+	}
+	if result.AccessToken == "" {
+		t.Fatalf("result = %+v, want a session", result)
+	}
+	if len(ta.identities.identities) != 1 || ta.identities.identities[0].AccountID != ta.alice.ID {
+		t.Fatalf("identities = %+v, want one linked to alice", ta.identities.identities)
+	}
+
+	// Later logins match the identity, whatever the email
+	profile := googleProfile("renamed@example.com")
+	if _, err := ta.HandleOAuthLogin(profile); err != nil {
+		t.Errorf("login with the linked identity: %v", err)
+	}
+}
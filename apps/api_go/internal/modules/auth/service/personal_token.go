@@ -0,0 +1,154 @@
+package service
+
+import (
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"api_go/internal/domain"
+)
+
+const (
+	personalTokenPrefix = "dwp_"
+	personalTokenBytes  = 32
+	// lastUsedResolution limits last_used_at writes to one per token per minute
+	lastUsedResolution = time.Minute
+)
+
+// CreatePersonalToken creates a token and returns it once; only its hash is stored
+func (s *authService) CreatePersonalToken(accountID uint, mfa bool, dto domain.CreatePersonalTokenDTO) (*domain.CreatedPersonalTokenDTO, error) {
+	name := strings.TrimSpace(dto.Name)
+	if name == "" {
+		return nil, errors.New("token name is required")
+	}
+
+	scopes := make([]string, 0, len(dto.Scopes))
+	seen := make(map[domain.TokenScope]bool)
+	for _, scope := range dto.Scopes {
+		if !scope.IsValid() {
+			return nil, errors.New("invalid scope: " + string(scope))
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			scopes = append(scopes, string(scope))
+		}
+	}
+
+	secret, err := generateOpaqueToken(personalTokenBytes)
+	if err != nil {
+		return nil, err
+	}
+	raw := personalTokenPrefix + secret
+
+	token := &domain.PersonalAccessToken{
+		AccountID: accountID,
+		Name:      name,
+		TokenHash: hashToken(raw),
+		Prefix:    raw[:len(personalTokenPrefix)+6],
+		Scopes:    strings.Join(scopes, " "),
+		MFA:       mfa,
+	}
+	if dto.ExpiresInDays != nil {
+		expiresAt := time.Now().AddDate(0, 0, *dto.ExpiresInDays)
+		token.ExpiresAt = &expiresAt
+	}
+
+	if err := s.patRepo.Create(token); err != nil {
+		return nil, err
+	}
+
+	return &domain.CreatedPersonalTokenDTO{
+		PersonalTokenResponseDTO: toPersonalTokenDTO(token),
+		Token:                    raw,
+	}, nil
+}
+
+// ListPersonalTokens lists the active tokens of an account
+func (s *authService) ListPersonalTokens(accountID uint) ([]domain.PersonalTokenResponseDTO, error) {
+	tokens, err := s.patRepo.FindByAccount(accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.PersonalTokenResponseDTO, len(tokens))
+	for i := range tokens {
+		result[i] = toPersonalTokenDTO(&tokens[i])
+	}
+	return result, nil
+}
+
+// RevokePersonalToken revokes a token owned by the account
+func (s *authService) RevokePersonalToken(accountID, tokenID uint) error {
+	revoked, err := s.patRepo.Revoke(tokenID, accountID)
+	if err != nil {
+		return err
+	}
+	if !revoked {
+		return errors.New("token not found")
+	}
+	return nil
+}
+
+// authenticatePersonalToken resolves a "dwp_" token to the payload of its owner
+func (s *authService) authenticatePersonalToken(raw string) (*domain.JWTPayload, error) {
+	token, err := s.patRepo.FindByTokenHash(hashToken(raw))
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if token == nil || token.RevokedAt != nil {
+		return nil, errors.New("invalid token")
+	}
+	if token.ExpiresAt != nil && now.After(*token.ExpiresAt) {
+		return nil, errors.New("token expired")
+	}
+
+	account, err := s.accountRepo.FindOne(token.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	if account == nil {
+		return nil, errors.New("invalid token")
+	}
+	if err := checkAccountStatus(account); err != nil {
+		return nil, err
+	}
+
+	if token.LastUsedAt == nil || now.Sub(*token.LastUsedAt) > lastUsedResolution {
+		if err := s.patRepo.TouchLastUsed(token.ID, now); err != nil {
+			log.Printf("failed to update last_used_at of token %d: %v", token.ID, err)
+		}
+	}
+
+	payload := &domain.JWTPayload{
+		Sub:      account.ID,
+		Email:    account.Email,
+		Role:     account.Role,
+		Name:     account.Name,
+		Provider: "token",
+		MFA:      token.MFA,
+		TokenID:  token.ID,
+		Scopes:   token.ScopeList(),
+	}
+	if account.AvatarURL != nil {
+		payload.Avatar = *account.AvatarURL
+	}
+	return payload, nil
+}
+
+func isPersonalToken(token string) bool {
+	return strings.HasPrefix(token, personalTokenPrefix)
+}
+
+func toPersonalTokenDTO(token *domain.PersonalAccessToken) domain.PersonalTokenResponseDTO {
+	return domain.PersonalTokenResponseDTO{
+		ID:         token.ID,
+		Name:       token.Name,
+		Prefix:     token.Prefix,
+		Scopes:     token.ScopeList(),
+		ExpiresAt:  token.ExpiresAt,
+		LastUsedAt: token.LastUsedAt,
+		CreatedAt:  token.CreatedAt,
+	}
+}
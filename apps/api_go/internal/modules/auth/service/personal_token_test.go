@@ -0,0 +1,139 @@
+package service
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"api_go/internal/domain"
+)
+
+func createToken(t *testing.T, ta *testAuth, accountID uint, mfa bool, scopes ...domain.TokenScope) *domain.CreatedPersonalTokenDTO {
+	t.Helper()
+	created, err := ta.CreatePersonalToken(accountID, mfa, domain.CreatePersonalTokenDTO{Name: "ci", Scopes: scopes})
+	if err != nil {
+		t.Fatalf("CreatePersonalToken: %v", err)
+	}
+	return created
+}
+
+func TestCreatePersonalToken(t *testing.T) {
+	ta := newTestAuth(t)
+
+	created := createToken(t, ta, ta.alice.ID, false, domain.ScopeVideosWrite, domain.ScopeTagsWrite, domain.ScopeVideosWrite)
+	if !strings.HasPrefix(created.Token, personalTokenPrefix) || !strings.HasPrefix(created.Token, created.Prefix) {
+		t.Errorf("token %q, prefix %q", created.Token, created.Prefix)
+	}
+	if want := []string{"videos:write", "tags:write"}; !reflect.DeepEqual(created.Scopes, want) {
+		t.Errorf("scopes = %v, want %v", created.Scopes, want)
+	}
+
+	stored := ta.personal.tokens[0]
+	if stored.TokenHash == created.Token || stored.TokenHash != hashToken(created.Token) {
+		t.Error("only the token hash must be stored")
+	}
+	if stored.ExpiresAt != nil {
+		t.Error("a token without expires_in_days must not expire")
+	}
+}
+
+func TestCreatePersonalTokenValidation(t *testing.T) {
+	ta := newTestAuth(t)
+
+	tests := []struct {
+		name string
+		dto  domain.CreatePersonalTokenDTO
+		want string
+	}{
+		{"blank name", domain.CreatePersonalTokenDTO{Name: "  ", Scopes: []domain.TokenScope{domain.ScopeTagsWrite}}, "token name is required"},
+		{"unknown scope", domain.CreatePersonalTokenDTO{Name: "ci", Scopes: []domain.TokenScope{"admin"}}, "invalid scope: admin"},
+	}
+	for _, tt := range tests {
+		if _, err := ta.CreatePersonalToken(ta.alice.ID, false, tt.dto); err == nil || err.Error() != tt.want {
+			t.Errorf("%s: err = %v, want %s", tt.name, err, tt.want)
+		}
+	}
+	if len(ta.personal.tokens) != 0 {
+		t.Error("invalid requests must not store a token")
+	}
+}
+
+func TestAuthenticatePersonalToken(t *testing.T) {
+	ta := newTestAuth(t)
+	created := createToken(t, ta, ta.alice.ID, false, domain.ScopeCommentsWrite)
+
+	payload, err := ta.AuthenticateToken(created.Token)
+	if err != nil {
+		t.Fatalf("AuthenticateToken: %v", err)
+	}
+	if payload.Sub != ta.alice.ID || payload.Provider != "token" || payload.TokenID != created.ID || !payload.IsPersonalToken() {
+		t.Errorf("payload = %+v", payload)
+	}
+	if !payload.HasScope(domain.ScopeCommentsWrite) || payload.HasScope(domain.ScopeVideosWrite) {
+		t.Errorf("scopes = %v", payload.Scopes)
+	}
+	if ta.personal.tokens[0].LastUsedAt == nil {
+		t.Error("last_used_at must be recorded")
+	}
+}
+
+func TestAuthenticatePersonalTokenRejections(t *testing.T) {
+	ta := newTestAuth(t)
+
+	revoked := createToken(t, ta, ta.alice.ID, false, domain.ScopeTagsWrite)
+	if err := ta.RevokePersonalToken(ta.alice.ID, revoked.ID); err != nil {
+		t.Fatalf("RevokePersonalToken: %v", err)
+	}
+
+	expired := createToken(t, ta, ta.alice.ID, false, domain.ScopeTagsWrite)
+	past := time.Now().Add(-time.Minute)
+	ta.personal.tokens[expired.ID-1].ExpiresAt = &past
+
+	carol := ta.addAccount(3, "carol@example.com", string(domain.AccountRoleUser))
+	banned := createToken(t, ta, carol.ID, false, domain.ScopeTagsWrite)
+	carol.Status = string(domain.AccountStatusBanned)
+
+	tests := map[string]string{
+		"revoked": revoked.Token,
+		"expired": expired.Token,
+		"banned":  banned.Token,
+		"unknown": personalTokenPrefix + "unknown",
+	}
+	for name, token := range tests {
+		if _, err := ta.AuthenticateToken(token); err == nil {
+			t.Errorf("%s token accepted", name)
+		}
+	}
+}
+
+func TestRevokePersonalTokenOfAnotherAccount(t *testing.T) {
+	ta := newTestAuth(t)
+	created := createToken(t, ta, ta.alice.ID, false, domain.ScopeTagsWrite)
+
+	if err := ta.RevokePersonalToken(ta.bob.ID, created.ID); err == nil || err.Error() != "token not found" {
+		t.Errorf("err = %v, want token not found", err)
+	}
+	if _, err := ta.AuthenticateToken(created.Token); err != nil {
+		t.Errorf("the token must stay valid: %v", err)
+	}
+}
+
+func TestPersonalTokenWithoutMFAIsNotPrivileged(t *testing.T) {
+	ta := newTestAuth(t)
+	ta.config.RequireMFAForPrivileged = true
+
+	plain := createToken(t, ta, ta.bob.ID, false, domain.ScopeTagsWrite)
+	payload, err := ta.AuthenticateToken(plain.Token)
+	if err != nil {
+		t.Fatalf("AuthenticateToken: %v", err)
+	}
+	if payload.Role != string(domain.AccountRoleUser) || !payload.MFARequired {
+		t.Errorf("token created without 2FA: role %q, mfa required %v", payload.Role, payload.MFARequired)
+	}
+
+	verified := createToken(t, ta, ta.bob.ID, true, domain.ScopeTagsWrite)
+	if payload, err = ta.AuthenticateToken(verified.Token); err != nil || payload.Role != string(domain.AccountRoleAdmin) {
+		t.Errorf("token created after 2FA: payload %+v, err %v", payload, err)
+	}
+}
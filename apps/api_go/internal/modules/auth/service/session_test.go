@@ -0,0 +1,183 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"api_go/internal/domain"
+)
+
+func TestRefreshSessionRotatesToken(t *testing.T) {
+	ta := newTestAuth(t)
+
+	login, err := ta.startSession(ta.alice, "local")
+	if err != nil {
+		t.Fatalf("startSession: %v", err)
+	}
+	if login.AccessToken == "" || login.RefreshToken == "" {
+		t.Fatalf("login returned %+v", login)
+	}
+	if ta.sessions.sessions[0].TokenHash == login.RefreshToken {
+		t.Error("the refresh token must be stored hashed")
+	}
+
+	refreshed, err := ta.RefreshSession(login.RefreshToken)
+	if err != nil {
+		t.Fatalf("RefreshSession: %v", err)
+	}
+	if refreshed.RefreshToken == login.RefreshToken {
+		t.Error("refresh must issue a new refresh token")
+	}
+
+	first, second := ta.sessions.sessions[0], ta.sessions.sessions[1]
+	if first.RotatedAt == nil {
+		t.Error("the presented token must be marked rotated")
+	}
+	if first.FamilyID != second.FamilyID {
+		t.Error("the new token must stay in the same session family")
+	}
+
+	// The access token is bound to the family and still valid
+	payload, err := ta.AuthenticateToken(refreshed.AccessToken)
+	if err != nil {
+		t.Fatalf("AuthenticateToken: %v", err)
+	}
+	if payload.SessionID != first.FamilyID || payload.Sub != ta.alice.ID {
+		t.Errorf("payload = %+v", payload)
+	}
+}
+
+func TestRefreshSessionReuseRevokesFamily(t *testing.T) {
+	ta := newTestAuth(t)
+
+	login, err := ta.startSession(ta.alice, "local")
+	if err != nil {
+		t.Fatalf("startSession: %v", err)
+	}
+	refreshed, err := ta.RefreshSession(login.RefreshToken)
+	if err != nil {
+		t.Fatalf("RefreshSession: %v", err)
+	}
+
+	// Replaying the rotated token (e.g. stolen) kills the whole family
+	if _, err := ta.RefreshSession(login.RefreshToken); err == nil || err.Error() != "refresh token reuse detected" {
+		t.Fatalf("reuse err = %v", err)
+	}
+	if _, err := ta.RefreshSession(refreshed.RefreshToken); err == nil {
+		t.Error("the legitimate successor token must be revoked too")
+	}
+	if _, err := ta.AuthenticateToken(refreshed.AccessToken); err == nil || err.Error() != "session revoked" {
+		t.Errorf("access token after reuse: err = %v", err)
+	}
+}
+
+func TestRefreshSessionRejectsInvalidTokens(t *testing.T) {
+	ta := newTestAuth(t)
+
+	login, err := ta.startSession(ta.alice, "local")
+	if err != nil {
+		t.Fatalf("startSession: %v", err)
+	}
+
+	for _, token := range []string{"", "unknown-token"} {
+		if _, err := ta.RefreshSession(token); err == nil || err.Error() != "invalid refresh token" {
+			t.Errorf("RefreshSession(%q) err = %v", token, err)
+		}
+	}
+
+	ta.sessions.sessions[0].ExpiresAt = time.Now().Add(-time.Second)
+	if _, err := ta.RefreshSession(login.RefreshToken); err == nil || err.Error() != "refresh token expired" {
+		t.Errorf("expired err = %v", err)
+	}
+}
+
+func TestRefreshSessionRejectsBannedAccount(t *testing.T) {
+	ta := newTestAuth(t)
+
+	login, err := ta.startSession(ta.alice, "local")
+	if err != nil {
+		t.Fatalf("startSession: %v", err)
+	}
+	ta.alice.Status = string(domain.AccountStatusBanned)
+
+	if _, err := ta.RefreshSession(login.RefreshToken); err == nil {
+		t.Fatal("a banned account must not refresh")
+	}
+	if active, _ := ta.sessions.IsFamilyActive(ta.sessions.sessions[0].FamilyID); active {
+		t.Error("the session family of a banned account must be revoked")
+	}
+}
+
+func TestLogoutRevokesFamily(t *testing.T) {
+	ta := newTestAuth(t)
+
+	login, err := ta.startSession(ta.alice, "local")
+	if err != nil {
+		t.Fatalf("startSession: %v", err)
+	}
+	other, err := ta.startSession(ta.alice, "local")
+	if err != nil {
+		t.Fatalf("startSession: %v", err)
+	}
+
+	if err := ta.Logout(login.RefreshToken); err != nil {
+		t.Fatalf("Logout: %v", err)
+	}
+	if _, err := ta.AuthenticateToken(login.AccessToken); err == nil {
+		t.Error("the logged out session must be rejected")
+	}
+	if _, err := ta.RefreshSession(login.RefreshToken); err == nil {
+		t.Error("the logged out refresh token must be rejected")
+	}
+	// Other devices keep their session
+	if _, err := ta.AuthenticateToken(other.AccessToken); err != nil {
+		t.Errorf("other session: %v", err)
+	}
+}
+
+func TestAuthenticateTokenRejectsTokensWithoutSession(t *testing.T) {
+	ta := newTestAuth(t)
+
+	token, err := ta.GenerateJWT(domain.JWTPayload{Sub: ta.alice.ID, Email: ta.alice.Email, Role: ta.alice.Role})
+	if err != nil {
+		t.Fatalf("GenerateJWT: %v", err)
+	}
+	if _, err := ta.AuthenticateToken(token); err == nil {
+		t.Error("a token without sid must be rejected")
+	}
+}
+
+func TestStatusCacheDoesNotCarryRoleIntoNewSession(t *testing.T) {
+	ta := newTestAuth(t)
+	ta.statusCache = newStatusCache(time.Hour)
+
+	old, err := ta.startSession(ta.bob, "local")
+	if err != nil {
+		t.Fatalf("startSession: %v", err)
+	}
+	if payload, err := ta.AuthenticateToken(old.AccessToken); err != nil || payload.Role != string(domain.AccountRoleAdmin) {
+		t.Fatalf("before demotion: payload = %+v, err = %v", payload, err)
+	}
+
+	// A role change updates the account and revokes its sessions (see accountService.UpdateRole)
+	ta.bob.Role = string(domain.AccountRoleUser)
+	if err := ta.sessions.RevokeAllForAccount(ta.bob.ID); err != nil {
+		t.Fatalf("RevokeAllForAccount: %v", err)
+	}
+
+	if _, err := ta.AuthenticateToken(old.AccessToken); err == nil || err.Error() != "session revoked" {
+		t.Errorf("old access token: err = %v", err)
+	}
+
+	fresh, err := ta.startSession(ta.bob, "local")
+	if err != nil {
+		t.Fatalf("startSession: %v", err)
+	}
+	payload, err := ta.AuthenticateToken(fresh.AccessToken)
+	if err != nil {
+		t.Fatalf("AuthenticateToken: %v", err)
+	}
+	if payload.Role != string(domain.AccountRoleUser) {
+		t.Errorf("new session role = %q, want the demoted role", payload.Role)
+	}
+}
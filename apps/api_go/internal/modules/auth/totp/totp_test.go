@@ -0,0 +1,99 @@
+package totp
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// rfcSecret is the RFC 6238 SHA-1 test key "12345678901234567890" in base32
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+// The RFC 6238 appendix B vectors, truncated to 6 digits
+var rfcVectors = []struct {
+	unix int64
+	code string
+}{
+	{59, "287082"},
+	{1111111109, "081804"},
+	{1111111111, "050471"},
+	{1234567890, "005924"},
+	{2000000000, "279037"},
+	{20000000000, "353130"},
+}
+
+func TestCodeMatchesRFC6238(t *testing.T) {
+	for _, v := range rfcVectors {
+		got, err := Code(rfcSecret, Counter(time.Unix(v.unix, 0)))
+		if err != nil {
+			t.Fatalf("Code: %v", err)
+		}
+		if got != v.code {
+			t.Errorf("code at %d = %s, want %s", v.unix, got, v.code)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	counter := Counter(now)
+
+	if got, ok := Validate(rfcSecret, "050471", now, 1); !ok || got != counter {
+		t.Errorf("current code: counter=%d ok=%v", got, ok)
+	}
+	// Spaces and the secret's case are tolerated
+	if _, ok := Validate(strings.ToLower(rfcSecret), " 050 471 ", now, 1); !ok {
+		t.Error("formatted code rejected")
+	}
+
+	previous, _ := Code(rfcSecret, counter-1)
+	if got, ok := Validate(rfcSecret, previous, now, 1); !ok || got != counter-1 {
+		t.Errorf("previous step within skew: counter=%d ok=%v", got, ok)
+	}
+	if _, ok := Validate(rfcSecret, previous, now, 0); ok {
+		t.Error("previous step accepted without skew")
+	}
+
+	old, _ := Code(rfcSecret, counter-2)
+	for _, code := range []string{old, "000000", "05047", "0504711", ""} {
+		if _, ok := Validate(rfcSecret, code, now, 1); ok {
+			t.Errorf("code %q accepted", code)
+		}
+	}
+	if _, ok := Validate("not base32!", "050471", now, 1); ok {
+		t.Error("invalid secret accepted")
+	}
+}
+
+func TestGenerateSecret(t *testing.T) {
+	a, err := GenerateSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := GenerateSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a == b {
+		t.Error("secrets must be random")
+	}
+	if key, err := encoding.DecodeString(a); err != nil || len(key) != secretSize {
+		t.Errorf("secret %q decodes to %d bytes (%v)", a, len(key), err)
+	}
+}
+
+func TestProvisioningURI(t *testing.T) {
+	raw := ProvisioningURI("Dev Wiki", "ada@example.com", rfcSecret)
+	u, err := url.Parse(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.Scheme != "otpauth" || u.Host != "totp" || u.Path != "/Dev Wiki:ada@example.com" {
+		t.Errorf("uri = %s", raw)
+	}
+	q := u.Query()
+	if q.Get("secret") != rfcSecret || q.Get("issuer") != "Dev Wiki" || q.Get("digits") != "6" || q.Get("period") != "30" {
+		t.Errorf("query = %v", q)
+	}
+}
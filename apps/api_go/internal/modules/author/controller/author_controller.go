@@ -0,0 +1,119 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"api_go/internal/domain"
+	"api_go/internal/modules/auth/middleware"
+)
+
+type AuthorController struct {
+	service domain.AuthorService
+}
+
+// NewAuthorController creates a new AuthorController instance
+func NewAuthorController(service domain.AuthorService) *AuthorController {
+	return &AuthorController{service: service}
+}
+
+// RegisterRoutes registers the public author routes
+func (ctrl *AuthorController) RegisterRoutes(r *gin.RouterGroup, policy *middleware.RoutePolicy) {
+	users := r.Group("/users")
+	{
+		users.GET("/:id", ctrl.GetProfile)
+		users.GET("/:id/tutorials", ctrl.FindTutorials)
+		users.GET("/:id/videos", ctrl.FindVideos)
+	}
+}
+
+// GetProfile handles GET /users/:id
+// @Summary Get an author profile
+// @Description Public profile with counts of published tutorials, uploaded videos, comments and received votes
+// @Tags users
+// @Produce json
+// @Param id path string true "Account ID or handle"
+// @Success 200 {object} domain.AuthorProfileDTO
+// @Failure 404 {object} map[string]string
+// @Router /users/{id} [get]
+func (ctrl *AuthorController) GetProfile(c *gin.Context) {
+	profile, err := ctrl.service.GetProfile(c.Param("id"))
+	if err != nil {
+		ctrl.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
+
+// FindTutorials handles GET /users/:id/tutorials
+// @Summary List an author's tutorials
+// @Description Published tutorials of the author, newest first
+// @Tags users
+// @Produce json
+// @Param id path string true "Account ID or handle"
+// @Param limit query int false "Page size (default 20, max 50)"
+// @Param cursor query int false "nextCursor of the previous page"
+// @Success 200 {object} domain.AuthorTutorialsDTO
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /users/{id}/tutorials [get]
+func (ctrl *AuthorController) FindTutorials(c *gin.Context) {
+	params, ok := ctrl.bindListParams(c)
+	if !ok {
+		return
+	}
+
+	result, err := ctrl.service.FindTutorials(c.Param("id"), params)
+	if err != nil {
+		ctrl.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// FindVideos handles GET /users/:id/videos
+// @Summary List an author's videos
+// @Description Videos uploaded by the author, newest first
+// @Tags users
+// @Produce json
+// @Param id path string true "Account ID or handle"
+// @Param limit query int false "Page size (default 20, max 50)"
+// @Param cursor query int false "nextCursor of the previous page"
+// @Success 200 {object} domain.AuthorVideosDTO
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /users/{id}/videos [get]
+func (ctrl *AuthorController) FindVideos(c *gin.Context) {
+	params, ok := ctrl.bindListParams(c)
+	if !ok {
+		return
+	}
+
+	result, err := ctrl.service.FindVideos(c.Param("id"), params)
+	if err != nil {
+		ctrl.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func (ctrl *AuthorController) bindListParams(c *gin.Context) (domain.AuthorContentParams, bool) {
+	var params domain.AuthorContentParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return params, false
+	}
+	return params, true
+}
+
+func (ctrl *AuthorController) respondError(c *gin.Context, err error) {
+	if err.Error() == "author not found" {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+}
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"api_go/internal/domain"
+)
+
+type authorService struct {
+	accountRepo  domain.AccountRepository
+	tutorialRepo domain.TutorialRepository
+	videoRepo    domain.VideoRepository
+	commentRepo  domain.CommentRepository
+	voteRepo     domain.VoteRepository
+	tutorialSvc  domain.TutorialService
+	videoSvc     domain.VideoService
+}
+
+// NewAuthorService creates a new AuthorService instance
+func NewAuthorService(
+	accountRepo domain.AccountRepository,
+	tutorialRepo domain.TutorialRepository,
+	videoRepo domain.VideoRepository,
+	commentRepo domain.CommentRepository,
+	voteRepo domain.VoteRepository,
+	tutorialSvc domain.TutorialService,
+	videoSvc domain.VideoService,
+) domain.AuthorService {
+	return &authorService{
+		accountRepo:  accountRepo,
+		tutorialRepo: tutorialRepo,
+		videoRepo:    videoRepo,
+		commentRepo:  commentRepo,
+		voteRepo:     voteRepo,
+		tutorialSvc:  tutorialSvc,
+		videoSvc:     videoSvc,
+	}
+}
+
+// GetProfile returns the public profile with contribution counts
+func (s *authorService) GetProfile(ref string) (*domain.AuthorProfileDTO, error) {
+	account, err := s.findAuthor(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	links, err := account.ProfileLinks()
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := s.stats(account.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.AuthorProfileDTO{
+		ID:        account.ID,
+		Name:      account.Name,
+		Handle:    account.Handle,
+		AvatarURL: account.AvatarURL,
+		Bio:       account.Bio,
+		Links:     links,
+		JoinedAt:  account.CreatedAt,
+		Stats:     *stats,
+	}, nil
+}
+
+// FindTutorials lists the author's published tutorials
+func (s *authorService) FindTutorials(ref string, params domain.AuthorContentParams) (*domain.AuthorTutorialsDTO, error) {
+	account, err := s.findAuthor(ref)
+	if err != nil {
+		return nil, err
+	}
+	return s.tutorialSvc.FindPublishedByAuthor(account.ID, params)
+}
+
+// FindVideos lists the videos the author uploaded
+func (s *authorService) FindVideos(ref string, params domain.AuthorContentParams) (*domain.AuthorVideosDTO, error) {
+	account, err := s.findAuthor(ref)
+	if err != nil {
+		return nil, err
+	}
+	return s.videoSvc.FindPageByUploaderID(account.ID, params)
+}
+
+// findAuthor loads an account by ID or handle that may be shown publicly (banned and deleted accounts are hidden)
+func (s *authorService) findAuthor(ref string) (*domain.Account, error) {
+	var account *domain.Account
+	var err error
+	if id, parseErr := strconv.ParseUint(ref, 10, 32); parseErr == nil {
+		account, err = s.accountRepo.FindOne(uint(id))
+	} else {
+		account, err = s.accountRepo.FindByHandle(strings.ToLower(strings.TrimPrefix(ref, "@")))
+	}
+	if err != nil {
+		return nil, err
+	}
+	if account == nil || account.StatusAt(time.Now()) != domain.AccountStatusActive {
+		return nil, errors.New("author not found")
+	}
+	return account, nil
+}
+
+func (s *authorService) stats(id uint) (*domain.AuthorStatsDTO, error) {
+	var stats domain.AuthorStatsDTO
+	var err error
+
+	if stats.Tutorials, err = s.tutorialRepo.CountPublishedByAuthor(id); err != nil {
+		return nil, err
+	}
+	if stats.Videos, err = s.videoRepo.CountByUploaderID(id); err != nil {
+		return nil, err
+	}
+	if stats.Comments, err = s.commentRepo.CountByAuthor(id); err != nil {
+		return nil, err
+	}
+	if stats.ReceivedUpvotes, err = s.voteRepo.CountReceivedByAuthor(id, domain.VoteTypeUp); err != nil {
+		return nil, err
+	}
+	if stats.ReceivedDownvotes, err = s.voteRepo.CountReceivedByAuthor(id, domain.VoteTypeDown); err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
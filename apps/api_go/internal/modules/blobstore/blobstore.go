@@ -0,0 +1,30 @@
+package blobstore
+
+import (
+	"errors"
+	"strings"
+
+	"api_go/internal/config"
+	"api_go/internal/domain"
+)
+
+// NewBlobStore creates the BlobStore selected by MEDIA_STORE ("local" or "s3")
+func NewBlobStore(cfg *config.Config) domain.BlobStore {
+	if cfg.MediaStore == "s3" {
+		return NewS3Store(cfg)
+	}
+	return NewLocalStore(cfg.MediaDir)
+}
+
+// validateKey rejects keys that could escape the store root or be misread as URLs
+func validateKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return errors.New("invalid blob key")
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == "" || part == "." || part == ".." {
+			return errors.New("invalid blob key")
+		}
+	}
+	return nil
+}
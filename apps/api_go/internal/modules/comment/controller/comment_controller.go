@@ -7,6 +7,7 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"api_go/internal/domain"
+	"api_go/internal/modules/auth/middleware"
 )
 
 type CommentController struct {
@@ -19,19 +20,19 @@ func NewCommentController(service domain.CommentService) *CommentController {
 }
 
 // RegisterRoutes registers all comment routes
-func (ctrl *CommentController) RegisterRoutes(r *gin.RouterGroup) {
+func (ctrl *CommentController) RegisterRoutes(r *gin.RouterGroup, policy *middleware.RoutePolicy) {
 	comments := r.Group("/comments")
 	{
-		comments.POST("", ctrl.Create)
+		comments.POST("", policy.Authenticated(domain.ScopeCommentsWrite), ctrl.Create)
 		comments.GET("", ctrl.FindAll)
 		comments.GET("/entity/:entityType/:entityId", ctrl.FindByEntity)
 		comments.GET("/author/:authorId", ctrl.FindByAuthor)
 		comments.GET("/replies/:parentId", ctrl.FindReplies)
 		comments.GET("/:id", ctrl.FindOne)
-		comments.PATCH("/:id", ctrl.Update)
-		comments.PATCH("/:id/upvote", ctrl.IncrementUpvotes)
-		comments.PATCH("/:id/downvote", ctrl.DecrementUpvotes)
-		comments.DELETE("/:id", ctrl.Remove)
+		comments.PATCH("/:id", policy.Authenticated(domain.ScopeCommentsWrite), ctrl.Update)
+		comments.PATCH("/:id/upvote", policy.Authenticated(domain.ScopeCommentsWrite), ctrl.IncrementUpvotes)
+		comments.PATCH("/:id/downvote", policy.Authenticated(domain.ScopeCommentsWrite), ctrl.DecrementUpvotes)
+		comments.DELETE("/:id", policy.Authenticated(domain.ScopeCommentsWrite), ctrl.Remove)
 	}
 }
 
@@ -44,6 +45,7 @@ func (ctrl *CommentController) RegisterRoutes(r *gin.RouterGroup) {
 // @Param dto body domain.CreateCommentDTO true "Create Comment DTO"
 // @Success 201 {object} domain.CommentResponseDTO
 // @Failure 400 {object} map[string]string
+// @Security BearerAuth
 // @Router /comments [post]
 func (ctrl *CommentController) Create(c *gin.Context) {
 	var dto domain.CreateCommentDTO
@@ -52,6 +54,13 @@ func (ctrl *CommentController) Create(c *gin.Context) {
 		return
 	}
 
+	actor, ok := middleware.GetActor(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	dto.AuthorID = actor.ID
+
 	comment, err := ctrl.service.Create(dto)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -196,7 +205,9 @@ func (ctrl *CommentController) FindReplies(c *gin.Context) {
 // @Param dto body domain.UpdateCommentDTO true "Update Comment DTO"
 // @Success 200 {object} domain.CommentResponseDTO
 // @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
 // @Failure 404 {object} map[string]string
+// @Security BearerAuth
 // @Router /comments/{id} [patch]
 func (ctrl *CommentController) Update(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
@@ -211,11 +222,21 @@ func (ctrl *CommentController) Update(c *gin.Context) {
 		return
 	}
 
-	comment, err := ctrl.service.Update(uint(id), dto)
+	actor, ok := middleware.GetActor(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	comment, err := ctrl.service.Update(uint(id), dto, actor)
 	if err != nil {
-		if err.Error() == "comment not found" {
+		switch err.Error() {
+		case "comment not found":
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 			return
+		case "forbidden":
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
 		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -226,13 +247,15 @@ func (ctrl *CommentController) Update(c *gin.Context) {
 
 // IncrementUpvotes handles PATCH /comments/:id/upvote
 // @Summary Upvote a comment
-// @Description Increment the upvote count of a comment
+// @Description Record the caller's upvote; each account has one vote per comment, repeating it has no effect
 // @Tags comments
 // @Produce json
 // @Param id path int true "Comment ID"
 // @Success 200 {object} domain.CommentResponseDTO
 // @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
 // @Failure 404 {object} map[string]string
+// @Security BearerAuth
 // @Router /comments/{id}/upvote [patch]
 func (ctrl *CommentController) IncrementUpvotes(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
@@ -241,7 +264,13 @@ func (ctrl *CommentController) IncrementUpvotes(c *gin.Context) {
 		return
 	}
 
-	comment, err := ctrl.service.IncrementUpvotes(uint(id))
+	actor, ok := middleware.GetActor(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	comment, err := ctrl.service.IncrementUpvotes(uint(id), actor)
 	if err != nil {
 		if err.Error() == "comment not found" {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
@@ -256,13 +285,15 @@ func (ctrl *CommentController) IncrementUpvotes(c *gin.Context) {
 
 // DecrementUpvotes handles PATCH /comments/:id/downvote
 // @Summary Downvote a comment
-// @Description Decrement the upvote count of a comment
+// @Description Record the caller's downvote; each account has one vote per comment, repeating it has no effect
 // @Tags comments
 // @Produce json
 // @Param id path int true "Comment ID"
 // @Success 200 {object} domain.CommentResponseDTO
 // @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
 // @Failure 404 {object} map[string]string
+// @Security BearerAuth
 // @Router /comments/{id}/downvote [patch]
 func (ctrl *CommentController) DecrementUpvotes(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
@@ -271,7 +302,13 @@ func (ctrl *CommentController) DecrementUpvotes(c *gin.Context) {
 		return
 	}
 
-	comment, err := ctrl.service.DecrementUpvotes(uint(id))
+	actor, ok := middleware.GetActor(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	comment, err := ctrl.service.DecrementUpvotes(uint(id), actor)
 	if err != nil {
 		if err.Error() == "comment not found" {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
@@ -291,7 +328,9 @@ func (ctrl *CommentController) DecrementUpvotes(c *gin.Context) {
 // @Param id path int true "Comment ID"
 // @Success 200 {object} map[string]bool
 // @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
 // @Failure 404 {object} map[string]string
+// @Security BearerAuth
 // @Router /comments/{id} [delete]
 func (ctrl *CommentController) Remove(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
@@ -300,11 +339,21 @@ func (ctrl *CommentController) Remove(c *gin.Context) {
 		return
 	}
 
-	err = ctrl.service.Remove(uint(id))
+	actor, ok := middleware.GetActor(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	err = ctrl.service.Remove(uint(id), actor)
 	if err != nil {
-		if err.Error() == "comment not found" {
+		switch err.Error() {
+		case "comment not found":
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 			return
+		case "forbidden":
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
 		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -54,6 +54,38 @@ func (r *commentRepository) Update(id uint, update *domain.Comment) error {
 	return nil
 }
 
+// ApplyVote stores the account's single vote on a comment and recounts the score as
+// upvotes minus downvotes, in one transaction so the vote and the score never drift apart
+func (r *commentRepository) ApplyVote(id uint, userID uint, voteType domain.VoteType) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var existing domain.Vote
+		err := tx.Where("user_id = ? AND entity_type = ? AND entity_id = ?", userID, domain.EntityTypeComment, int64(id)).
+			First(&existing).Error
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			err = tx.Create(&domain.Vote{
+				UserID:     userID,
+				EntityType: domain.EntityTypeComment,
+				EntityID:   int64(id),
+				VoteType:   voteType,
+			}).Error
+		case err == nil && existing.VoteType != voteType:
+			err = tx.Model(&existing).Update("vote_type", voteType).Error
+		}
+		if err != nil {
+			return err
+		}
+
+		score := tx.Model(&domain.Vote{}).
+			Select("COALESCE(SUM(CASE WHEN vote_type = ? THEN 1 WHEN vote_type = ? THEN -1 ELSE 0 END), 0)",
+				domain.VoteTypeUp, domain.VoteTypeDown).
+			Where("entity_type = ? AND entity_id = ?", domain.EntityTypeComment, int64(id))
+		return tx.Model(&domain.Comment{}).
+			Where("id = ?", id).
+			UpdateColumn("upvotes", score).Error
+	})
+}
+
 // Delete removes a comment by ID
 func (r *commentRepository) Delete(id uint) error {
 	result := r.db.Delete(&domain.Comment{}, id)
@@ -84,6 +116,13 @@ func (r *commentRepository) FindByAuthor(authorID uint) ([]domain.Comment, error
 	return comments, err
 }
 
+// CountByAuthor counts comments by author ID
+func (r *commentRepository) CountByAuthor(authorID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&domain.Comment{}).Where("author_id = ?", authorID).Count(&count).Error
+	return count, err
+}
+
 // FindByParent retrieves replies to a comment
 func (r *commentRepository) FindByParent(parentID uint) ([]domain.Comment, error) {
 	var comments []domain.Comment
@@ -4,15 +4,17 @@ import (
 	"errors"
 
 	"api_go/internal/domain"
+	"api_go/internal/mention"
 )
 
 type commentService struct {
-	repo domain.CommentRepository
+	repo     domain.CommentRepository
+	mentions domain.MentionResolver
 }
 
 // NewCommentService creates a new CommentService instance
-func NewCommentService(repo domain.CommentRepository) domain.CommentService {
-	return &commentService{repo: repo}
+func NewCommentService(repo domain.CommentRepository, mentions domain.MentionResolver) domain.CommentService {
+	return &commentService{repo: repo, mentions: mentions}
 }
 
 // toResponseDTO converts Comment entity to CommentResponseDTO
@@ -21,22 +23,24 @@ func toResponseDTO(c *domain.Comment) *domain.CommentResponseDTO {
 		return nil
 	}
 
-	authorName := ""
+	authorName, authorHandle := "", ""
 	if c.Author != nil {
 		authorName = c.Author.Name
+		authorHandle = c.Author.Handle
 	}
 
 	dto := &domain.CommentResponseDTO{
-		ID:         c.ID,
-		Content:    c.Content,
-		AuthorID:   c.AuthorID,
-		AuthorName: authorName,
-		ParentID:   c.ParentID,
-		EntityType: c.EntityType,
-		EntityID:   c.EntityID,
-		Upvotes:    c.Upvotes,
-		CreatedAt:  c.CreatedAt,
-		UpdatedAt:  c.UpdatedAt,
+		ID:           c.ID,
+		Content:      c.Content,
+		AuthorID:     c.AuthorID,
+		AuthorName:   authorName,
+		AuthorHandle: authorHandle,
+		ParentID:     c.ParentID,
+		EntityType:   c.EntityType,
+		EntityID:     c.EntityID,
+		Upvotes:      c.Upvotes,
+		CreatedAt:    c.CreatedAt,
+		UpdatedAt:    c.UpdatedAt,
 	}
 
 	// Convert replies
@@ -59,6 +63,49 @@ func toResponseDTOList(comments []domain.Comment) []domain.CommentResponseDTO {
 	return result
 }
 
+// respond converts a comment and resolves its mentions
+func (s *commentService) respond(c *domain.Comment) (*domain.CommentResponseDTO, error) {
+	dtos, err := s.respondList([]domain.Comment{*c})
+	if err != nil {
+		return nil, err
+	}
+	return &dtos[0], nil
+}
+
+// respondList converts comments and resolves the @handle mentions of all of them, replies
+// included, with a single lookup
+func (s *commentService) respondList(comments []domain.Comment) ([]domain.CommentResponseDTO, error) {
+	dtos := toResponseDTOList(comments)
+
+	var contents []string
+	walkComments(dtos, func(dto *domain.CommentResponseDTO) { contents = append(contents, dto.Content) })
+	handles := mention.Extract(contents...)
+	if len(handles) == 0 {
+		return dtos, nil
+	}
+
+	accounts, err := s.mentions.ResolveMentions(handles)
+	if err != nil {
+		return nil, err
+	}
+	walkComments(dtos, func(dto *domain.CommentResponseDTO) {
+		for _, handle := range mention.Extract(dto.Content) {
+			if account, ok := accounts[handle]; ok {
+				dto.Mentions = append(dto.Mentions, account)
+			}
+		}
+	})
+	return dtos, nil
+}
+
+// walkComments calls fn on every comment and, depth first, on its replies
+func walkComments(dtos []domain.CommentResponseDTO, fn func(dto *domain.CommentResponseDTO)) {
+	for i := range dtos {
+		fn(&dtos[i])
+		walkComments(dtos[i].Replies, fn)
+	}
+}
+
 // Create creates a new comment
 func (s *commentService) Create(dto domain.CreateCommentDTO) (*domain.CommentResponseDTO, error) {
 	comment := &domain.Comment{
@@ -80,7 +127,7 @@ func (s *commentService) Create(dto domain.CreateCommentDTO) (*domain.CommentRes
 		return nil, err
 	}
 
-	return toResponseDTO(created), nil
+	return s.respond(created)
 }
 
 // FindAll retrieves all comments
@@ -89,7 +136,7 @@ func (s *commentService) FindAll() ([]domain.CommentResponseDTO, error) {
 	if err != nil {
 		return nil, err
 	}
-	return toResponseDTOList(comments), nil
+	return s.respondList(comments)
 }
 
 // FindOne retrieves a comment by ID
@@ -101,11 +148,11 @@ func (s *commentService) FindOne(id uint) (*domain.CommentResponseDTO, error) {
 	if comment == nil {
 		return nil, errors.New("comment not found")
 	}
-	return toResponseDTO(comment), nil
+	return s.respond(comment)
 }
 
 // Update updates a comment
-func (s *commentService) Update(id uint, dto domain.UpdateCommentDTO) (*domain.CommentResponseDTO, error) {
+func (s *commentService) Update(id uint, dto domain.UpdateCommentDTO, actor domain.Actor) (*domain.CommentResponseDTO, error) {
 	existing, err := s.repo.FindOne(id)
 	if err != nil {
 		return nil, err
@@ -113,6 +160,13 @@ func (s *commentService) Update(id uint, dto domain.UpdateCommentDTO) (*domain.C
 	if existing == nil {
 		return nil, errors.New("comment not found")
 	}
+	if !actor.CanModify(existing.AuthorID) {
+		return nil, errors.New("forbidden")
+	}
+	// Authors may edit content, but only moderators can overwrite vote counts
+	if dto.Upvotes != nil && !actor.CanModerate() {
+		return nil, errors.New("forbidden")
+	}
 
 	update := &domain.Comment{}
 	if dto.Content != nil {
@@ -131,11 +185,11 @@ func (s *commentService) Update(id uint, dto domain.UpdateCommentDTO) (*domain.C
 		return nil, err
 	}
 
-	return toResponseDTO(updated), nil
+	return s.respond(updated)
 }
 
 // Remove deletes a comment
-func (s *commentService) Remove(id uint) error {
+func (s *commentService) Remove(id uint, actor domain.Actor) error {
 	existing, err := s.repo.FindOne(id)
 	if err != nil {
 		return err
@@ -143,6 +197,9 @@ func (s *commentService) Remove(id uint) error {
 	if existing == nil {
 		return errors.New("comment not found")
 	}
+	if !actor.CanModify(existing.AuthorID) {
+		return errors.New("forbidden")
+	}
 	return s.repo.Delete(id)
 }
 
@@ -152,7 +209,7 @@ func (s *commentService) FindByEntity(entityType domain.EntityType, entityID int
 	if err != nil {
 		return nil, err
 	}
-	return toResponseDTOList(comments), nil
+	return s.respondList(comments)
 }
 
 // FindByAuthor retrieves comments by author
@@ -161,7 +218,7 @@ func (s *commentService) FindByAuthor(authorID uint) ([]domain.CommentResponseDT
 	if err != nil {
 		return nil, err
 	}
-	return toResponseDTOList(comments), nil
+	return s.respondList(comments)
 }
 
 // FindReplies retrieves replies to a comment
@@ -170,36 +227,22 @@ func (s *commentService) FindReplies(parentID uint) ([]domain.CommentResponseDTO
 	if err != nil {
 		return nil, err
 	}
-	return toResponseDTOList(comments), nil
+	return s.respondList(comments)
 }
 
-// IncrementUpvotes increments upvotes for a comment
-func (s *commentService) IncrementUpvotes(id uint) (*domain.CommentResponseDTO, error) {
-	comment, err := s.repo.FindOne(id)
-	if err != nil {
-		return nil, err
-	}
-	if comment == nil {
-		return nil, errors.New("comment not found")
-	}
-
-	newUpvotes := comment.Upvotes + 1
-	update := &domain.Comment{Upvotes: newUpvotes}
-
-	if err := s.repo.Update(id, update); err != nil {
-		return nil, err
-	}
-
-	updated, err := s.repo.FindOne(id)
-	if err != nil {
-		return nil, err
-	}
+// IncrementUpvotes records the actor's upvote on a comment
+func (s *commentService) IncrementUpvotes(id uint, actor domain.Actor) (*domain.CommentResponseDTO, error) {
+	return s.vote(id, actor, domain.VoteTypeUp)
+}
 
-	return toResponseDTO(updated), nil
+// DecrementUpvotes records the actor's downvote on a comment
+func (s *commentService) DecrementUpvotes(id uint, actor domain.Actor) (*domain.CommentResponseDTO, error) {
+	return s.vote(id, actor, domain.VoteTypeDown)
 }
 
-// DecrementUpvotes decrements upvotes for a comment
-func (s *commentService) DecrementUpvotes(id uint) (*domain.CommentResponseDTO, error) {
+// vote stores one vote per account and recounts the score, so repeating the same vote has
+// no effect and switching sides moves the score from one side to the other
+func (s *commentService) vote(id uint, actor domain.Actor, voteType domain.VoteType) (*domain.CommentResponseDTO, error) {
 	comment, err := s.repo.FindOne(id)
 	if err != nil {
 		return nil, err
@@ -208,13 +251,7 @@ func (s *commentService) DecrementUpvotes(id uint) (*domain.CommentResponseDTO,
 		return nil, errors.New("comment not found")
 	}
 
-	newUpvotes := comment.Upvotes - 1
-	if newUpvotes < 0 {
-		newUpvotes = 0
-	}
-	update := &domain.Comment{Upvotes: newUpvotes}
-
-	if err := s.repo.Update(id, update); err != nil {
+	if err := s.repo.ApplyVote(id, actor.ID, voteType); err != nil {
 		return nil, err
 	}
 
@@ -223,5 +260,5 @@ func (s *commentService) DecrementUpvotes(id uint) (*domain.CommentResponseDTO,
 		return nil, err
 	}
 
-	return toResponseDTO(updated), nil
+	return s.respond(updated)
 }
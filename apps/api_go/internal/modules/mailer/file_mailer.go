@@ -0,0 +1,44 @@
+package mailer
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"api_go/internal/domain"
+)
+
+type fileMailer struct {
+	dir  string
+	from string
+}
+
+// NewFileMailer creates a Mailer that writes each message as an .eml file (local development)
+func NewFileMailer(dir, from string) domain.Mailer {
+	return &fileMailer{dir: dir, from: from}
+}
+
+// Send writes the message to <dir>/<timestamp>-<recipient>.eml
+func (m *fileMailer) Send(msg domain.MailMessage) error {
+	body, err := buildMessage(m.from, msg)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(m.dir, 0o755); err != nil {
+		return err
+	}
+
+	recipient := strings.NewReplacer("@", "_at_", "/", "_", "\\", "_").Replace(msg.To)
+	name := fmt.Sprintf("%s-%s.eml", time.Now().Format("20060102-150405.000000000"), recipient)
+	path := filepath.Join(m.dir, name)
+	if err := os.WriteFile(path, body, 0o600); err != nil {
+		return err
+	}
+
+	log.Printf("mail to %s written to %s", msg.To, path)
+	return nil
+}
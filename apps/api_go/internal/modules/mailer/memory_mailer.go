@@ -0,0 +1,33 @@
+package mailer
+
+import (
+	"sync"
+
+	"api_go/internal/domain"
+)
+
+// MemoryMailer keeps sent messages in memory (tests)
+type MemoryMailer struct {
+	mu       sync.Mutex
+	messages []domain.MailMessage
+}
+
+// NewMemoryMailer creates an empty MemoryMailer
+func NewMemoryMailer() *MemoryMailer {
+	return &MemoryMailer{}
+}
+
+// Send records the message
+func (m *MemoryMailer) Send(msg domain.MailMessage) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.messages = append(m.messages, msg)
+	return nil
+}
+
+// Messages returns a copy of the recorded messages
+func (m *MemoryMailer) Messages() []domain.MailMessage {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]domain.MailMessage(nil), m.messages...)
+}
@@ -0,0 +1,92 @@
+package mailer
+
+import (
+	"crypto/tls"
+	"errors"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+
+	"api_go/internal/config"
+	"api_go/internal/domain"
+)
+
+type smtpMailer struct {
+	host     string
+	port     int
+	username string
+	password string
+	from     string
+}
+
+// NewSMTPMailer creates a Mailer that sends through an SMTP server.
+// Port 465 uses implicit TLS; other ports upgrade with STARTTLS when the server offers it.
+func NewSMTPMailer(cfg *config.Config) domain.Mailer {
+	return &smtpMailer{
+		host:     cfg.SMTPHost,
+		port:     cfg.SMTPPort,
+		username: cfg.SMTPUser,
+		password: cfg.SMTPPassword,
+		from:     cfg.MailFrom,
+	}
+}
+
+// Send delivers a message to a single recipient
+func (m *smtpMailer) Send(msg domain.MailMessage) error {
+	if m.host == "" {
+		return errors.New("SMTP host not configured")
+	}
+	if strings.ContainsAny(msg.To, "\r\n") {
+		return errors.New("invalid recipient")
+	}
+
+	body, err := buildMessage(m.from, msg)
+	if err != nil {
+		return err
+	}
+
+	addr := net.JoinHostPort(m.host, strconv.Itoa(m.port))
+	var auth smtp.Auth
+	if m.username != "" {
+		auth = smtp.PlainAuth("", m.username, m.password, m.host)
+	}
+
+	if m.port != 465 {
+		return smtp.SendMail(addr, auth, m.from, []string{msg.To}, body)
+	}
+
+	conn, err := tls.Dial("tcp", addr, &tls.Config{ServerName: m.host})
+	if err != nil {
+		return err
+	}
+	client, err := smtp.NewClient(conn, m.host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if auth != nil {
+		if err := client.Auth(auth); err != nil {
+			return err
+		}
+	}
+	if err := client.Mail(m.from); err != nil {
+		return err
+	}
+	if err := client.Rcpt(msg.To); err != nil {
+		return err
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(body); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
@@ -0,0 +1,157 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"api_go/internal/config"
+	"api_go/internal/domain"
+	"api_go/internal/modules/auth/middleware"
+)
+
+// multipartOverhead leaves room for form boundaries and headers around the file
+const multipartOverhead = 64 << 10
+
+type MediaController struct {
+	avatarService  domain.AvatarService
+	blobs          domain.BlobStore
+	avatarMaxBytes int64
+}
+
+// NewMediaController creates a new MediaController instance
+func NewMediaController(cfg *config.Config, avatarService domain.AvatarService, blobs domain.BlobStore) *MediaController {
+	return &MediaController{avatarService: avatarService, blobs: blobs, avatarMaxBytes: cfg.AvatarMaxBytes}
+}
+
+// RegisterRoutes registers avatar uploads and the public file route
+func (ctrl *MediaController) RegisterRoutes(r *gin.RouterGroup, policy *middleware.RoutePolicy) {
+	avatar := r.Group("/me/avatar", policy.Authenticated())
+	{
+		avatar.POST("", ctrl.UploadAvatar)
+		avatar.DELETE("", ctrl.RemoveAvatar)
+	}
+
+	r.GET("/media/*key", ctrl.Serve)
+}
+
+// UploadAvatar handles POST /me/avatar
+// @Summary Upload my avatar
+// @Description Accepts a JPEG, PNG, GIF or WebP image (multipart field "avatar"), crops it to a square and stores 64, 128 and 256 px versions
+// @Tags accounts
+// @Accept multipart/form-data
+// @Produce json
+// @Param avatar formData file true "Image file"
+// @Success 200 {object} domain.AvatarResponseDTO
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 413 {object} map[string]string
+// @Failure 415 {object} map[string]string
+// @Security BearerAuth
+// @Router /me/avatar [post]
+func (ctrl *MediaController) UploadAvatar(c *gin.Context) {
+	actor, ok := middleware.GetActor(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, ctrl.avatarMaxBytes+multipartOverhead)
+	header, err := c.FormFile("avatar")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "file too large"})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "avatar file is required"})
+		return
+	}
+	if header.Size > ctrl.avatarMaxBytes {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "file too large"})
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "avatar file is required"})
+		return
+	}
+	defer file.Close()
+
+	result, err := ctrl.avatarService.Upload(actor.ID, file)
+	if err != nil {
+		switch err.Error() {
+		case "file too large":
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+		case "unsupported image type":
+			c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": err.Error()})
+		case "invalid image", "image dimensions too large":
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case "user not found":
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// RemoveAvatar handles DELETE /me/avatar
+// @Summary Remove my avatar
+// @Tags accounts
+// @Success 204
+// @Failure 401 {object} map[string]string
+// @Security BearerAuth
+// @Router /me/avatar [delete]
+func (ctrl *MediaController) RemoveAvatar(c *gin.Context) {
+	actor, ok := middleware.GetActor(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	if err := ctrl.avatarService.Remove(actor.ID); err != nil {
+		if err.Error() == "user not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Serve handles GET /media/*key
+// @Summary Get an uploaded file
+// @Description Stored keys are never overwritten, so responses are cacheable forever
+// @Tags media
+// @Produce octet-stream
+// @Param key path string true "File key"
+// @Success 200 {file} binary
+// @Failure 404 {object} map[string]string
+// @Router /media/{key} [get]
+func (ctrl *MediaController) Serve(c *gin.Context) {
+	key := strings.TrimPrefix(c.Param("key"), "/")
+
+	blob, err := ctrl.blobs.Open(key)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if blob == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "file not found"})
+		return
+	}
+	defer blob.Body.Close()
+
+	c.DataFromReader(http.StatusOK, blob.Size, blob.ContentType, blob.Body, map[string]string{
+		"Cache-Control":          "public, max-age=31536000, immutable",
+		"X-Content-Type-Options": "nosniff",
+	})
+}
@@ -0,0 +1,204 @@
+package service
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif" // register decoders for image.Decode
+	"image/jpeg"
+	_ "image/png"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+
+	"api_go/internal/config"
+	"api_go/internal/domain"
+)
+
+// avatarSizes are the square sizes (pixels) stored for each upload; the last one becomes Account.AvatarURL
+var avatarSizes = []int{64, 128, 256}
+
+// allowedAvatarTypes are the sniffed content types accepted for upload
+var allowedAvatarTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+	"image/webp": true,
+}
+
+const (
+	// maxAvatarPixels guards against decompression bombs (a small file declaring a huge canvas)
+	maxAvatarPixels = 25_000_000
+	avatarQuality   = 85
+)
+
+type avatarService struct {
+	accountRepo domain.AccountRepository
+	blobs       domain.BlobStore
+	maxBytes    int64
+	publicURL   string
+}
+
+// NewAvatarService creates a new AvatarService instance
+func NewAvatarService(cfg *config.Config, accountRepo domain.AccountRepository, blobs domain.BlobStore) domain.AvatarService {
+	publicURL := cfg.MediaPublicURL
+	if publicURL == "" {
+		publicURL = cfg.APIBaseURL + "/media"
+	}
+	return &avatarService{
+		accountRepo: accountRepo,
+		blobs:       blobs,
+		maxBytes:    cfg.AvatarMaxBytes,
+		publicURL:   strings.TrimRight(publicURL, "/"),
+	}
+}
+
+// Upload stores the image as square JPEGs under a new random key, so each URL can be cached forever
+func (s *avatarService) Upload(accountID uint, file io.Reader) (*domain.AvatarResponseDTO, error) {
+	account, err := s.accountRepo.FindOne(accountID)
+	if err != nil {
+		return nil, err
+	}
+	if account == nil {
+		return nil, errors.New("user not found")
+	}
+
+	data, err := io.ReadAll(io.LimitReader(file, s.maxBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > s.maxBytes {
+		return nil, errors.New("file too large")
+	}
+	if !allowedAvatarTypes[http.DetectContentType(data)] && !isWebP(data) {
+		return nil, errors.New("unsupported image type")
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.New("invalid image")
+	}
+	if cfg.Width*cfg.Height > maxAvatarPixels {
+		return nil, errors.New("image dimensions too large")
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.New("invalid image")
+	}
+	square := cropSquare(img)
+
+	token := make([]byte, 8)
+	if _, err := rand.Read(token); err != nil {
+		return nil, err
+	}
+	prefix := fmt.Sprintf("avatars/%d/%s", accountID, hex.EncodeToString(token))
+
+	result := &domain.AvatarResponseDTO{Sizes: make(map[string]string, len(avatarSizes))}
+	for _, size := range avatarSizes {
+		encoded, err := encodeAvatar(square, size)
+		if err != nil {
+			return nil, err
+		}
+		key := avatarKey(prefix, size)
+		if err := s.blobs.Put(key, encoded, "image/jpeg"); err != nil {
+			return nil, err
+		}
+		result.Sizes[strconv.Itoa(size)] = s.publicURL + "/" + key
+		result.AvatarURL = s.publicURL + "/" + key
+	}
+
+	previousKey := account.AvatarKey
+	if err := s.accountRepo.SetAvatar(accountID, &result.AvatarURL, prefix); err != nil {
+		s.deleteFiles(prefix)
+		return nil, err
+	}
+	if previousKey != "" {
+		s.deleteFiles(previousKey)
+	}
+	return result, nil
+}
+
+// Remove clears the avatar (uploaded or external)
+func (s *avatarService) Remove(accountID uint) error {
+	return s.SetURL(accountID, nil)
+}
+
+// SetURL replaces the avatar with an external URL; an upload it replaces is deleted
+func (s *avatarService) SetURL(accountID uint, avatarURL *string) error {
+	account, err := s.accountRepo.FindOne(accountID)
+	if err != nil {
+		return err
+	}
+	if account == nil {
+		return errors.New("user not found")
+	}
+
+	previousKey := account.AvatarKey
+	if err := s.accountRepo.SetAvatar(accountID, avatarURL, ""); err != nil {
+		return err
+	}
+	if previousKey != "" {
+		s.deleteFiles(previousKey)
+	}
+	return nil
+}
+
+// DeleteUpload removes the files of a replaced upload
+func (s *avatarService) DeleteUpload(avatarKey string) {
+	if avatarKey != "" {
+		s.deleteFiles(avatarKey)
+	}
+}
+
+// deleteFiles removes every size of an upload; failures only leave unreferenced files behind
+func (s *avatarService) deleteFiles(prefix string) {
+	for _, size := range avatarSizes {
+		if err := s.blobs.Delete(avatarKey(prefix, size)); err != nil {
+			log.Printf("failed to delete avatar %s: %v", avatarKey(prefix, size), err)
+		}
+	}
+}
+
+func avatarKey(prefix string, size int) string {
+	return fmt.Sprintf("%s/%d.jpg", prefix, size)
+}
+
+// isWebP checks the RIFF....WEBP header (http.DetectContentType does not know WebP)
+func isWebP(data []byte) bool {
+	return len(data) >= 12 && string(data[0:4]) == "RIFF" && string(data[8:12]) == "WEBP"
+}
+
+// cropSquare keeps the centered square of the image
+func cropSquare(img image.Image) image.Image {
+	b := img.Bounds()
+	side := min(b.Dx(), b.Dy())
+	x := b.Min.X + (b.Dx()-side)/2
+	y := b.Min.Y + (b.Dy()-side)/2
+	rect := image.Rect(x, y, x+side, y+side)
+
+	square := image.NewRGBA(image.Rect(0, 0, side, side))
+	draw.Draw(square, square.Bounds(), img, rect.Min, draw.Src)
+	return square
+}
+
+// encodeAvatar resizes the square image and encodes it as JPEG on a white background (JPEG has no transparency)
+func encodeAvatar(square image.Image, size int) ([]byte, error) {
+	dst := image.NewRGBA(image.Rect(0, 0, size, size))
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.CatmullRom.Scale(dst, dst.Bounds(), square, square.Bounds(), draw.Over, nil)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, dst, &jpeg.Options{Quality: avatarQuality}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
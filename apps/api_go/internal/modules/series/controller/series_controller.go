@@ -0,0 +1,336 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"api_go/internal/domain"
+	"api_go/internal/modules/auth/middleware"
+)
+
+type SeriesController struct {
+	service domain.SeriesService
+}
+
+// NewSeriesController creates a new SeriesController instance
+func NewSeriesController(service domain.SeriesService) *SeriesController {
+	return &SeriesController{service: service}
+}
+
+// RegisterRoutes registers all series routes
+func (ctrl *SeriesController) RegisterRoutes(r *gin.RouterGroup, policy *middleware.RoutePolicy) {
+	series := r.Group("/series")
+	{
+		series.POST("", policy.Authenticated(domain.ScopeTutorialsWrite), ctrl.Create)
+		series.GET("", policy.Public(), ctrl.FindAll)
+		series.GET("/:id", policy.Public(), ctrl.FindOne)
+		series.PATCH("/:id", policy.Authenticated(domain.ScopeTutorialsWrite), ctrl.Update)
+		series.DELETE("/:id", policy.Authenticated(domain.ScopeTutorialsWrite), ctrl.Remove)
+		series.POST("/:id/items", policy.Authenticated(domain.ScopeTutorialsWrite), ctrl.AddItem)
+		series.PUT("/:id/items/order", policy.Authenticated(domain.ScopeTutorialsWrite), ctrl.Reorder)
+		series.DELETE("/:id/items/:itemId", policy.Authenticated(domain.ScopeTutorialsWrite), ctrl.RemoveItem)
+		series.PUT("/:id/items/:itemId/progress", policy.Authenticated(), ctrl.CompleteItem)
+		series.DELETE("/:id/items/:itemId/progress", policy.Authenticated(), ctrl.UncompleteItem)
+	}
+}
+
+// Create handles POST /series
+// @Summary Create a series
+// @Description Create an empty series; tutorials and videos are added with POST /series/{id}/items
+// @Tags series
+// @Accept json
+// @Produce json
+// @Param dto body domain.CreateSeriesDTO true "Create Series DTO"
+// @Success 201 {object} domain.SeriesDetailDTO
+// @Failure 400 {object} map[string]string
+// @Security BearerAuth
+// @Router /series [post]
+func (ctrl *SeriesController) Create(c *gin.Context) {
+	var dto domain.CreateSeriesDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	actor, ok := middleware.GetActor(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	series, err := ctrl.service.Create(dto, actor.ID)
+	if err != nil {
+		ctrl.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, series)
+}
+
+// FindAll handles GET /series
+// @Summary Get all series
+// @Description Retrieve every series, newest first; itemCount only counts the items the caller can see
+// @Tags series
+// @Produce json
+// @Success 200 {array} domain.SeriesListItemDTO
+// @Router /series [get]
+func (ctrl *SeriesController) FindAll(c *gin.Context) {
+	actor, _ := middleware.GetActor(c)
+	series, err := ctrl.service.FindAll(actor)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, series)
+}
+
+// FindOne handles GET /series/:id
+// @Summary Get a series by ID
+// @Description Retrieve a series with its items in order (unpublished tutorials only for their author and moderators); logged-in readers also get their progress
+// @Tags series
+// @Produce json
+// @Param id path int true "Series ID"
+// @Success 200 {object} domain.SeriesDetailDTO
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /series/{id} [get]
+func (ctrl *SeriesController) FindOne(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	actor, _ := middleware.GetActor(c)
+	series, err := ctrl.service.FindOne(uint(id), actor)
+	if err != nil {
+		ctrl.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, series)
+}
+
+// Update handles PATCH /series/:id
+// @Summary Update a series
+// @Description Update the title or description of a series (owner or moderator)
+// @Tags series
+// @Accept json
+// @Produce json
+// @Param id path int true "Series ID"
+// @Param dto body domain.UpdateSeriesDTO true "Update Series DTO"
+// @Success 200 {object} domain.SeriesDetailDTO
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Security BearerAuth
+// @Router /series/{id} [patch]
+func (ctrl *SeriesController) Update(c *gin.Context) {
+	var dto domain.UpdateSeriesDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctrl.runAsActor(c, func(id uint, actor domain.Actor) (interface{}, error) {
+		return ctrl.service.Update(id, dto, actor)
+	})
+}
+
+// Remove handles DELETE /series/:id
+// @Summary Delete a series
+// @Description Delete a series (owner or moderator); its tutorials and videos are kept
+// @Tags series
+// @Param id path int true "Series ID"
+// @Success 200 {object} map[string]bool
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Security BearerAuth
+// @Router /series/{id} [delete]
+func (ctrl *SeriesController) Remove(c *gin.Context) {
+	ctrl.runAsActor(c, func(id uint, actor domain.Actor) (interface{}, error) {
+		if err := ctrl.service.Remove(id, actor); err != nil {
+			return nil, err
+		}
+		return gin.H{"deleted": true}, nil
+	})
+}
+
+// AddItem handles POST /series/:id/items
+// @Summary Add an item to a series
+// @Description Append a tutorial or a video (owner or moderator). A tutorial can belong to one series only and must be the caller's own.
+// @Tags series
+// @Accept json
+// @Produce json
+// @Param id path int true "Series ID"
+// @Param dto body domain.AddSeriesItemDTO true "Tutorial or video to add"
+// @Success 201 {object} domain.SeriesDetailDTO
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Security BearerAuth
+// @Router /series/{id}/items [post]
+func (ctrl *SeriesController) AddItem(c *gin.Context) {
+	var dto domain.AddSeriesItemDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	actor, ok := middleware.GetActor(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	series, err := ctrl.service.AddItem(uint(id), dto, actor)
+	if err != nil {
+		ctrl.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, series)
+}
+
+// Reorder handles PUT /series/:id/items/order
+// @Summary Reorder the items of a series
+// @Description Set the order of the items (owner or moderator); itemIds must list every item once
+// @Tags series
+// @Accept json
+// @Produce json
+// @Param id path int true "Series ID"
+// @Param dto body domain.ReorderSeriesItemsDTO true "Item IDs in the new order"
+// @Success 200 {object} domain.SeriesDetailDTO
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Security BearerAuth
+// @Router /series/{id}/items/order [put]
+func (ctrl *SeriesController) Reorder(c *gin.Context) {
+	var dto domain.ReorderSeriesItemsDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctrl.runAsActor(c, func(id uint, actor domain.Actor) (interface{}, error) {
+		return ctrl.service.Reorder(id, dto, actor)
+	})
+}
+
+// RemoveItem handles DELETE /series/:id/items/:itemId
+// @Summary Remove an item from a series
+// @Description Take a tutorial or video out of a series (owner or moderator); the following items move up
+// @Tags series
+// @Produce json
+// @Param id path int true "Series ID"
+// @Param itemId path int true "Series item ID"
+// @Success 200 {object} domain.SeriesDetailDTO
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Security BearerAuth
+// @Router /series/{id}/items/{itemId} [delete]
+func (ctrl *SeriesController) RemoveItem(c *gin.Context) {
+	ctrl.runOnItem(c, func(id, itemID uint, actor domain.Actor) (interface{}, error) {
+		return ctrl.service.RemoveItem(id, itemID, actor)
+	})
+}
+
+// CompleteItem handles PUT /series/:id/items/:itemId/progress
+// @Summary Mark a series item as completed
+// @Description Record that the caller has completed the item and return their progress through the series
+// @Tags series
+// @Produce json
+// @Param id path int true "Series ID"
+// @Param itemId path int true "Series item ID"
+// @Success 200 {object} domain.SeriesProgressDTO
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Security BearerAuth
+// @Router /series/{id}/items/{itemId}/progress [put]
+func (ctrl *SeriesController) CompleteItem(c *gin.Context) {
+	ctrl.runOnItem(c, func(id, itemID uint, actor domain.Actor) (interface{}, error) {
+		return ctrl.service.CompleteItem(id, itemID, actor)
+	})
+}
+
+// UncompleteItem handles DELETE /series/:id/items/:itemId/progress
+// @Summary Unmark a completed series item
+// @Description Remove the caller's completion mark and return their progress through the series
+// @Tags series
+// @Produce json
+// @Param id path int true "Series ID"
+// @Param itemId path int true "Series item ID"
+// @Success 200 {object} domain.SeriesProgressDTO
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Security BearerAuth
+// @Router /series/{id}/items/{itemId}/progress [delete]
+func (ctrl *SeriesController) UncompleteItem(c *gin.Context) {
+	ctrl.runOnItem(c, func(id, itemID uint, actor domain.Actor) (interface{}, error) {
+		return ctrl.service.UncompleteItem(id, itemID, actor)
+	})
+}
+
+// runAsActor parses the series ID, runs the action as the caller and responds with its result
+func (ctrl *SeriesController) runAsActor(c *gin.Context, action func(id uint, actor domain.Actor) (interface{}, error)) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	actor, ok := middleware.GetActor(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	result, err := action(uint(id), actor)
+	if err != nil {
+		ctrl.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// runOnItem is runAsActor for the routes that also take an item ID
+func (ctrl *SeriesController) runOnItem(c *gin.Context, action func(id, itemID uint, actor domain.Actor) (interface{}, error)) {
+	itemID, err := strconv.ParseUint(c.Param("itemId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid item id"})
+		return
+	}
+
+	ctrl.runAsActor(c, func(id uint, actor domain.Actor) (interface{}, error) {
+		return action(id, uint(itemID), actor)
+	})
+}
+
+func (ctrl *SeriesController) respondError(c *gin.Context, err error) {
+	switch err.Error() {
+	case "series not found", "item not found", "tutorial not found", "video not found":
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case "forbidden":
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case "tutorial already belongs to a series", "video already in series":
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case "title cannot be empty", "exactly one of tutorialId and videoId is required", "itemIds must list every item of the series once":
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,178 @@
+package repo
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"api_go/internal/domain"
+)
+
+type seriesRepository struct {
+	db *gorm.DB
+}
+
+// NewSeriesRepository creates a new SeriesRepository instance
+func NewSeriesRepository(db *gorm.DB) domain.SeriesRepository {
+	return &seriesRepository{db: db}
+}
+
+// Create inserts a new series
+func (r *seriesRepository) Create(series *domain.Series) error {
+	return r.db.Create(series).Error
+}
+
+// FindAll retrieves every series with its author and items, newest first
+func (r *seriesRepository) FindAll() ([]domain.Series, error) {
+	var series []domain.Series
+	err := r.db.Preload("Author").
+		Preload("Items", func(db *gorm.DB) *gorm.DB { return db.Select("id", "series_id", "tutorial_id", "video_id") }).
+		Preload("Items.Tutorial", func(db *gorm.DB) *gorm.DB { return db.Select("id", "status", "author_id") }).
+		Preload("Items.Video", func(db *gorm.DB) *gorm.DB { return db.Select("id") }).
+		Order("created_at DESC").
+		Find(&series).Error
+	return series, err
+}
+
+// FindOne retrieves a series by ID
+func (r *seriesRepository) FindOne(id uint) (*domain.Series, error) {
+	var series domain.Series
+	err := r.db.First(&series, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &series, nil
+}
+
+// FindOneWithItems retrieves a series with its author and ordered items
+func (r *seriesRepository) FindOneWithItems(id uint) (*domain.Series, error) {
+	var series domain.Series
+	err := r.db.Preload("Author").
+		Preload("Items", func(db *gorm.DB) *gorm.DB { return db.Order("position") }).
+		Preload("Items.Tutorial", func(db *gorm.DB) *gorm.DB { return db.Omit("content") }).
+		Preload("Items.Video").
+		First(&series, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &series, nil
+}
+
+// Update updates the title and description of a series
+func (r *seriesRepository) Update(id uint, title, description string) error {
+	return r.db.Model(&domain.Series{}).Where("id = ?", id).
+		Updates(map[string]interface{}{"title": title, "description": description}).Error
+}
+
+// Delete soft-deletes the series and removes its items (which frees the tutorials for another series)
+func (r *seriesRepository) Delete(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("series_id = ?", id).Delete(&domain.SeriesItem{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&domain.Series{}, id).Error
+	})
+}
+
+// AddItem appends the item; the series row is locked so concurrent appends get distinct positions
+func (r *seriesRepository) AddItem(item *domain.SeriesItem) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var series domain.Series
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&series, item.SeriesID).Error; err != nil {
+			return err
+		}
+
+		var last int
+		if err := tx.Model(&domain.SeriesItem{}).
+			Where("series_id = ?", item.SeriesID).
+			Select("COALESCE(MAX(position), 0)").
+			Scan(&last).Error; err != nil {
+			return err
+		}
+		item.Position = last + 1
+		return tx.Create(item).Error
+	})
+}
+
+// RemoveItem deletes the item and moves the following items up
+func (r *seriesRepository) RemoveItem(seriesID, itemID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var series domain.Series
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&series, seriesID).Error; err != nil {
+			return err
+		}
+
+		var item domain.SeriesItem
+		err := tx.Where("id = ? AND series_id = ?", itemID, seriesID).First(&item).Error
+		if err != nil {
+			return err
+		}
+		if err := tx.Delete(&item).Error; err != nil {
+			return err
+		}
+		return tx.Model(&domain.SeriesItem{}).
+			Where("series_id = ? AND position > ?", seriesID, item.Position).
+			Update("position", gorm.Expr("position - 1")).Error
+	})
+}
+
+// Reorder writes positions 1..n following the order of itemIDs
+func (r *seriesRepository) Reorder(seriesID uint, itemIDs []uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var series domain.Series
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&series, seriesID).Error; err != nil {
+			return err
+		}
+
+		for i, itemID := range itemIDs {
+			if err := tx.Model(&domain.SeriesItem{}).
+				Where("id = ? AND series_id = ?", itemID, seriesID).
+				Update("position", i+1).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// FindItemByTutorial retrieves the series item holding a tutorial
+func (r *seriesRepository) FindItemByTutorial(tutorialID uint) (*domain.SeriesItem, error) {
+	var item domain.SeriesItem
+	err := r.db.Where("tutorial_id = ?", tutorialID).First(&item).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// CompleteItem marks an item as completed by the account (marking it twice keeps the first time)
+func (r *seriesRepository) CompleteItem(accountID, itemID uint) error {
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&domain.SeriesProgress{AccountID: accountID, SeriesItemID: itemID}).Error
+}
+
+// UncompleteItem removes the completion mark
+func (r *seriesRepository) UncompleteItem(accountID, itemID uint) error {
+	return r.db.Where("account_id = ? AND series_item_id = ?", accountID, itemID).
+		Delete(&domain.SeriesProgress{}).Error
+}
+
+// FindCompletedItemIDs retrieves the IDs of the series items the account has completed
+func (r *seriesRepository) FindCompletedItemIDs(accountID, seriesID uint) ([]uint, error) {
+	var ids []uint
+	err := r.db.Model(&domain.SeriesProgress{}).
+		Joins("JOIN series_items si ON si.id = series_progress.series_item_id").
+		Where("series_progress.account_id = ? AND si.series_id = ?", accountID, seriesID).
+		Pluck("series_progress.series_item_id", &ids).Error
+	return ids, err
+}
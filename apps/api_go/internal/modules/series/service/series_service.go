@@ -0,0 +1,393 @@
+package service
+
+import (
+	"errors"
+	"strings"
+
+	"api_go/internal/domain"
+)
+
+const defaultAuthorName = "Anonymous"
+
+type seriesService struct {
+	repo         domain.SeriesRepository
+	tutorialRepo domain.TutorialRepository
+	videoRepo    domain.VideoRepository
+}
+
+// NewSeriesService creates a new SeriesService instance
+func NewSeriesService(
+	repo domain.SeriesRepository,
+	tutorialRepo domain.TutorialRepository,
+	videoRepo domain.VideoRepository,
+) domain.SeriesService {
+	return &seriesService{repo: repo, tutorialRepo: tutorialRepo, videoRepo: videoRepo}
+}
+
+// toItemDTO converts a SeriesItem with its tutorial or video preloaded
+func toItemDTO(item *domain.SeriesItem) domain.SeriesItemDTO {
+	dto := domain.SeriesItemDTO{
+		ID:         item.ID,
+		Position:   item.Position,
+		TutorialID: item.TutorialID,
+		VideoID:    item.VideoID,
+	}
+	switch {
+	case item.Tutorial != nil:
+		dto.Type = domain.SeriesItemTutorial
+		dto.Title = item.Tutorial.Title
+		dto.Slug = item.Tutorial.Slug
+		dto.Status = item.Tutorial.Status
+	case item.Video != nil:
+		dto.Type = domain.SeriesItemVideo
+		dto.Title = item.Video.Title
+		dto.YoutubeID = item.Video.YoutubeID
+	}
+	return dto
+}
+
+// toListItemDTO converts Series entity to SeriesListItemDTO, counting the items the actor can see
+func toListItemDTO(s *domain.Series, actor domain.Actor) domain.SeriesListItemDTO {
+	authorName, authorHandle := defaultAuthorName, ""
+	if s.Author != nil {
+		authorName = s.Author.Name
+		authorHandle = s.Author.Handle
+	}
+	itemCount := 0
+	for i := range s.Items {
+		if s.Items[i].VisibleTo(actor) {
+			itemCount++
+		}
+	}
+	return domain.SeriesListItemDTO{
+		ID:           s.ID,
+		Title:        s.Title,
+		Description:  s.Description,
+		ItemCount:    itemCount,
+		AuthorID:     s.AuthorID,
+		AuthorName:   authorName,
+		AuthorHandle: authorHandle,
+		CreatedAt:    s.CreatedAt,
+		UpdatedAt:    s.UpdatedAt,
+	}
+}
+
+// Create creates a new, empty series
+func (s *seriesService) Create(dto domain.CreateSeriesDTO, authorID uint) (*domain.SeriesDetailDTO, error) {
+	series := &domain.Series{
+		Title:       strings.TrimSpace(dto.Title),
+		Description: strings.TrimSpace(dto.Description),
+		AuthorID:    authorID,
+	}
+	if series.Title == "" {
+		return nil, errors.New("title cannot be empty")
+	}
+	if err := s.repo.Create(series); err != nil {
+		return nil, err
+	}
+	return s.FindOne(series.ID, domain.Actor{ID: authorID})
+}
+
+// FindAll retrieves every series
+func (s *seriesService) FindAll(actor domain.Actor) ([]domain.SeriesListItemDTO, error) {
+	series, err := s.repo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]domain.SeriesListItemDTO, len(series))
+	for i := range series {
+		result[i] = toListItemDTO(&series[i], actor)
+	}
+	return result, nil
+}
+
+// FindOne retrieves a series with its items and the actor's progress
+func (s *seriesService) FindOne(id uint, actor domain.Actor) (*domain.SeriesDetailDTO, error) {
+	series, err := s.repo.FindOneWithItems(id)
+	if err != nil {
+		return nil, err
+	}
+	if series == nil {
+		return nil, errors.New("series not found")
+	}
+
+	authorName, authorHandle := defaultAuthorName, ""
+	if series.Author != nil {
+		authorName = series.Author.Name
+		authorHandle = series.Author.Handle
+	}
+	dto := &domain.SeriesDetailDTO{
+		ID:           series.ID,
+		Title:        series.Title,
+		Description:  series.Description,
+		AuthorID:     series.AuthorID,
+		AuthorName:   authorName,
+		AuthorHandle: authorHandle,
+		CreatedAt:    series.CreatedAt,
+		UpdatedAt:    series.UpdatedAt,
+		Items:        []domain.SeriesItemDTO{},
+	}
+	for i := range series.Items {
+		if series.Items[i].VisibleTo(actor) {
+			dto.Items = append(dto.Items, toItemDTO(&series.Items[i]))
+		}
+	}
+
+	if actor.ID != 0 {
+		progress, err := s.progress(series.ID, dto.Items, actor.ID)
+		if err != nil {
+			return nil, err
+		}
+		for i := range dto.Items {
+			dto.Items[i].Completed = containsID(progress.CompletedItemIDs, dto.Items[i].ID)
+		}
+		dto.Progress = progress
+	}
+	return dto, nil
+}
+
+// Update updates the title or description of a series
+func (s *seriesService) Update(id uint, dto domain.UpdateSeriesDTO, actor domain.Actor) (*domain.SeriesDetailDTO, error) {
+	series, err := s.findModifiable(id, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	title, description := series.Title, series.Description
+	if dto.Title != nil {
+		title = strings.TrimSpace(*dto.Title)
+		if title == "" {
+			return nil, errors.New("title cannot be empty")
+		}
+	}
+	if dto.Description != nil {
+		description = strings.TrimSpace(*dto.Description)
+	}
+	if err := s.repo.Update(id, title, description); err != nil {
+		return nil, err
+	}
+	return s.FindOne(id, actor)
+}
+
+// Remove deletes a series; its tutorials and videos are kept
+func (s *seriesService) Remove(id uint, actor domain.Actor) error {
+	if _, err := s.findModifiable(id, actor); err != nil {
+		return err
+	}
+	return s.repo.Delete(id)
+}
+
+// AddItem appends a tutorial or a video to a series
+func (s *seriesService) AddItem(id uint, dto domain.AddSeriesItemDTO, actor domain.Actor) (*domain.SeriesDetailDTO, error) {
+	if (dto.TutorialID == nil) == (dto.VideoID == nil) {
+		return nil, errors.New("exactly one of tutorialId and videoId is required")
+	}
+	series, err := s.findModifiableWithItems(id, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	item := &domain.SeriesItem{SeriesID: series.ID}
+	if dto.TutorialID != nil {
+		tutorial, err := s.tutorialRepo.FindOne(*dto.TutorialID)
+		if err != nil {
+			return nil, err
+		}
+		if tutorial == nil || !tutorial.VisibleTo(actor) {
+			return nil, errors.New("tutorial not found")
+		}
+		if !actor.CanModify(tutorial.AuthorID) {
+			return nil, errors.New("forbidden")
+		}
+		existing, err := s.repo.FindItemByTutorial(tutorial.ID)
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil {
+			return nil, errors.New("tutorial already belongs to a series")
+		}
+		item.TutorialID = &tutorial.ID
+	} else {
+		video, err := s.videoRepo.FindOne(*dto.VideoID)
+		if err != nil {
+			return nil, err
+		}
+		if video == nil {
+			return nil, errors.New("video not found")
+		}
+		for _, existing := range series.Items {
+			if existing.VideoID != nil && *existing.VideoID == video.ID {
+				return nil, errors.New("video already in series")
+			}
+		}
+		item.VideoID = &video.ID
+	}
+
+	if err := s.repo.AddItem(item); err != nil {
+		return nil, err
+	}
+	return s.FindOne(id, actor)
+}
+
+// RemoveItem takes an item out of a series
+func (s *seriesService) RemoveItem(id, itemID uint, actor domain.Actor) (*domain.SeriesDetailDTO, error) {
+	series, err := s.findModifiableWithItems(id, actor)
+	if err != nil {
+		return nil, err
+	}
+	if findItem(series.Items, itemID) == nil {
+		return nil, errors.New("item not found")
+	}
+	if err := s.repo.RemoveItem(id, itemID); err != nil {
+		return nil, err
+	}
+	return s.FindOne(id, actor)
+}
+
+// Reorder sets the order of the items of a series
+func (s *seriesService) Reorder(id uint, dto domain.ReorderSeriesItemsDTO, actor domain.Actor) (*domain.SeriesDetailDTO, error) {
+	series, err := s.findModifiableWithItems(id, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	// itemIds must be a permutation of the items whose tutorial or video still exists;
+	// items left behind by deleted content keep their place at the end
+	var live, dangling []uint
+	for _, item := range series.Items {
+		if item.Tutorial != nil || item.Video != nil {
+			live = append(live, item.ID)
+		} else {
+			dangling = append(dangling, item.ID)
+		}
+	}
+	if len(dto.ItemIDs) != len(live) {
+		return nil, errors.New("itemIds must list every item of the series once")
+	}
+	seen := make(map[uint]bool, len(dto.ItemIDs))
+	for _, itemID := range dto.ItemIDs {
+		if seen[itemID] || !containsID(live, itemID) {
+			return nil, errors.New("itemIds must list every item of the series once")
+		}
+		seen[itemID] = true
+	}
+
+	order := append(append([]uint{}, dto.ItemIDs...), dangling...)
+	if err := s.repo.Reorder(id, order); err != nil {
+		return nil, err
+	}
+	return s.FindOne(id, actor)
+}
+
+// CompleteItem marks an item of the series as completed by the actor
+func (s *seriesService) CompleteItem(id, itemID uint, actor domain.Actor) (*domain.SeriesProgressDTO, error) {
+	items, err := s.visibleItems(id, itemID, actor)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.CompleteItem(actor.ID, itemID); err != nil {
+		return nil, err
+	}
+	return s.progress(id, items, actor.ID)
+}
+
+// UncompleteItem removes the completion mark of an item
+func (s *seriesService) UncompleteItem(id, itemID uint, actor domain.Actor) (*domain.SeriesProgressDTO, error) {
+	items, err := s.visibleItems(id, itemID, actor)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.UncompleteItem(actor.ID, itemID); err != nil {
+		return nil, err
+	}
+	return s.progress(id, items, actor.ID)
+}
+
+// visibleItems returns the items of the series the actor can see, checking itemID is one of them
+func (s *seriesService) visibleItems(id, itemID uint, actor domain.Actor) ([]domain.SeriesItemDTO, error) {
+	series, err := s.repo.FindOneWithItems(id)
+	if err != nil {
+		return nil, err
+	}
+	if series == nil {
+		return nil, errors.New("series not found")
+	}
+
+	var items []domain.SeriesItemDTO
+	found := false
+	for i := range series.Items {
+		if series.Items[i].VisibleTo(actor) {
+			items = append(items, toItemDTO(&series.Items[i]))
+			found = found || series.Items[i].ID == itemID
+		}
+	}
+	if !found {
+		return nil, errors.New("item not found")
+	}
+	return items, nil
+}
+
+// progress counts the completed items among items (completions of hidden items are not counted)
+func (s *seriesService) progress(id uint, items []domain.SeriesItemDTO, accountID uint) (*domain.SeriesProgressDTO, error) {
+	completedIDs, err := s.repo.FindCompletedItemIDs(accountID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	progress := &domain.SeriesProgressDTO{SeriesID: id, Total: len(items), CompletedItemIDs: []uint{}}
+	for _, item := range items {
+		if containsID(completedIDs, item.ID) {
+			progress.CompletedItemIDs = append(progress.CompletedItemIDs, item.ID)
+		}
+	}
+	progress.Completed = len(progress.CompletedItemIDs)
+	return progress, nil
+}
+
+// findModifiable loads a series and checks the actor is its author or a moderator
+func (s *seriesService) findModifiable(id uint, actor domain.Actor) (*domain.Series, error) {
+	series, err := s.repo.FindOne(id)
+	if err != nil {
+		return nil, err
+	}
+	if series == nil {
+		return nil, errors.New("series not found")
+	}
+	if !actor.CanModify(series.AuthorID) {
+		return nil, errors.New("forbidden")
+	}
+	return series, nil
+}
+
+// findModifiableWithItems is findModifiable with the items loaded
+func (s *seriesService) findModifiableWithItems(id uint, actor domain.Actor) (*domain.Series, error) {
+	series, err := s.repo.FindOneWithItems(id)
+	if err != nil {
+		return nil, err
+	}
+	if series == nil {
+		return nil, errors.New("series not found")
+	}
+	if !actor.CanModify(series.AuthorID) {
+		return nil, errors.New("forbidden")
+	}
+	return series, nil
+}
+
+func findItem(items []domain.SeriesItem, itemID uint) *domain.SeriesItem {
+	for i := range items {
+		if items[i].ID == itemID {
+			return &items[i]
+		}
+	}
+	return nil
+}
+
+func containsID(ids []uint, id uint) bool {
+	for _, candidate := range ids {
+		if candidate == id {
+			return true
+		}
+	}
+	return false
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"api_go/internal/domain"
+	"api_go/internal/modules/auth/middleware"
 )
 
 type TagController struct {
@@ -19,16 +20,16 @@ func NewTagController(service domain.TagService) *TagController {
 }
 
 // RegisterRoutes registers all tag routes
-func (ctrl *TagController) RegisterRoutes(r *gin.RouterGroup) {
+func (ctrl *TagController) RegisterRoutes(r *gin.RouterGroup, policy *middleware.RoutePolicy) {
 	tags := r.Group("/tags")
 	{
-		tags.POST("", ctrl.Create)
+		tags.POST("", policy.Authenticated(domain.ScopeTagsWrite), ctrl.Create)
 		tags.GET("", ctrl.FindAll)
 		tags.GET("/search", ctrl.Search)
 		tags.GET("/name/:name", ctrl.FindByName)
 		tags.GET("/:id", ctrl.FindOne)
-		tags.PATCH("/:id", ctrl.Update)
-		tags.DELETE("/:id", ctrl.Remove)
+		tags.PATCH("/:id", policy.Moderators(domain.ScopeTagsWrite), ctrl.Update)
+		tags.DELETE("/:id", policy.Moderators(domain.ScopeTagsWrite), ctrl.Remove)
 	}
 }
 
@@ -41,6 +42,7 @@ func (ctrl *TagController) RegisterRoutes(r *gin.RouterGroup) {
 // @Success 201 {object} domain.TagResponseDTO
 // @Failure 400 {object} map[string]string
 // @Failure 409 {object} map[string]string
+// @Security BearerAuth
 // @Router /tags [post]
 func (ctrl *TagController) Create(c *gin.Context) {
 	var dto domain.CreateTagDTO
@@ -170,6 +172,7 @@ func (ctrl *TagController) FindOne(c *gin.Context) {
 // @Failure 400 {object} map[string]string
 // @Failure 404 {object} map[string]string
 // @Failure 409 {object} map[string]string
+// @Security BearerAuth
 // @Router /tags/{id} [patch]
 func (ctrl *TagController) Update(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
@@ -207,6 +210,7 @@ func (ctrl *TagController) Update(c *gin.Context) {
 // @Success 200 {object} map[string]bool
 // @Failure 400 {object} map[string]string
 // @Failure 404 {object} map[string]string
+// @Security BearerAuth
 // @Router /tags/{id} [delete]
 func (ctrl *TagController) Remove(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
@@ -0,0 +1,70 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"api_go/internal/domain"
+)
+
+// fakeSlugService knows one tutorial under its current slug and the slugs it used before
+type fakeSlugService struct {
+	domain.TutorialService
+	current string
+	history map[string]string
+}
+
+func (s *fakeSlugService) FindBySlug(slug string, query domain.TutorialDetailQuery, actor domain.Actor) (*domain.TutorialDetailDTO, error) {
+	if slug != s.current {
+		return nil, errors.New("tutorial not found")
+	}
+	return &domain.TutorialDetailDTO{}, nil
+}
+
+func (s *fakeSlugService) ResolveSlug(slug string, actor domain.Actor) (string, error) {
+	current, ok := s.history[slug]
+	if !ok {
+		return "", errors.New("tutorial not found")
+	}
+	return current, nil
+}
+
+func newSlugTestRouter(service domain.TutorialService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/api/tutorials/slug/:slug", NewTutorialController(service).FindBySlug)
+	return r
+}
+
+func TestFindBySlugRedirectsOldSlugs(t *testing.T) {
+	r := newSlugTestRouter(&fakeSlugService{
+		current: "go generics",
+		history: map[string]string{"old-title": "go generics"},
+	})
+
+	tests := []struct {
+		name     string
+		path     string
+		status   int
+		location string
+	}{
+		{"current slug", "/api/tutorials/slug/go%20generics", http.StatusOK, ""},
+		{"old slug", "/api/tutorials/slug/old-title", http.StatusMovedPermanently, "/api/tutorials/slug/go%20generics"},
+		{"old slug keeps the query", "/api/tutorials/slug/old-title?format=html", http.StatusMovedPermanently, "/api/tutorials/slug/go%20generics?format=html"},
+		{"unknown slug", "/api/tutorials/slug/never-existed", http.StatusNotFound, ""},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+		if w.Code != tt.status {
+			t.Errorf("%s: status = %d, want %d (%s)", tt.name, w.Code, tt.status, w.Body)
+		}
+		if got := w.Header().Get("Location"); got != tt.location {
+			t.Errorf("%s: location = %q, want %q", tt.name, got, tt.location)
+		}
+	}
+}
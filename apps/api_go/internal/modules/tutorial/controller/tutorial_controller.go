@@ -1,12 +1,17 @@
 package controller
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 
 	"api_go/internal/domain"
+	"api_go/internal/modules/auth/middleware"
 )
 
 type TutorialController struct {
@@ -19,29 +24,40 @@ func NewTutorialController(service domain.TutorialService) *TutorialController {
 }
 
 // RegisterRoutes registers all tutorial routes
-func (ctrl *TutorialController) RegisterRoutes(r *gin.RouterGroup) {
+func (ctrl *TutorialController) RegisterRoutes(r *gin.RouterGroup, policy *middleware.RoutePolicy) {
 	tutorials := r.Group("/tutorials")
 	{
-		tutorials.POST("", ctrl.Create)
-		tutorials.GET("", ctrl.FindAll)
-		tutorials.GET("/slug/:slug", ctrl.FindBySlug)
-		tutorials.GET("/:id", ctrl.FindOne)
-		tutorials.PATCH("/:id", ctrl.Update)
-		tutorials.DELETE("/:id", ctrl.Remove)
+		tutorials.POST("", policy.Authenticated(domain.ScopeTutorialsWrite), ctrl.Create)
+		tutorials.GET("", policy.Public(), ctrl.FindAll)
+		tutorials.GET("/review", policy.Moderators(), ctrl.FindInReview)
+		tutorials.GET("/slug/:slug", policy.Public(), ctrl.FindBySlug)
+		tutorials.GET("/:id", policy.Public(), ctrl.FindOne)
+		tutorials.PATCH("/:id", policy.Authenticated(domain.ScopeTutorialsWrite), ctrl.Update)
+		tutorials.DELETE("/:id", policy.Authenticated(domain.ScopeTutorialsWrite), ctrl.Remove)
+		tutorials.POST("/:id/submit", policy.Authenticated(domain.ScopeTutorialsWrite), ctrl.Submit)
+		tutorials.POST("/:id/publish", policy.Moderators(domain.ScopeTutorialsWrite), ctrl.Publish)
+		tutorials.POST("/:id/unpublish", policy.Authenticated(domain.ScopeTutorialsWrite), ctrl.Unpublish)
+		tutorials.POST("/:id/archive", policy.Authenticated(domain.ScopeTutorialsWrite), ctrl.Archive)
+		tutorials.GET("/:id/views", policy.Authenticated(), ctrl.FindViewStats)
+		tutorials.GET("/:id/revisions", policy.Public(), ctrl.FindRevisions)
+		tutorials.GET("/:id/revisions/diff", policy.Public(), ctrl.DiffRevisions)
+		tutorials.POST("/:id/revisions/:rev/restore", policy.Authenticated(domain.ScopeTutorialsWrite), ctrl.RestoreRevision)
 	}
+
+	r.GET("/me/tutorials", policy.Authenticated(), ctrl.FindMine)
 }
 
 // Create handles POST /tutorials
 // @Summary Create a new tutorial
-// @Description Create a new tutorial article
+// @Description Create a new tutorial article as a draft (a title already in use gets a -2, -3, ... slug suffix)
 // @Tags tutorials
 // @Accept json
 // @Produce json
-// @Param X-User-ID header int true "User ID"
 // @Param dto body domain.CreateTutorialDTO true "Create Tutorial DTO"
 // @Success 201 {object} domain.TutorialDetailDTO
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
+// @Security BearerAuth
 // @Router /tutorials [post]
 func (ctrl *TutorialController) Create(c *gin.Context) {
 	var dto domain.CreateTutorialDTO
@@ -50,22 +66,20 @@ func (ctrl *TutorialController) Create(c *gin.Context) {
 		return
 	}
 
-	// TODO: Get authorID from JWT context
-	// For now, get from header or use default
-	authorIDStr := c.GetHeader("X-User-ID")
-	authorID, err := strconv.ParseUint(authorIDStr, 10, 32)
-	if err != nil || authorID == 0 {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid user"})
+	actor, ok := middleware.GetActor(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
 		return
 	}
 
-	tutorial, err := ctrl.service.Create(dto, uint(authorID))
+	tutorial, err := ctrl.service.Create(dto, actor.ID)
 	if err != nil {
-		if err.Error() == "a tutorial with a similar title already exists" {
+		switch err.Error() {
+		case "title cannot be empty", "content cannot be empty":
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-			return
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
@@ -73,29 +87,57 @@ func (ctrl *TutorialController) Create(c *gin.Context) {
 }
 
 // FindAll handles GET /tutorials
-// @Summary Get all tutorials
-// @Description Retrieve all tutorials list
+// @Summary List tutorials
+// @Description Retrieve a page of tutorials (published ones unless a moderator, or the author with authorId, asks for another status). Pass nextCursor back as cursor for the next page.
 // @Tags tutorials
 // @Produce json
-// @Success 200 {array} domain.TutorialListItemDTO
+// @Param limit query int false "Page size (default 20, max 50)"
+// @Param cursor query string false "Cursor from the previous page"
+// @Param tags query string false "Comma-separated tag IDs"
+// @Param tagMatch query string false "any (default) or all of the tags"
+// @Param authorId query int false "Author ID"
+// @Param status query string false "published (default), draft, in_review or archived"
+// @Param from query string false "Created on or after (YYYY-MM-DD)"
+// @Param to query string false "Created on or before (YYYY-MM-DD)"
+// @Param sort query string false "newest (default), views or votes"
+// @Param lang query string false "Language of a code block, e.g. go"
+// @Param includeTotal query bool false "Also count all matching tutorials"
+// @Success 200 {object} domain.TutorialPageDTO
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /tutorials [get]
 func (ctrl *TutorialController) FindAll(c *gin.Context) {
-	tutorials, err := ctrl.service.FindAll()
+	var params domain.TutorialListParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	actor, _ := middleware.GetActor(c)
+	page, err := ctrl.service.FindAll(params, actor)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		switch err.Error() {
+		case "invalid cursor", "invalid date range":
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case "forbidden":
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
 		return
 	}
 
-	c.JSON(http.StatusOK, tutorials)
+	c.JSON(http.StatusOK, page)
 }
 
 // FindOne handles GET /tutorials/:id
 // @Summary Get a tutorial by ID
-// @Description Retrieve a single tutorial by its ID
+// @Description Retrieve a single tutorial by its ID (unpublished tutorials only for their author and moderators)
 // @Tags tutorials
 // @Produce json
 // @Param id path int true "Tutorial ID"
+// @Param format query string false "markdown (default), html or both; html adds contentHtml and toc"
 // @Success 200 {object} domain.TutorialDetailDTO
 // @Failure 400 {object} map[string]string
 // @Failure 404 {object} map[string]string
@@ -107,7 +149,15 @@ func (ctrl *TutorialController) FindOne(c *gin.Context) {
 		return
 	}
 
-	tutorial, err := ctrl.service.FindOne(uint(id))
+	var query domain.TutorialDetailQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	actor, _ := middleware.GetActor(c)
+	query.Viewer = viewerKey(c, actor)
+	tutorial, err := ctrl.service.FindOne(uint(id), query, actor)
 	if err != nil {
 		if err.Error() == "tutorial not found" {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
@@ -122,20 +172,32 @@ func (ctrl *TutorialController) FindOne(c *gin.Context) {
 
 // FindBySlug handles GET /tutorials/slug/:slug
 // @Summary Get a tutorial by slug
-// @Description Retrieve a tutorial by its URL slug
+// @Description Retrieve a tutorial by its URL slug (unpublished tutorials only for their author and moderators).
+// @Description A slug replaced by a title change answers 301 with the current slug and its Location.
 // @Tags tutorials
 // @Produce json
 // @Param slug path string true "Tutorial Slug"
+// @Param format query string false "markdown (default), html or both; html adds contentHtml and toc"
 // @Success 200 {object} domain.TutorialDetailDTO
+// @Success 301 {object} map[string]string
+// @Failure 400 {object} map[string]string
 // @Failure 404 {object} map[string]string
 // @Router /tutorials/slug/{slug} [get]
 func (ctrl *TutorialController) FindBySlug(c *gin.Context) {
 	slug := c.Param("slug")
 
-	tutorial, err := ctrl.service.FindBySlug(slug)
+	var query domain.TutorialDetailQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	actor, _ := middleware.GetActor(c)
+	query.Viewer = viewerKey(c, actor)
+	tutorial, err := ctrl.service.FindBySlug(slug, query, actor)
 	if err != nil {
 		if err.Error() == "tutorial not found" {
-			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			ctrl.redirectOldSlug(c, slug, actor)
 			return
 		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -147,16 +209,17 @@ func (ctrl *TutorialController) FindBySlug(c *gin.Context) {
 
 // Update handles PATCH /tutorials/:id
 // @Summary Update a tutorial
-// @Description Update an existing tutorial
+// @Description Update an existing tutorial; every change is kept as a revision. Authors' edits to published or scheduled tutorials send them back to review.
 // @Tags tutorials
 // @Accept json
 // @Produce json
 // @Param id path int true "Tutorial ID"
-// @Param X-User-ID header int false "User ID"
 // @Param dto body domain.UpdateTutorialDTO true "Update Tutorial DTO"
 // @Success 200 {object} domain.TutorialDetailDTO
 // @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
 // @Failure 404 {object} map[string]string
+// @Security BearerAuth
 // @Router /tutorials/{id} [patch]
 func (ctrl *TutorialController) Update(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
@@ -171,15 +234,24 @@ func (ctrl *TutorialController) Update(c *gin.Context) {
 		return
 	}
 
-	// TODO: Get userID from JWT context
-	userIDStr := c.GetHeader("X-User-ID")
-	userID, _ := strconv.ParseUint(userIDStr, 10, 32)
+	actor, ok := middleware.GetActor(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
 
-	tutorial, err := ctrl.service.Update(uint(id), dto, uint(userID))
+	tutorial, err := ctrl.service.Update(uint(id), dto, actor)
 	if err != nil {
-		if err.Error() == "tutorial not found" {
+		switch err.Error() {
+		case "title cannot be empty", "content cannot be empty":
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		case "tutorial not found":
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 			return
+		case "forbidden":
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
 		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -193,10 +265,11 @@ func (ctrl *TutorialController) Update(c *gin.Context) {
 // @Description Delete a tutorial by ID
 // @Tags tutorials
 // @Param id path int true "Tutorial ID"
-// @Param X-User-ID header int false "User ID"
 // @Success 200 {object} map[string]int
 // @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
 // @Failure 404 {object} map[string]string
+// @Security BearerAuth
 // @Router /tutorials/{id} [delete]
 func (ctrl *TutorialController) Remove(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
@@ -205,15 +278,21 @@ func (ctrl *TutorialController) Remove(c *gin.Context) {
 		return
 	}
 
-	// TODO: Get userID from JWT context
-	userIDStr := c.GetHeader("X-User-ID")
-	userID, _ := strconv.ParseUint(userIDStr, 10, 32)
+	actor, ok := middleware.GetActor(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
 
-	err = ctrl.service.Remove(uint(id), uint(userID))
+	err = ctrl.service.Remove(uint(id), actor)
 	if err != nil {
-		if err.Error() == "tutorial not found" {
+		switch err.Error() {
+		case "tutorial not found":
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 			return
+		case "forbidden":
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
 		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -221,3 +300,338 @@ func (ctrl *TutorialController) Remove(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"id": id})
 }
+
+// redirectOldSlug points a client holding an outdated slug to the current one, or answers 404
+func (ctrl *TutorialController) redirectOldSlug(c *gin.Context, oldSlug string, actor domain.Actor) {
+	current, err := ctrl.service.ResolveSlug(oldSlug, actor)
+	if err != nil {
+		if err.Error() == "tutorial not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Keep the caller's options (e.g. ?format=html) on the new URL
+	location := strings.TrimSuffix(c.FullPath(), ":slug") + url.PathEscape(current)
+	if c.Request.URL.RawQuery != "" {
+		location += "?" + c.Request.URL.RawQuery
+	}
+	c.Header("Location", location)
+	c.JSON(http.StatusMovedPermanently, gin.H{"slug": current, "location": location})
+}
+
+// viewerKey identifies a reader for view deduplication: the account when signed in,
+// otherwise a hash of IP address and user agent (never stored)
+func viewerKey(c *gin.Context, actor domain.Actor) string {
+	if actor.ID != 0 {
+		return "u:" + strconv.FormatUint(uint64(actor.ID), 10)
+	}
+	sum := sha256.Sum256([]byte(c.ClientIP() + "|" + c.Request.UserAgent()))
+	return "a:" + hex.EncodeToString(sum[:16])
+}
+
+// FindViewStats handles GET /tutorials/:id/views
+// @Summary Get daily views of a tutorial
+// @Description Deduplicated views per UTC day, zero-filled (author or moderator). The range defaults to the last 30 days and is limited to 366 days.
+// @Tags tutorials
+// @Produce json
+// @Param id path int true "Tutorial ID"
+// @Param from query string false "First day (YYYY-MM-DD)"
+// @Param to query string false "Last day (YYYY-MM-DD), defaults to today"
+// @Success 200 {object} domain.TutorialViewStatsDTO
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Security BearerAuth
+// @Router /tutorials/{id}/views [get]
+func (ctrl *TutorialController) FindViewStats(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	var query domain.TutorialViewsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	actor, ok := middleware.GetActor(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	stats, err := ctrl.service.FindViewStats(uint(id), query, actor)
+	if err != nil {
+		switch err.Error() {
+		case "invalid date range":
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case "tutorial not found":
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case "forbidden":
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
+// FindMine handles GET /me/tutorials
+// @Summary List my tutorials
+// @Description All tutorials of the caller in every status, most recently updated first
+// @Tags tutorials
+// @Produce json
+// @Success 200 {array} domain.TutorialListItemDTO
+// @Failure 401 {object} map[string]string
+// @Security BearerAuth
+// @Router /me/tutorials [get]
+func (ctrl *TutorialController) FindMine(c *gin.Context) {
+	actor, ok := middleware.GetActor(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	tutorials, err := ctrl.service.FindMine(actor.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, tutorials)
+}
+
+// FindInReview handles GET /tutorials/review
+// @Summary List tutorials waiting for review
+// @Description Tutorials submitted for review, oldest first (moderators only)
+// @Tags tutorials
+// @Produce json
+// @Success 200 {array} domain.TutorialListItemDTO
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Security BearerAuth
+// @Router /tutorials/review [get]
+func (ctrl *TutorialController) FindInReview(c *gin.Context) {
+	tutorials, err := ctrl.service.FindInReview()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, tutorials)
+}
+
+// Submit handles POST /tutorials/:id/submit
+// @Summary Submit a tutorial for review
+// @Description Move a draft to in_review (author or moderator)
+// @Tags tutorials
+// @Produce json
+// @Param id path int true "Tutorial ID"
+// @Success 200 {object} domain.TutorialDetailDTO
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Security BearerAuth
+// @Router /tutorials/{id}/submit [post]
+func (ctrl *TutorialController) Submit(c *gin.Context) {
+	ctrl.runWorkflow(c, ctrl.service.Submit)
+}
+
+// Publish handles POST /tutorials/:id/publish
+// @Summary Publish a tutorial
+// @Description Publish now, or schedule publication of a draft or in-review tutorial with a future publishAt (moderators only)
+// @Tags tutorials
+// @Accept json
+// @Produce json
+// @Param id path int true "Tutorial ID"
+// @Param dto body domain.PublishTutorialDTO false "Optional publication time"
+// @Success 200 {object} domain.TutorialDetailDTO
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Security BearerAuth
+// @Router /tutorials/{id}/publish [post]
+func (ctrl *TutorialController) Publish(c *gin.Context) {
+	var dto domain.PublishTutorialDTO
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&dto); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	ctrl.runWorkflow(c, func(id uint, actor domain.Actor) (*domain.TutorialDetailDTO, error) {
+		return ctrl.service.Publish(id, dto, actor)
+	})
+}
+
+// Unpublish handles POST /tutorials/:id/unpublish
+// @Summary Unpublish a tutorial
+// @Description Move a tutorial back to draft and cancel any scheduled publication (author or moderator)
+// @Tags tutorials
+// @Produce json
+// @Param id path int true "Tutorial ID"
+// @Success 200 {object} domain.TutorialDetailDTO
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Security BearerAuth
+// @Router /tutorials/{id}/unpublish [post]
+func (ctrl *TutorialController) Unpublish(c *gin.Context) {
+	ctrl.runWorkflow(c, ctrl.service.Unpublish)
+}
+
+// Archive handles POST /tutorials/:id/archive
+// @Summary Archive a tutorial
+// @Description Retire a published tutorial (author or moderator)
+// @Tags tutorials
+// @Produce json
+// @Param id path int true "Tutorial ID"
+// @Success 200 {object} domain.TutorialDetailDTO
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Security BearerAuth
+// @Router /tutorials/{id}/archive [post]
+func (ctrl *TutorialController) Archive(c *gin.Context) {
+	ctrl.runWorkflow(c, ctrl.service.Archive)
+}
+
+// runWorkflow parses the tutorial ID, applies a workflow step as the caller and maps its errors
+func (ctrl *TutorialController) runWorkflow(c *gin.Context, step func(id uint, actor domain.Actor) (*domain.TutorialDetailDTO, error)) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	actor, ok := middleware.GetActor(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	tutorial, err := step(uint(id), actor)
+	if err != nil {
+		switch err.Error() {
+		case "tutorial not found", "revision not found":
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case "forbidden":
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case "invalid status transition":
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, tutorial)
+}
+
+// FindRevisions handles GET /tutorials/:id/revisions
+// @Summary List tutorial revisions
+// @Description Saved versions of a tutorial with editor and change summary, newest first
+// @Tags tutorials
+// @Produce json
+// @Param id path int true "Tutorial ID"
+// @Success 200 {array} domain.TutorialRevisionDTO
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /tutorials/{id}/revisions [get]
+func (ctrl *TutorialController) FindRevisions(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	actor, _ := middleware.GetActor(c)
+	revisions, err := ctrl.service.FindRevisions(uint(id), actor)
+	if err != nil {
+		if err.Error() == "tutorial not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, revisions)
+}
+
+// DiffRevisions handles GET /tutorials/:id/revisions/diff
+// @Summary Compare two tutorial revisions
+// @Description Line-level diff of the content of revision "from" against revision "to"
+// @Tags tutorials
+// @Produce json
+// @Param id path int true "Tutorial ID"
+// @Param from query int true "Old revision number"
+// @Param to query int true "New revision number"
+// @Success 200 {object} domain.TutorialRevisionDiffDTO
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /tutorials/{id}/revisions/diff [get]
+func (ctrl *TutorialController) DiffRevisions(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	var query domain.TutorialDiffQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	actor, _ := middleware.GetActor(c)
+	diff, err := ctrl.service.DiffRevisions(uint(id), query, actor)
+	if err != nil {
+		switch err.Error() {
+		case "tutorial not found", "revision not found":
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, diff)
+}
+
+// RestoreRevision handles POST /tutorials/:id/revisions/:rev/restore
+// @Summary Restore a tutorial revision
+// @Description Save the title and content of an old revision as a new revision (author or moderator)
+// @Tags tutorials
+// @Produce json
+// @Param id path int true "Tutorial ID"
+// @Param rev path int true "Revision number"
+// @Success 200 {object} domain.TutorialDetailDTO
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Security BearerAuth
+// @Router /tutorials/{id}/revisions/{rev}/restore [post]
+func (ctrl *TutorialController) RestoreRevision(c *gin.Context) {
+	number, err := strconv.Atoi(c.Param("rev"))
+	if err != nil || number < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid revision"})
+		return
+	}
+
+	ctrl.runWorkflow(c, func(id uint, actor domain.Actor) (*domain.TutorialDetailDTO, error) {
+		return ctrl.service.RestoreRevision(id, number, actor)
+	})
+}
@@ -1,9 +1,14 @@
 package repo
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
+	"regexp"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"api_go/internal/domain"
 )
@@ -17,15 +22,122 @@ func NewTutorialRepository(db *gorm.DB) domain.TutorialRepository {
 	return &tutorialRepository{db: db}
 }
 
-// Create inserts a new tutorial into the database
-func (r *tutorialRepository) Create(tutorial *domain.Tutorial) error {
-	return r.db.Create(tutorial).Error
+// Create inserts a new tutorial together with revision 1
+func (r *tutorialRepository) Create(tutorial *domain.Tutorial, revision *domain.TutorialRevision) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		tutorial.IsPublished = tutorial.Status == domain.TutorialStatusPublished
+		if err := tx.Create(tutorial).Error; err != nil {
+			return err
+		}
+		if !tutorial.IsPublished {
+			// Create leaves a false out of the insert, so the column would keep its default
+			if err := tx.Model(tutorial).UpdateColumn("is_published", false).Error; err != nil {
+				return err
+			}
+		}
+		revision.TutorialID = tutorial.ID
+		revision.Number = 1
+		return tx.Create(revision).Error
+	})
 }
 
-// FindAll retrieves all tutorials from the database
-func (r *tutorialRepository) FindAll() ([]domain.Tutorial, error) {
+// tutorialScore is the vote score of the tutorial in the current row
+var tutorialScore = fmt.Sprintf(
+	"(SELECT COALESCE(SUM(CASE v.vote_type WHEN '%s' THEN 1 WHEN '%s' THEN -1 ELSE 0 END), 0) "+
+		"FROM votes v WHERE v.entity_type = '%s' AND v.entity_id = tutorials.id AND v.deleted_at IS NULL)",
+	domain.VoteTypeUp, domain.VoteTypeDown, domain.EntityTypeTutorial,
+)
+
+// FindList retrieves a page of tutorials with keyset pagination on (sort key, id)
+func (r *tutorialRepository) FindList(filter domain.TutorialListFilter, limit int) ([]domain.Tutorial, error) {
+	query := r.filtered(filter).Preload("Author").Preload("Tags").Limit(limit)
+
+	var key string
+	switch filter.Sort {
+	case domain.TutorialSortViews:
+		key = "tutorials.views"
+	case domain.TutorialSortVotes:
+		key = tutorialScore
+		query = query.Select("tutorials.*, " + tutorialScore + " AS score")
+	default:
+		key = "tutorials.created_at"
+	}
+	query = query.Order(key + " DESC").Order("tutorials.id DESC")
+
+	if after := filter.After; after != nil {
+		var value interface{} = after.Count
+		if filter.Sort != domain.TutorialSortViews && filter.Sort != domain.TutorialSortVotes {
+			value = after.CreatedAt
+		}
+		query = query.Where("("+key+", tutorials.id) < (?, ?)", value, after.ID)
+	}
+
+	var tutorials []domain.Tutorial
+	err := query.Find(&tutorials).Error
+	return tutorials, err
+}
+
+// CountList counts the tutorials matching the filter
+func (r *tutorialRepository) CountList(filter domain.TutorialListFilter) (int64, error) {
+	var count int64
+	err := r.filtered(filter).Count(&count).Error
+	return count, err
+}
+
+// filtered applies the list filters except the cursor
+func (r *tutorialRepository) filtered(filter domain.TutorialListFilter) *gorm.DB {
+	query := r.db.Model(&domain.Tutorial{}).Where("tutorials.status = ?", filter.Status)
+	if filter.AuthorID != nil {
+		query = query.Where("tutorials.author_id = ?", *filter.AuthorID)
+	}
+	if filter.From != nil {
+		query = query.Where("tutorials.created_at >= ?", *filter.From)
+	}
+	if filter.Until != nil {
+		query = query.Where("tutorials.created_at < ?", *filter.Until)
+	}
+	if filter.Language != "" {
+		language, _ := json.Marshal([]string{filter.Language})
+		query = query.Where("tutorials.code_languages @> ?::jsonb", string(language))
+	}
+	if len(filter.TagIDs) > 0 {
+		if filter.MatchAllTags {
+			query = query.Where(
+				"(SELECT COUNT(DISTINCT tt.tag_id) FROM tutorial_tags tt WHERE tt.tutorial_id = tutorials.id AND tt.tag_id IN ?) = ?",
+				filter.TagIDs, len(filter.TagIDs),
+			)
+		} else {
+			query = query.Where(
+				"EXISTS (SELECT 1 FROM tutorial_tags tt WHERE tt.tutorial_id = tutorials.id AND tt.tag_id IN ?)",
+				filter.TagIDs,
+			)
+		}
+	}
+	return query
+}
+
+// FindPublishedByTag retrieves published tutorials with the tag, newest first
+func (r *tutorialRepository) FindPublishedByTag(tagID uint) ([]domain.Tutorial, error) {
+	var tutorials []domain.Tutorial
+	err := r.db.Preload("Author").Preload("Tags").
+		Where("status = ?", domain.TutorialStatusPublished).
+		Where("EXISTS (SELECT 1 FROM tutorial_tags tt WHERE tt.tutorial_id = tutorials.id AND tt.tag_id = ?)", tagID).
+		Order("created_at DESC").
+		Find(&tutorials).Error
+	return tutorials, err
+}
+
+// FindByAuthor retrieves all tutorials of an author regardless of status, most recently updated first
+func (r *tutorialRepository) FindByAuthor(authorID uint) ([]domain.Tutorial, error) {
 	var tutorials []domain.Tutorial
-	err := r.db.Preload("Author").Order("created_at DESC").Find(&tutorials).Error
+	err := r.db.Preload("Author").Preload("Tags").Where("author_id = ?", authorID).Order("updated_at DESC").Find(&tutorials).Error
+	return tutorials, err
+}
+
+// FindByStatus retrieves tutorials in a status, oldest first
+func (r *tutorialRepository) FindByStatus(status domain.TutorialStatus) ([]domain.Tutorial, error) {
+	var tutorials []domain.Tutorial
+	err := r.db.Preload("Author").Preload("Tags").Where("status = ?", status).Order("updated_at ASC").Find(&tutorials).Error
 	return tutorials, err
 }
 
@@ -81,9 +193,134 @@ func (r *tutorialRepository) FindBySlugWithTags(slug string) (*domain.Tutorial,
 	return &tutorial, nil
 }
 
-// Update updates an existing tutorial
-func (r *tutorialRepository) Update(id uint, update *domain.Tutorial) error {
-	result := r.db.Model(&domain.Tutorial{}).Where("id = ?", id).Updates(update)
+// FindBySlugHistory retrieves the tutorial an old slug belonged to
+func (r *tutorialRepository) FindBySlugHistory(slug string) (*domain.Tutorial, error) {
+	var tutorial domain.Tutorial
+	err := r.db.
+		Joins("JOIN tutorial_slug_history h ON h.tutorial_id = tutorials.id").
+		Where("h.slug = ?", slug).
+		First(&tutorial).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &tutorial, nil
+}
+
+// FindTakenSlugs retrieves base and base-N slugs used by other tutorials. Soft-deleted
+// tutorials count too since the unique index still covers them.
+func (r *tutorialRepository) FindTakenSlugs(base string, exceptID uint) ([]string, error) {
+	pattern := "^" + regexp.QuoteMeta(base) + "(-[0-9]+)?$"
+	var slugs []string
+	err := r.db.Raw(
+		`SELECT slug FROM tutorials WHERE slug ~ ? AND id <> ?
+		UNION
+		SELECT slug FROM tutorial_slug_history WHERE slug ~ ? AND tutorial_id <> ?`,
+		pattern, exceptID, pattern, exceptID,
+	).Scan(&slugs).Error
+	return slugs, err
+}
+
+// Update updates an existing tutorial and appends the revision. The tutorial row is locked
+// so concurrent edits get consecutive revision numbers.
+func (r *tutorialRepository) Update(id uint, update *domain.Tutorial, revision *domain.TutorialRevision) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var current domain.Tutorial
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&current, id).Error; err != nil {
+			return err
+		}
+
+		var last int
+		if err := tx.Model(&domain.TutorialRevision{}).
+			Where("tutorial_id = ?", id).
+			Select("COALESCE(MAX(number), 0)").
+			Scan(&last).Error; err != nil {
+			return err
+		}
+		if last == 0 {
+			// Written before revisions existed: keep the current version first
+			baseline := &domain.TutorialRevision{
+				TutorialID: id,
+				Number:     1,
+				Title:      current.Title,
+				Content:    current.Content,
+				EditorID:   current.AuthorID,
+				Summary:    "Initial version",
+				CreatedAt:  current.UpdatedAt,
+			}
+			if err := tx.Create(baseline).Error; err != nil {
+				return err
+			}
+			last = 1
+		}
+
+		if err := tx.Model(&domain.Tutorial{}).Where("id = ?", id).Updates(update).Error; err != nil {
+			return err
+		}
+		if update.Status != "" {
+			// An edit sent back to review also leaves the public listing and loses its schedule
+			if err := tx.Model(&domain.Tutorial{}).Where("id = ?", id).Updates(map[string]interface{}{
+				"is_published": update.Status == domain.TutorialStatusPublished,
+				"publish_at":   nil,
+			}).Error; err != nil {
+				return err
+			}
+		}
+		if update.Content != "" {
+			// Written separately: a zero word count would be skipped by Updates
+			if err := tx.Model(&domain.Tutorial{}).Where("id = ?", id).Updates(statsColumns(update)).Error; err != nil {
+				return err
+			}
+		}
+
+		if update.Slug != "" && update.Slug != current.Slug {
+			// Renaming back to an old slug makes it current again
+			if err := tx.Where("tutorial_id = ? AND slug = ?", id, update.Slug).
+				Delete(&domain.TutorialSlugHistory{}).Error; err != nil {
+				return err
+			}
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).
+				Create(&domain.TutorialSlugHistory{TutorialID: id, Slug: current.Slug}).Error; err != nil {
+				return err
+			}
+		}
+
+		revision.TutorialID = id
+		revision.Number = last + 1
+		return tx.Create(revision).Error
+	})
+}
+
+// FindMissingStats retrieves up to limit tutorials whose content statistics were never computed
+func (r *tutorialRepository) FindMissingStats(limit int) ([]domain.Tutorial, error) {
+	var tutorials []domain.Tutorial
+	err := r.db.Where("reading_minutes = 0").Order("id").Limit(limit).Find(&tutorials).Error
+	return tutorials, err
+}
+
+// UpdateStats writes the content statistics without touching updated_at
+func (r *tutorialRepository) UpdateStats(id uint, stats *domain.Tutorial) error {
+	return r.db.Model(&domain.Tutorial{}).Where("id = ?", id).UpdateColumns(statsColumns(stats)).Error
+}
+
+func statsColumns(t *domain.Tutorial) map[string]interface{} {
+	return map[string]interface{}{
+		"word_count":      t.WordCount,
+		"reading_minutes": t.ReadingMinutes,
+		"code_languages":  t.CodeLanguages,
+	}
+}
+
+// UpdateStatus sets the status and publication times in one update
+func (r *tutorialRepository) UpdateStatus(id uint, status domain.TutorialStatus, publishAt, publishedAt *time.Time) error {
+	result := r.db.Model(&domain.Tutorial{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":       status,
+		"is_published": status == domain.TutorialStatusPublished,
+		"publish_at":   publishAt,
+		"published_at": publishedAt,
+	})
 	if result.Error != nil {
 		return result.Error
 	}
@@ -93,6 +330,19 @@ func (r *tutorialRepository) Update(id uint, update *domain.Tutorial) error {
 	return nil
 }
 
+// PublishDue publishes scheduled tutorials in a single conditional update
+func (r *tutorialRepository) PublishDue(now time.Time) (int64, error) {
+	result := r.db.Model(&domain.Tutorial{}).
+		Where("status IN ? AND publish_at <= ?", []domain.TutorialStatus{domain.TutorialStatusDraft, domain.TutorialStatusInReview}, now).
+		Updates(map[string]interface{}{
+			"status":       domain.TutorialStatusPublished,
+			"is_published": true,
+			"published_at": gorm.Expr("COALESCE(published_at, publish_at)"),
+			"publish_at":   nil,
+		})
+	return result.RowsAffected, result.Error
+}
+
 // Delete removes a tutorial by ID
 func (r *tutorialRepository) Delete(id uint) error {
 	result := r.db.Delete(&domain.Tutorial{}, id)
@@ -104,3 +354,51 @@ func (r *tutorialRepository) Delete(id uint) error {
 	}
 	return nil
 }
+
+// FindPublishedByAuthor retrieves a page of an author's published tutorials (keyset on ID, newest first)
+func (r *tutorialRepository) FindPublishedByAuthor(authorID uint, cursor *uint, limit int) ([]domain.Tutorial, error) {
+	query := r.db.Preload("Author").Preload("Tags").
+		Where("author_id = ? AND status = ?", authorID, domain.TutorialStatusPublished).
+		Order("id DESC").
+		Limit(limit)
+	if cursor != nil {
+		query = query.Where("id < ?", *cursor)
+	}
+
+	var tutorials []domain.Tutorial
+	err := query.Find(&tutorials).Error
+	return tutorials, err
+}
+
+// CountPublishedByAuthor counts an author's published tutorials
+func (r *tutorialRepository) CountPublishedByAuthor(authorID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&domain.Tutorial{}).
+		Where("author_id = ? AND status = ?", authorID, domain.TutorialStatusPublished).
+		Count(&count).Error
+	return count, err
+}
+
+// FindRevisions retrieves the revisions of a tutorial, newest first
+func (r *tutorialRepository) FindRevisions(tutorialID uint) ([]domain.TutorialRevision, error) {
+	var revisions []domain.TutorialRevision
+	err := r.db.Preload("Editor").
+		Omit("content").
+		Where("tutorial_id = ?", tutorialID).
+		Order("number DESC").
+		Find(&revisions).Error
+	return revisions, err
+}
+
+// FindRevision retrieves one revision by its number
+func (r *tutorialRepository) FindRevision(tutorialID uint, number int) (*domain.TutorialRevision, error) {
+	var revision domain.TutorialRevision
+	err := r.db.Where("tutorial_id = ? AND number = ?", tutorialID, number).First(&revision).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &revision, nil
+}
@@ -0,0 +1,63 @@
+package repo
+
+import (
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"api_go/internal/domain"
+)
+
+type tutorialViewRepository struct {
+	db *gorm.DB
+}
+
+// NewTutorialViewRepository creates a new TutorialViewRepository instance
+func NewTutorialViewRepository(db *gorm.DB) domain.TutorialViewRepository {
+	return &tutorialViewRepository{db: db}
+}
+
+// AddViews upserts the daily counts and bumps tutorials.views with one statement per table
+func (r *tutorialViewRepository) AddViews(counts []domain.TutorialViewDaily) error {
+	if len(counts) == 0 {
+		return nil
+	}
+
+	totals := make(map[uint]int64)
+	for _, c := range counts {
+		totals[c.TutorialID] += c.Views
+	}
+	values := make([]string, 0, len(totals))
+	args := make([]interface{}, 0, 2*len(totals))
+	for id, n := range totals {
+		values = append(values, "(?::bigint, ?::bigint)")
+		args = append(args, id, n)
+	}
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "tutorial_id"}, {Name: "day"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{"views": gorm.Expr("tutorial_view_daily.views + excluded.views")}),
+		}).Create(&counts).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Exec(
+			"UPDATE tutorials AS t SET views = t.views + v.n FROM (VALUES "+strings.Join(values, ", ")+") AS v(id, n) WHERE t.id = v.id",
+			args...,
+		).Error
+	})
+}
+
+// FindDaily retrieves the daily rows of a tutorial within the range
+func (r *tutorialViewRepository) FindDaily(tutorialID uint, from, to time.Time) ([]domain.TutorialViewDaily, error) {
+	var days []domain.TutorialViewDaily
+	err := r.db.
+		Where("tutorial_id = ? AND day BETWEEN ? AND ?", tutorialID, from, to).
+		Order("day").
+		Find(&days).Error
+	return days, err
+}
@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"api_go/internal/domain"
+)
+
+// PublishScheduler periodically publishes tutorials whose publishAt has passed.
+// Every instance may run one: publication is a single conditional update.
+type PublishScheduler struct {
+	service  domain.TutorialService
+	interval time.Duration
+}
+
+// NewPublishScheduler creates a scheduler that checks every interval
+func NewPublishScheduler(service domain.TutorialService, interval time.Duration) *PublishScheduler {
+	return &PublishScheduler{service: service, interval: interval}
+}
+
+// Run checks for due tutorials until ctx is cancelled
+func (s *PublishScheduler) Run(ctx context.Context) {
+	if s.interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		s.publishDue()
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *PublishScheduler) publishDue() {
+	published, err := s.service.PublishDue()
+	if err != nil {
+		log.Printf("scheduled publication failed: %v", err)
+		return
+	}
+	if published > 0 {
+		log.Printf("published %d scheduled tutorials", published)
+	}
+}
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"api_go/internal/domain"
+	"api_go/internal/markdown"
+)
+
+// FindAll retrieves a page of tutorials; the next cursor is set when more tutorials follow
+func (s *tutorialService) FindAll(params domain.TutorialListParams, actor domain.Actor) (*domain.TutorialPageDTO, error) {
+	filter, err := listFilter(params, actor)
+	if err != nil {
+		return nil, err
+	}
+	limit := params.PageLimit()
+
+	// Fetch one extra to determine if there's more
+	tutorials, err := s.repo.FindList(filter, limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &domain.TutorialPageDTO{}
+	if len(tutorials) > limit {
+		tutorials = tutorials[:limit]
+		cursor := encodeCursor(filter.Sort, &tutorials[limit-1])
+		page.NextCursor = &cursor
+	}
+	page.Items = toListItemDTOList(tutorials)
+
+	if params.IncludeTotal {
+		total, err := s.repo.CountList(filter)
+		if err != nil {
+			return nil, err
+		}
+		page.Total = &total
+	}
+	return page, nil
+}
+
+// listFilter validates the query parameters and checks the actor may list the requested status
+func listFilter(params domain.TutorialListParams, actor domain.Actor) (domain.TutorialListFilter, error) {
+	filter := domain.TutorialListFilter{
+		AuthorID:     params.AuthorID,
+		Status:       params.Status,
+		Sort:         params.Sort,
+		MatchAllTags: params.TagMatch == "all",
+		Language:     markdown.NormalizeLanguage(params.Lang),
+	}
+	if filter.Status == "" {
+		filter.Status = domain.TutorialStatusPublished
+	}
+	if filter.Sort == "" {
+		filter.Sort = domain.TutorialSortNewest
+	}
+	if filter.Status != domain.TutorialStatusPublished {
+		ownList := actor.ID != 0 && filter.AuthorID != nil && *filter.AuthorID == actor.ID
+		if !ownList && !actor.CanModerate() {
+			return filter, errors.New("forbidden")
+		}
+	}
+
+	seen := make(map[uint]bool, len(params.TagIDs))
+	for _, tagID := range params.TagIDs {
+		if !seen[tagID] {
+			seen[tagID] = true
+			filter.TagIDs = append(filter.TagIDs, tagID)
+		}
+	}
+
+	if params.From != "" {
+		from, err := time.Parse(dayLayout, params.From)
+		if err != nil {
+			return filter, errors.New("invalid date range")
+		}
+		filter.From = &from
+	}
+	if params.To != "" {
+		to, err := time.Parse(dayLayout, params.To)
+		if err != nil {
+			return filter, errors.New("invalid date range")
+		}
+		until := to.AddDate(0, 0, 1)
+		filter.Until = &until
+	}
+	if filter.From != nil && filter.Until != nil && !filter.From.Before(*filter.Until) {
+		return filter, errors.New("invalid date range")
+	}
+
+	if params.Cursor != "" {
+		after, err := decodeCursor(params.Cursor)
+		if err != nil || after.Sort != filter.Sort {
+			return filter, errors.New("invalid cursor")
+		}
+		filter.After = after
+	}
+	return filter, nil
+}
+
+// encodeCursor returns the opaque cursor pointing after t in the given order
+func encodeCursor(sort domain.TutorialSort, t *domain.Tutorial) string {
+	cursor := domain.TutorialCursor{Sort: sort, ID: t.ID}
+	switch sort {
+	case domain.TutorialSortViews:
+		cursor.Count = t.Views
+	case domain.TutorialSortVotes:
+		cursor.Count = t.Score
+	default:
+		cursor.CreatedAt = t.CreatedAt
+	}
+	raw, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodeCursor(value string) (*domain.TutorialCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+	var cursor domain.TutorialCursor
+	if err := json.Unmarshal(raw, &cursor); err != nil {
+		return nil, err
+	}
+	return &cursor, nil
+}
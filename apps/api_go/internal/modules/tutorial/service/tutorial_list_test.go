@@ -0,0 +1,102 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"api_go/internal/domain"
+)
+
+type fakeTutorialRepo struct {
+	domain.TutorialRepository
+	tutorials []domain.Tutorial
+	filter    domain.TutorialListFilter
+}
+
+func (r *fakeTutorialRepo) FindList(filter domain.TutorialListFilter, limit int) ([]domain.Tutorial, error) {
+	r.filter = filter
+	if limit > len(r.tutorials) {
+		limit = len(r.tutorials)
+	}
+	return r.tutorials[:limit], nil
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+	created := time.Date(2026, 3, 1, 12, 30, 0, 123456000, time.UTC)
+	tutorial := &domain.Tutorial{Views: 42, Score: -3}
+	tutorial.ID = 7
+	tutorial.CreatedAt = created
+
+	tests := []struct {
+		sort domain.TutorialSort
+		want domain.TutorialCursor
+	}{
+		{domain.TutorialSortNewest, domain.TutorialCursor{Sort: domain.TutorialSortNewest, CreatedAt: created, ID: 7}},
+		{domain.TutorialSortViews, domain.TutorialCursor{Sort: domain.TutorialSortViews, Count: 42, ID: 7}},
+		{domain.TutorialSortVotes, domain.TutorialCursor{Sort: domain.TutorialSortVotes, Count: -3, ID: 7}},
+	}
+	for _, tt := range tests {
+		filter, err := listFilter(domain.TutorialListParams{Sort: tt.sort, Cursor: encodeCursor(tt.sort, tutorial)}, domain.Actor{})
+		if err != nil {
+			t.Fatalf("%s: %v", tt.sort, err)
+		}
+		got := *filter.After
+		if got.Sort != tt.want.Sort || got.Count != tt.want.Count || got.ID != tt.want.ID || !got.CreatedAt.Equal(tt.want.CreatedAt) {
+			t.Errorf("%s: cursor = %+v, want %+v", tt.sort, got, tt.want)
+		}
+	}
+}
+
+func TestListFilterRejectsInvalidCursors(t *testing.T) {
+	tutorial := &domain.Tutorial{Views: 42}
+	tutorial.ID = 7
+	viewsCursor := encodeCursor(domain.TutorialSortViews, tutorial)
+
+	tests := map[string]domain.TutorialListParams{
+		"not base64":          {Cursor: "%%%"},
+		"not json":            {Cursor: "bm90IGpzb24"},
+		"other sort":          {Sort: domain.TutorialSortVotes, Cursor: viewsCursor},
+		"other default sort":  {Cursor: viewsCursor},
+		"padded base64 value": {Sort: domain.TutorialSortViews, Cursor: viewsCursor + "=="},
+	}
+	for name, params := range tests {
+		if _, err := listFilter(params, domain.Actor{}); err == nil || err.Error() != "invalid cursor" {
+			t.Errorf("%s: err = %v, want invalid cursor", name, err)
+		}
+	}
+}
+
+func TestFindAllSetsNextCursor(t *testing.T) {
+	repo := &fakeTutorialRepo{}
+	for i := 1; i <= 3; i++ {
+		tutorial := domain.Tutorial{Views: int64(100 - i)}
+		tutorial.ID = uint(i)
+		repo.tutorials = append(repo.tutorials, tutorial)
+	}
+	s := &tutorialService{repo: repo}
+
+	page, err := s.FindAll(domain.TutorialListParams{Sort: domain.TutorialSortViews, Limit: 2}, domain.Actor{})
+	if err != nil {
+		t.Fatalf("FindAll: %v", err)
+	}
+	if len(page.Items) != 2 || page.NextCursor == nil {
+		t.Fatalf("page = %+v", page)
+	}
+
+	// The next page starts after the last item shown, not after the extra one fetched
+	if _, err := s.FindAll(domain.TutorialListParams{Sort: domain.TutorialSortViews, Limit: 2, Cursor: *page.NextCursor}, domain.Actor{}); err != nil {
+		t.Fatalf("FindAll with cursor: %v", err)
+	}
+	if after := repo.filter.After; after == nil || after.ID != 2 || after.Count != 98 {
+		t.Errorf("cursor = %+v, want the second tutorial", after)
+	}
+
+	repo.tutorials = repo.tutorials[:2]
+	page, err = s.FindAll(domain.TutorialListParams{Sort: domain.TutorialSortViews, Limit: 2}, domain.Actor{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page.NextCursor != nil {
+		t.Error("the last page must not have a next cursor")
+	}
+}
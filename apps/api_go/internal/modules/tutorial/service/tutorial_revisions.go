@@ -0,0 +1,127 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"api_go/internal/domain"
+	"api_go/internal/textdiff"
+)
+
+// FindRevisions retrieves the revision history of a tutorial the actor can read
+func (s *tutorialService) FindRevisions(id uint, actor domain.Actor) ([]domain.TutorialRevisionDTO, error) {
+	if _, err := s.findVisible(id, actor); err != nil {
+		return nil, err
+	}
+
+	revisions, err := s.repo.FindRevisions(id)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.TutorialRevisionDTO, len(revisions))
+	for i := range revisions {
+		result[i] = toRevisionDTO(&revisions[i])
+	}
+	return result, nil
+}
+
+// DiffRevisions compares the content of two revisions line by line
+func (s *tutorialService) DiffRevisions(id uint, query domain.TutorialDiffQuery, actor domain.Actor) (*domain.TutorialRevisionDiffDTO, error) {
+	if _, err := s.findVisible(id, actor); err != nil {
+		return nil, err
+	}
+
+	from, err := s.findRevision(id, query.From)
+	if err != nil {
+		return nil, err
+	}
+	to, err := s.findRevision(id, query.To)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := textdiff.Lines(from.Content, to.Content)
+	diff := &domain.TutorialRevisionDiffDTO{
+		From:      from.Number,
+		To:        to.Number,
+		FromTitle: from.Title,
+		ToTitle:   to.Title,
+		Lines:     make([]domain.TutorialDiffLineDTO, len(lines)),
+	}
+	for i, line := range lines {
+		switch line.Op {
+		case textdiff.Insert:
+			diff.Added++
+		case textdiff.Delete:
+			diff.Removed++
+		}
+		diff.Lines[i] = domain.TutorialDiffLineDTO{
+			Op:      string(line.Op),
+			Text:    line.Text,
+			OldLine: line.OldLine,
+			NewLine: line.NewLine,
+		}
+	}
+	return diff, nil
+}
+
+// RestoreRevision saves the title and content of an old revision as a new revision
+func (s *tutorialService) RestoreRevision(id uint, number int, actor domain.Actor) (*domain.TutorialDetailDTO, error) {
+	if _, err := s.findModifiable(id, actor); err != nil {
+		return nil, err
+	}
+
+	revision, err := s.findRevision(id, number)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Update(id, domain.UpdateTutorialDTO{
+		Title:   &revision.Title,
+		Content: &revision.Content,
+		Summary: fmt.Sprintf("Restored revision %d", revision.Number),
+	}, actor)
+}
+
+// findVisible loads a tutorial the actor may read
+func (s *tutorialService) findVisible(id uint, actor domain.Actor) (*domain.Tutorial, error) {
+	tutorial, err := s.repo.FindOne(id)
+	if err != nil {
+		return nil, err
+	}
+	if tutorial == nil || !tutorial.VisibleTo(actor) {
+		return nil, errors.New("tutorial not found")
+	}
+	return tutorial, nil
+}
+
+func (s *tutorialService) findRevision(id uint, number int) (*domain.TutorialRevision, error) {
+	revision, err := s.repo.FindRevision(id, number)
+	if err != nil {
+		return nil, err
+	}
+	if revision == nil {
+		return nil, errors.New("revision not found")
+	}
+	return revision, nil
+}
+
+// toRevisionDTO converts a TutorialRevision entity (without content) to its list DTO
+func toRevisionDTO(r *domain.TutorialRevision) domain.TutorialRevisionDTO {
+	editorName := defaultAuthorName
+	editorHandle := ""
+	if r.Editor != nil {
+		editorName = r.Editor.Name
+		editorHandle = r.Editor.Handle
+	}
+	return domain.TutorialRevisionDTO{
+		Number:       r.Number,
+		Title:        r.Title,
+		Summary:      r.Summary,
+		EditorID:     r.EditorID,
+		EditorName:   editorName,
+		EditorHandle: editorHandle,
+		CreatedAt:    r.CreatedAt,
+	}
+}
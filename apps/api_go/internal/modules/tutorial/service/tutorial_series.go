@@ -0,0 +1,66 @@
+package service
+
+import "api_go/internal/domain"
+
+// seriesNav places a tutorial within its series, or returns nil when it is in none.
+// Items the actor cannot see are skipped, so previous/next never point at a hidden tutorial.
+func (s *tutorialService) seriesNav(tutorialID uint, actor domain.Actor) (*domain.TutorialSeriesNavDTO, error) {
+	item, err := s.seriesRepo.FindItemByTutorial(tutorialID)
+	if err != nil || item == nil {
+		return nil, err
+	}
+	series, err := s.seriesRepo.FindOneWithItems(item.SeriesID)
+	if err != nil || series == nil {
+		return nil, err
+	}
+
+	var visible []*domain.SeriesItem
+	current := -1
+	for i := range series.Items {
+		candidate := &series.Items[i]
+		if candidate.ID == item.ID {
+			current = len(visible)
+		} else if !candidate.VisibleTo(actor) {
+			continue
+		}
+		visible = append(visible, candidate)
+	}
+	if current < 0 {
+		return nil, nil
+	}
+
+	nav := &domain.TutorialSeriesNavDTO{
+		ID:       series.ID,
+		Title:    series.Title,
+		Position: current + 1,
+		Total:    len(visible),
+	}
+	if current > 0 {
+		nav.Previous = toSeriesItemDTO(visible[current-1], current)
+	}
+	if current < len(visible)-1 {
+		nav.Next = toSeriesItemDTO(visible[current+1], current+2)
+	}
+	return nav, nil
+}
+
+// toSeriesItemDTO converts a neighbouring series item; position counts the visible items
+func toSeriesItemDTO(item *domain.SeriesItem, position int) *domain.SeriesItemDTO {
+	dto := &domain.SeriesItemDTO{
+		ID:         item.ID,
+		Position:   position,
+		TutorialID: item.TutorialID,
+		VideoID:    item.VideoID,
+	}
+	if item.Tutorial != nil {
+		dto.Type = domain.SeriesItemTutorial
+		dto.Title = item.Tutorial.Title
+		dto.Slug = item.Tutorial.Slug
+		dto.Status = item.Tutorial.Status
+	} else if item.Video != nil {
+		dto.Type = domain.SeriesItemVideo
+		dto.Title = item.Video.Title
+		dto.YoutubeID = item.Video.YoutubeID
+	}
+	return dto
+}
@@ -2,13 +2,11 @@ package service
 
 import (
 	"errors"
-	"regexp"
 	"strings"
-	"unicode"
-
-	"golang.org/x/text/unicode/norm"
 
 	"api_go/internal/domain"
+	"api_go/internal/markdown"
+	"api_go/internal/mention"
 )
 
 const (
@@ -17,48 +15,41 @@ const (
 )
 
 type tutorialService struct {
-	repo domain.TutorialRepository
+	repo       domain.TutorialRepository
+	viewRepo   domain.TutorialViewRepository
+	seriesRepo domain.SeriesRepository
+	renderer   *markdown.Renderer
+	views      *ViewCounter
+	mentions   domain.MentionResolver
 }
 
 // NewTutorialService creates a new TutorialService instance
-func NewTutorialService(repo domain.TutorialRepository) domain.TutorialService {
-	return &tutorialService{repo: repo}
-}
-
-// generateSlug creates URL-friendly slug from title
-func generateSlug(title string) string {
-	// Normalize unicode
-	s := norm.NFD.String(title)
-	// Remove diacritics
-	var result strings.Builder
-	for _, r := range s {
-		if unicode.Is(unicode.Mn, r) {
-			continue
-		}
-		result.WriteRune(r)
-	}
-	slug := result.String()
-	// To lowercase
-	slug = strings.ToLower(slug)
-	// Replace spaces with hyphens
-	slug = strings.ReplaceAll(slug, " ", "-")
-	// Remove special characters
-	reg := regexp.MustCompile(`[^a-z0-9\-]`)
-	slug = reg.ReplaceAllString(slug, "")
-	// Remove multiple hyphens
-	reg = regexp.MustCompile(`-+`)
-	slug = reg.ReplaceAllString(slug, "-")
-	// Trim hyphens
-	slug = strings.Trim(slug, "-")
-	return slug
+func NewTutorialService(
+	repo domain.TutorialRepository,
+	viewRepo domain.TutorialViewRepository,
+	seriesRepo domain.SeriesRepository,
+	renderer *markdown.Renderer,
+	views *ViewCounter,
+	mentions domain.MentionResolver,
+) domain.TutorialService {
+	return &tutorialService{
+		repo:       repo,
+		viewRepo:   viewRepo,
+		seriesRepo: seriesRepo,
+		renderer:   renderer,
+		views:      views,
+		mentions:   mentions,
+	}
 }
 
 // toListItemDTO converts Tutorial entity to TutorialListItemDTO
 func toListItemDTO(t *domain.Tutorial) domain.TutorialListItemDTO {
 	authorName := defaultAuthorName
+	authorHandle := ""
 	authorAvatar := defaultAuthorAvatar
 	if t.Author != nil {
 		authorName = t.Author.Name
+		authorHandle = t.Author.Handle
 		if t.Author.AvatarURL != nil {
 			authorAvatar = *t.Author.AvatarURL
 		}
@@ -67,76 +58,114 @@ func toListItemDTO(t *domain.Tutorial) domain.TutorialListItemDTO {
 		ID:              t.ID,
 		Title:           t.Title,
 		Slug:            t.Slug,
+		Status:          t.Status,
+		PublishedAt:     t.PublishedAt,
 		CreatedAt:       t.CreatedAt,
 		UpdatedAt:       t.UpdatedAt,
 		AuthorName:      authorName,
+		AuthorHandle:    authorHandle,
 		AuthorAvatarURL: authorAvatar,
+		Tags:            toTagDTOList(t.Tags),
+		WordCount:       t.WordCount,
+		ReadingMinutes:  t.ReadingMinutes,
+		CodeLanguages:   codeLanguages(t),
+	}
+}
+
+// toTagDTOList converts the preloaded tags of a tutorial
+func toTagDTOList(tags []domain.Tag) []domain.TagResponseDTO {
+	result := make([]domain.TagResponseDTO, len(tags))
+	for i, tag := range tags {
+		result[i] = domain.TagResponseDTO{
+			ID:          tag.ID,
+			Name:        tag.Name,
+			Description: tag.Description,
+		}
+	}
+	return result
+}
+
+// toListItemDTOList converts a slice of Tutorial entities to list items
+func toListItemDTOList(tutorials []domain.Tutorial) []domain.TutorialListItemDTO {
+	result := make([]domain.TutorialListItemDTO, len(tutorials))
+	for i := range tutorials {
+		result[i] = toListItemDTO(&tutorials[i])
 	}
+	return result
 }
 
 // toDetailDTO converts Tutorial entity to TutorialDetailDTO
 func toDetailDTO(t *domain.Tutorial) *domain.TutorialDetailDTO {
 	authorName := defaultAuthorName
+	authorHandle := ""
 	authorAvatar := defaultAuthorAvatar
 	if t.Author != nil {
 		authorName = t.Author.Name
+		authorHandle = t.Author.Handle
 		if t.Author.AvatarURL != nil {
 			authorAvatar = *t.Author.AvatarURL
 		}
 	}
 
-	tags := make([]domain.TagResponseDTO, len(t.Tags))
-	for i, tag := range t.Tags {
-		tags[i] = domain.TagResponseDTO{
-			ID:          tag.ID,
-			Name:        tag.Name,
-			Description: tag.Description,
-		}
-	}
-
 	return &domain.TutorialDetailDTO{
 		ID:              t.ID,
 		Title:           t.Title,
 		Slug:            t.Slug,
 		Content:         t.Content,
 		Views:           t.Views,
-		IsPublished:     t.IsPublished,
+		IsPublished:     t.Status == domain.TutorialStatusPublished,
+		Status:          t.Status,
+		PublishAt:       t.PublishAt,
+		PublishedAt:     t.PublishedAt,
 		CreatedAt:       t.CreatedAt,
 		UpdatedAt:       t.UpdatedAt,
 		AuthorName:      authorName,
+		AuthorHandle:    authorHandle,
 		AuthorAvatarURL: authorAvatar,
-		Tags:            tags,
+		Tags:            toTagDTOList(t.Tags),
+		WordCount:       t.WordCount,
+		ReadingMinutes:  t.ReadingMinutes,
+		CodeLanguages:   codeLanguages(t),
 	}
 }
 
-// Create creates a new tutorial
+// Create creates a new draft tutorial
 func (s *tutorialService) Create(dto domain.CreateTutorialDTO, authorID uint) (*domain.TutorialDetailDTO, error) {
 	// 1. Validate
 	title := strings.TrimSpace(dto.Title)
 	content := strings.TrimSpace(dto.Content)
-	slug := generateSlug(title)
+	if title == "" {
+		return nil, errors.New("title cannot be empty")
+	}
+	if content == "" {
+		return nil, errors.New("content cannot be empty")
+	}
 
-	// 2. Check duplicate slug
-	existing, err := s.repo.FindBySlug(slug)
+	// 2. Pick a free slug (same titles get -2, -3, ...)
+	tutorialSlug, err := s.uniqueSlug(title, 0)
 	if err != nil {
 		return nil, err
 	}
-	if existing != nil {
-		return nil, errors.New("a tutorial with a similar title already exists")
-	}
 
 	// 3. Create entity
 	tutorial := &domain.Tutorial{
-		Title:       title,
-		Content:     content,
-		Slug:        slug,
-		AuthorID:    authorID,
-		Views:       0,
-		IsPublished: true,
+		Title:    title,
+		Content:  content,
+		Slug:     tutorialSlug,
+		AuthorID: authorID,
+		Views:    0,
+		Status:   domain.TutorialStatusDraft,
 	}
+	applyStats(tutorial)
 
-	// 4. Save
-	if err := s.repo.Create(tutorial); err != nil {
+	// 4. Save with the first revision
+	revision := &domain.TutorialRevision{
+		Title:    title,
+		Content:  content,
+		EditorID: authorID,
+		Summary:  "Initial version",
+	}
+	if err := s.repo.Create(tutorial, revision); err != nil {
 		return nil, err
 	}
 
@@ -149,69 +178,139 @@ func (s *tutorialService) Create(dto domain.CreateTutorialDTO, authorID uint) (*
 	return toDetailDTO(created), nil
 }
 
-// FindAll retrieves all tutorials
-func (s *tutorialService) FindAll() ([]domain.TutorialListItemDTO, error) {
-	tutorials, err := s.repo.FindAll()
+// FindPublishedByTag retrieves published tutorials with the tag
+func (s *tutorialService) FindPublishedByTag(tagID uint) ([]domain.TutorialListItemDTO, error) {
+	tutorials, err := s.repo.FindPublishedByTag(tagID)
 	if err != nil {
 		return nil, err
 	}
-
-	result := make([]domain.TutorialListItemDTO, len(tutorials))
-	for i := range tutorials {
-		result[i] = toListItemDTO(&tutorials[i])
-	}
-	return result, nil
+	return toListItemDTOList(tutorials), nil
 }
 
 // FindOne retrieves a tutorial by ID
-func (s *tutorialService) FindOne(id uint) (*domain.TutorialDetailDTO, error) {
+func (s *tutorialService) FindOne(id uint, query domain.TutorialDetailQuery, actor domain.Actor) (*domain.TutorialDetailDTO, error) {
 	tutorial, err := s.repo.FindOneWithTags(id)
 	if err != nil {
 		return nil, err
 	}
-	if tutorial == nil {
+	if tutorial == nil || !tutorial.VisibleTo(actor) {
 		return nil, errors.New("tutorial not found")
 	}
-	return toDetailDTO(tutorial), nil
+	s.recordView(tutorial, query.Viewer)
+	return s.toFormattedDTO(tutorial, query.Format, actor)
 }
 
 // FindBySlug retrieves a tutorial by slug
-func (s *tutorialService) FindBySlug(slug string) (*domain.TutorialDetailDTO, error) {
+func (s *tutorialService) FindBySlug(slug string, query domain.TutorialDetailQuery, actor domain.Actor) (*domain.TutorialDetailDTO, error) {
 	tutorial, err := s.repo.FindBySlugWithTags(slug)
 	if err != nil {
 		return nil, err
 	}
-	if tutorial == nil {
+	if tutorial == nil || !tutorial.VisibleTo(actor) {
 		return nil, errors.New("tutorial not found")
 	}
-	return toDetailDTO(tutorial), nil
+	s.recordView(tutorial, query.Viewer)
+	return s.toFormattedDTO(tutorial, query.Format, actor)
 }
 
-// Update updates a tutorial
-func (s *tutorialService) Update(id uint, dto domain.UpdateTutorialDTO, requesterID uint) (*domain.TutorialDetailDTO, error) {
-	// 1. Check exists
-	existing, err := s.repo.FindOne(id)
+// toFormattedDTO converts the tutorial, adds its series navigation and mentions and renders
+// its content when HTML is requested
+func (s *tutorialService) toFormattedDTO(t *domain.Tutorial, format domain.ContentFormat, actor domain.Actor) (*domain.TutorialDetailDTO, error) {
+	dto := toDetailDTO(t)
+	nav, err := s.seriesNav(t.ID, actor)
 	if err != nil {
 		return nil, err
 	}
-	if existing == nil {
-		return nil, errors.New("tutorial not found")
+	dto.Series = nav
+	if dto.Mentions, err = s.resolveMentions(t.Content); err != nil {
+		return nil, err
+	}
+
+	if format != domain.ContentFormatHTML && format != domain.ContentFormatBoth {
+		return dto, nil
 	}
 
-	// 2. Build update
+	doc, err := s.renderer.Render(t.Content)
+	if err != nil {
+		return nil, err
+	}
+	dto.ContentHTML = doc.HTML
+	dto.TOC = make([]domain.TutorialTOCEntryDTO, len(doc.TOC))
+	for i, heading := range doc.TOC {
+		dto.TOC[i] = domain.TutorialTOCEntryDTO{Level: heading.Level, Text: heading.Text, ID: heading.ID}
+	}
+	if format == domain.ContentFormatHTML {
+		dto.Content = ""
+	}
+	return dto, nil
+}
+
+// resolveMentions returns the accounts mentioned in the content, in order of first mention
+func (s *tutorialService) resolveMentions(content string) ([]domain.MentionDTO, error) {
+	handles := mention.Extract(content)
+	if len(handles) == 0 || s.mentions == nil {
+		return nil, nil
+	}
+	accounts, err := s.mentions.ResolveMentions(handles)
+	if err != nil {
+		return nil, err
+	}
+	var mentions []domain.MentionDTO
+	for _, handle := range handles {
+		if account, ok := accounts[handle]; ok {
+			mentions = append(mentions, account)
+		}
+	}
+	return mentions, nil
+}
+
+// Update updates a tutorial
+func (s *tutorialService) Update(id uint, dto domain.UpdateTutorialDTO, actor domain.Actor) (*domain.TutorialDetailDTO, error) {
+	// 1. Check exists and ownership
+	existing, err := s.findModifiable(id, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Build update (the revision keeps the complete new version)
 	update := &domain.Tutorial{}
+	revision := &domain.TutorialRevision{
+		Title:    existing.Title,
+		Content:  existing.Content,
+		EditorID: actor.ID,
+		Summary:  strings.TrimSpace(dto.Summary),
+	}
 	if dto.Title != nil {
 		title := strings.TrimSpace(*dto.Title)
+		if title == "" {
+			return nil, errors.New("title cannot be empty")
+		}
+		tutorialSlug, err := s.uniqueSlug(title, id)
+		if err != nil {
+			return nil, err
+		}
 		update.Title = title
-		update.Slug = generateSlug(title)
+		update.Slug = tutorialSlug
+		revision.Title = title
 	}
 	if dto.Content != nil {
 		update.Content = strings.TrimSpace(*dto.Content)
+		if update.Content == "" {
+			return nil, errors.New("content cannot be empty")
+		}
+		revision.Content = update.Content
+		applyStats(update)
 	}
 
-	// 3. Update
-	if err := s.repo.Update(id, update); err != nil {
-		return nil, err
+	// 3. Update, unless nothing changed (no empty revisions). Authors' edits to published or
+	// scheduled tutorials go back to review, so they cannot skip moderation.
+	if revision.Title != existing.Title || revision.Content != existing.Content {
+		if !actor.CanModerate() && (existing.Status == domain.TutorialStatusPublished || existing.PublishAt != nil) {
+			update.Status = domain.TutorialStatusInReview
+		}
+		if err := s.repo.Update(id, update, revision); err != nil {
+			return nil, err
+		}
 	}
 
 	// 4. Fetch updated
@@ -224,7 +323,7 @@ func (s *tutorialService) Update(id uint, dto domain.UpdateTutorialDTO, requeste
 }
 
 // Remove deletes a tutorial
-func (s *tutorialService) Remove(id uint, requesterID uint) error {
+func (s *tutorialService) Remove(id uint, actor domain.Actor) error {
 	existing, err := s.repo.FindOne(id)
 	if err != nil {
 		return err
@@ -232,5 +331,31 @@ func (s *tutorialService) Remove(id uint, requesterID uint) error {
 	if existing == nil {
 		return errors.New("tutorial not found")
 	}
+	if !actor.CanModify(existing.AuthorID) {
+		return errors.New("forbidden")
+	}
 	return s.repo.Delete(id)
 }
+
+// FindPublishedByAuthor retrieves a page of an author's published tutorials
+func (s *tutorialService) FindPublishedByAuthor(authorID uint, params domain.AuthorContentParams) (*domain.AuthorTutorialsDTO, error) {
+	limit := params.PageLimit()
+
+	// Fetch one extra to determine if there's more
+	tutorials, err := s.repo.FindPublishedByAuthor(authorID, params.Cursor, limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	var nextCursor *uint
+	if len(tutorials) > limit {
+		tutorials = tutorials[:limit]
+		nextCursor = &tutorials[limit-1].ID
+	}
+
+	items := make([]domain.TutorialListItemDTO, len(tutorials))
+	for i := range tutorials {
+		items[i] = toListItemDTO(&tutorials[i])
+	}
+	return &domain.AuthorTutorialsDTO{Items: items, NextCursor: nextCursor}, nil
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"api_go/internal/domain"
+)
+
+type fakeEditRepo struct {
+	domain.TutorialRepository
+	tutorial *domain.Tutorial
+	update   *domain.Tutorial
+}
+
+func (r *fakeEditRepo) FindOne(id uint) (*domain.Tutorial, error) {
+	if id != r.tutorial.ID {
+		return nil, nil
+	}
+	copied := *r.tutorial
+	return &copied, nil
+}
+
+func (r *fakeEditRepo) FindOneWithTags(id uint) (*domain.Tutorial, error) {
+	return r.FindOne(id)
+}
+
+func (r *fakeEditRepo) Update(id uint, update *domain.Tutorial, revision *domain.TutorialRevision) error {
+	r.update = update
+	return nil
+}
+
+func newEditService(status domain.TutorialStatus, publishAt *time.Time) (*tutorialService, *fakeEditRepo) {
+	tutorial := &domain.Tutorial{Title: "Go", Content: "old", AuthorID: 1, Status: status, PublishAt: publishAt}
+	tutorial.ID = 5
+	repo := &fakeEditRepo{tutorial: tutorial}
+	return &tutorialService{repo: repo}, repo
+}
+
+func TestUpdateSendsAuthorEditsBackToReview(t *testing.T) {
+	author := domain.Actor{ID: 1, Role: domain.AccountRoleUser}
+	moderator := domain.Actor{ID: 2, Role: domain.AccountRoleMod}
+	scheduled := time.Now().Add(time.Hour)
+	content := "new"
+
+	tests := []struct {
+		name      string
+		status    domain.TutorialStatus
+		publishAt *time.Time
+		actor     domain.Actor
+		want      domain.TutorialStatus
+	}{
+		{"author edits published", domain.TutorialStatusPublished, nil, author, domain.TutorialStatusInReview},
+		{"author edits scheduled draft", domain.TutorialStatusDraft, &scheduled, author, domain.TutorialStatusInReview},
+		{"author edits draft", domain.TutorialStatusDraft, nil, author, ""},
+		{"moderator edits published", domain.TutorialStatusPublished, nil, moderator, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, repo := newEditService(tt.status, tt.publishAt)
+			if _, err := s.Update(5, domain.UpdateTutorialDTO{Content: &content}, tt.actor); err != nil {
+				t.Fatalf("Update: %v", err)
+			}
+			if repo.update == nil {
+				t.Fatal("Update did not write the change")
+			}
+			if repo.update.Status != tt.want {
+				t.Errorf("status = %q, want %q", repo.update.Status, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpdateHidesOthersUnpublishedTutorials(t *testing.T) {
+	s, _ := newEditService(domain.TutorialStatusDraft, nil)
+	content := "new"
+
+	_, err := s.Update(5, domain.UpdateTutorialDTO{Content: &content}, domain.Actor{ID: 3, Role: domain.AccountRoleUser})
+	if err == nil || err.Error() != "tutorial not found" {
+		t.Errorf("draft of another author: err = %v, want tutorial not found", err)
+	}
+
+	s, _ = newEditService(domain.TutorialStatusPublished, nil)
+	_, err = s.Update(5, domain.UpdateTutorialDTO{Content: &content}, domain.Actor{ID: 3, Role: domain.AccountRoleUser})
+	if err == nil || err.Error() != "forbidden" {
+		t.Errorf("published tutorial of another author: err = %v, want forbidden", err)
+	}
+}
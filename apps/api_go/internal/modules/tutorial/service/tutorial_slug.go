@@ -0,0 +1,48 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"api_go/internal/domain"
+	"api_go/internal/slug"
+)
+
+// fallbackSlug is used for titles without any letter or digit slug.Make keeps
+const fallbackSlug = "tutorial"
+
+// ResolveSlug finds where an old slug points to now
+func (s *tutorialService) ResolveSlug(oldSlug string, actor domain.Actor) (string, error) {
+	tutorial, err := s.repo.FindBySlugHistory(oldSlug)
+	if err != nil {
+		return "", err
+	}
+	if tutorial == nil || !tutorial.VisibleTo(actor) {
+		return "", errors.New("tutorial not found")
+	}
+	return tutorial.Slug, nil
+}
+
+// uniqueSlug derives the slug of a title, adding -2, -3, ... when other tutorials hold it
+// (now or in their slug history). tutorialID is the tutorial being renamed, 0 on create.
+func (s *tutorialService) uniqueSlug(title string, tutorialID uint) (string, error) {
+	base := slug.Make(title)
+	if base == "" {
+		base = fallbackSlug
+	}
+
+	taken, err := s.repo.FindTakenSlugs(base, tutorialID)
+	if err != nil {
+		return "", err
+	}
+	used := make(map[string]bool, len(taken))
+	for _, t := range taken {
+		used[t] = true
+	}
+
+	candidate := base
+	for n := 2; used[candidate]; n++ {
+		candidate = fmt.Sprintf("%s-%d", base, n)
+	}
+	return candidate, nil
+}
@@ -0,0 +1,47 @@
+package service
+
+import (
+	"api_go/internal/domain"
+	"api_go/internal/markdown"
+)
+
+// statsBatchSize is the number of tutorials loaded per BackfillStats round
+const statsBatchSize = 200
+
+// applyStats computes the word count, reading time and code languages of t.Content
+func applyStats(t *domain.Tutorial) {
+	stats := markdown.Analyze(t.Content)
+	t.WordCount = stats.Words
+	t.ReadingMinutes = stats.ReadingMinutes
+	t.CodeLanguages = stats.CodeLanguages
+}
+
+// BackfillStats computes the statistics of tutorials that have none
+func (s *tutorialService) BackfillStats() (int, error) {
+	updated := 0
+	for {
+		tutorials, err := s.repo.FindMissingStats(statsBatchSize)
+		if err != nil {
+			return updated, err
+		}
+		if len(tutorials) == 0 {
+			return updated, nil
+		}
+
+		for i := range tutorials {
+			applyStats(&tutorials[i])
+			if err := s.repo.UpdateStats(tutorials[i].ID, &tutorials[i]); err != nil {
+				return updated, err
+			}
+			updated++
+		}
+	}
+}
+
+// codeLanguages returns the languages of t, never nil
+func codeLanguages(t *domain.Tutorial) []string {
+	if t.CodeLanguages == nil {
+		return []string{}
+	}
+	return t.CodeLanguages
+}
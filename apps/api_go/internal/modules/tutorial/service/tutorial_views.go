@@ -0,0 +1,82 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"api_go/internal/domain"
+)
+
+const (
+	defaultViewStatsDays = 30
+	maxViewStatsDays     = 366
+	dayLayout            = "2006-01-02"
+)
+
+// recordView counts a view of a published tutorial (drafts being edited do not count)
+func (s *tutorialService) recordView(tutorial *domain.Tutorial, viewer string) {
+	if viewer == "" || tutorial.Status != domain.TutorialStatusPublished {
+		return
+	}
+	s.views.Record(tutorial.ID, viewer)
+}
+
+// FindViewStats returns one entry per day of the range, days without views included
+func (s *tutorialService) FindViewStats(id uint, query domain.TutorialViewsQuery, actor domain.Actor) (*domain.TutorialViewStatsDTO, error) {
+	if _, err := s.findModifiable(id, actor); err != nil {
+		return nil, err
+	}
+
+	from, to, err := viewStatsRange(query, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.viewRepo.FindDaily(id, from, to)
+	if err != nil {
+		return nil, err
+	}
+	byDay := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		byDay[row.Day.UTC().Format(dayLayout)] = row.Views
+	}
+
+	stats := &domain.TutorialViewStatsDTO{
+		TutorialID: id,
+		From:       from.Format(dayLayout),
+		To:         to.Format(dayLayout),
+		Days:       []domain.TutorialViewDayDTO{},
+	}
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		date := day.Format(dayLayout)
+		stats.Days = append(stats.Days, domain.TutorialViewDayDTO{Date: date, Views: byDay[date]})
+		stats.Total += byDay[date]
+	}
+	return stats, nil
+}
+
+// viewStatsRange parses the requested days; to defaults to today and from to 30 days before to
+func viewStatsRange(query domain.TutorialViewsQuery, now time.Time) (time.Time, time.Time, error) {
+	to := utcDay(now)
+	if query.To != "" {
+		parsed, err := time.Parse(dayLayout, query.To)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.New("invalid date range")
+		}
+		to = parsed
+	}
+
+	from := to.AddDate(0, 0, -(defaultViewStatsDays - 1))
+	if query.From != "" {
+		parsed, err := time.Parse(dayLayout, query.From)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.New("invalid date range")
+		}
+		from = parsed
+	}
+
+	if from.After(to) || to.Sub(from) >= maxViewStatsDays*24*time.Hour {
+		return time.Time{}, time.Time{}, errors.New("invalid date range")
+	}
+	return from, to, nil
+}
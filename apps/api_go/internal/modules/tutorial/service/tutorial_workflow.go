@@ -0,0 +1,127 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"api_go/internal/domain"
+)
+
+// FindMine retrieves the author's tutorials in every status
+func (s *tutorialService) FindMine(authorID uint) ([]domain.TutorialListItemDTO, error) {
+	tutorials, err := s.repo.FindByAuthor(authorID)
+	if err != nil {
+		return nil, err
+	}
+	return toListItemDTOList(tutorials), nil
+}
+
+// FindInReview retrieves the moderation queue
+func (s *tutorialService) FindInReview() ([]domain.TutorialListItemDTO, error) {
+	tutorials, err := s.repo.FindByStatus(domain.TutorialStatusInReview)
+	if err != nil {
+		return nil, err
+	}
+	return toListItemDTOList(tutorials), nil
+}
+
+// Submit moves a draft to review
+func (s *tutorialService) Submit(id uint, actor domain.Actor) (*domain.TutorialDetailDTO, error) {
+	tutorial, err := s.findModifiable(id, actor)
+	if err != nil {
+		return nil, err
+	}
+	return s.transition(tutorial, domain.TutorialStatusInReview)
+}
+
+// Publish publishes the tutorial, or records the publication time when it lies in the future.
+// A scheduled tutorial keeps its status until the scheduler publishes it.
+func (s *tutorialService) Publish(id uint, dto domain.PublishTutorialDTO, actor domain.Actor) (*domain.TutorialDetailDTO, error) {
+	if !actor.CanModerate() {
+		return nil, errors.New("forbidden")
+	}
+	tutorial, err := s.findModifiable(id, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	if dto.PublishAt != nil && dto.PublishAt.After(time.Now()) {
+		// The scheduler only picks up drafts and tutorials in review
+		if tutorial.Status != domain.TutorialStatusDraft && tutorial.Status != domain.TutorialStatusInReview {
+			return nil, errors.New("invalid status transition")
+		}
+		if err := s.repo.UpdateStatus(tutorial.ID, tutorial.Status, dto.PublishAt, tutorial.PublishedAt); err != nil {
+			return nil, err
+		}
+		return s.reload(tutorial.ID)
+	}
+
+	return s.transition(tutorial, domain.TutorialStatusPublished)
+}
+
+// Unpublish moves the tutorial back to draft (for a scheduled draft it only cancels the schedule)
+func (s *tutorialService) Unpublish(id uint, actor domain.Actor) (*domain.TutorialDetailDTO, error) {
+	tutorial, err := s.findModifiable(id, actor)
+	if err != nil {
+		return nil, err
+	}
+	if tutorial.Status == domain.TutorialStatusDraft && tutorial.PublishAt != nil {
+		if err := s.repo.UpdateStatus(tutorial.ID, tutorial.Status, nil, tutorial.PublishedAt); err != nil {
+			return nil, err
+		}
+		return s.reload(tutorial.ID)
+	}
+	return s.transition(tutorial, domain.TutorialStatusDraft)
+}
+
+// Archive retires a published tutorial
+func (s *tutorialService) Archive(id uint, actor domain.Actor) (*domain.TutorialDetailDTO, error) {
+	tutorial, err := s.findModifiable(id, actor)
+	if err != nil {
+		return nil, err
+	}
+	return s.transition(tutorial, domain.TutorialStatusArchived)
+}
+
+// PublishDue publishes tutorials whose scheduled time has passed
+func (s *tutorialService) PublishDue() (int64, error) {
+	return s.repo.PublishDue(time.Now())
+}
+
+// findModifiable loads a tutorial the actor may change (unpublished tutorials of others look missing)
+func (s *tutorialService) findModifiable(id uint, actor domain.Actor) (*domain.Tutorial, error) {
+	tutorial, err := s.findVisible(id, actor)
+	if err != nil {
+		return nil, err
+	}
+	if !actor.CanModify(tutorial.AuthorID) {
+		return nil, errors.New("forbidden")
+	}
+	return tutorial, nil
+}
+
+// transition applies a workflow step; any scheduled publication is cancelled
+func (s *tutorialService) transition(tutorial *domain.Tutorial, next domain.TutorialStatus) (*domain.TutorialDetailDTO, error) {
+	if !tutorial.Status.CanTransitionTo(next) {
+		return nil, errors.New("invalid status transition")
+	}
+
+	publishedAt := tutorial.PublishedAt
+	if next == domain.TutorialStatusPublished && publishedAt == nil {
+		now := time.Now()
+		publishedAt = &now
+	}
+
+	if err := s.repo.UpdateStatus(tutorial.ID, next, nil, publishedAt); err != nil {
+		return nil, err
+	}
+	return s.reload(tutorial.ID)
+}
+
+func (s *tutorialService) reload(id uint) (*domain.TutorialDetailDTO, error) {
+	tutorial, err := s.repo.FindOneWithTags(id)
+	if err != nil {
+		return nil, err
+	}
+	return toDetailDTO(tutorial), nil
+}
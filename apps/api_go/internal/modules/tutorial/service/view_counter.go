@@ -0,0 +1,143 @@
+package service
+
+import (
+	"container/list"
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"api_go/internal/domain"
+)
+
+type viewerKey struct {
+	tutorialID uint
+	viewer     string
+}
+
+type seenEntry struct {
+	key  viewerKey
+	last time.Time // last counted view
+}
+
+type dayKey struct {
+	tutorialID uint
+	day        time.Time
+}
+
+// ViewCounter deduplicates tutorial views per viewer within a window and buffers the
+// increments in memory; Run flushes them in batches. Deduplication is per instance.
+//
+// At most maxSeen viewers are remembered; when a new viewer arrives with the map full, the one
+// counted longest ago is forgotten. A client rotating its User-Agent cannot grow memory or stop
+// counting for others, it can only make the oldest viewers count again a little early.
+type ViewCounter struct {
+	repo     domain.TutorialViewRepository
+	window   time.Duration
+	interval time.Duration
+	maxSeen  int
+
+	mu      sync.Mutex
+	seen    map[viewerKey]*list.Element
+	order   *list.List // of *seenEntry; front is the most recently counted
+	pending map[dayKey]int64
+}
+
+// NewViewCounter creates a counter ignoring repeated views within window, remembering up to
+// maxSeen viewers and flushing every interval
+func NewViewCounter(repo domain.TutorialViewRepository, window, interval time.Duration, maxSeen int) *ViewCounter {
+	return &ViewCounter{
+		repo:     repo,
+		window:   window,
+		interval: interval,
+		maxSeen:  maxSeen,
+		seen:     make(map[viewerKey]*list.Element),
+		order:    list.New(),
+		pending:  make(map[dayKey]int64),
+	}
+}
+
+// Record counts a view unless the viewer already viewed the tutorial within the window
+func (vc *ViewCounter) Record(tutorialID uint, viewer string) bool {
+	now := time.Now()
+	key := viewerKey{tutorialID: tutorialID, viewer: viewer}
+
+	vc.mu.Lock()
+	defer vc.mu.Unlock()
+
+	// Entries left after pruning are all within the window
+	vc.pruneSeen(now)
+	if _, ok := vc.seen[key]; ok {
+		return false
+	}
+	vc.seen[key] = vc.order.PushFront(&seenEntry{key: key, last: now})
+	for vc.order.Len() > vc.maxSeen {
+		vc.forget(vc.order.Back())
+	}
+	vc.pending[dayKey{tutorialID: tutorialID, day: utcDay(now)}]++
+	return true
+}
+
+// Run flushes buffered views every interval and once more when ctx is cancelled
+func (vc *ViewCounter) Run(ctx context.Context) {
+	ticker := time.NewTicker(vc.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			vc.Flush()
+			return
+		case <-ticker.C:
+			vc.Flush()
+		}
+	}
+}
+
+// Flush writes the buffered views; on failure they are kept for the next flush
+func (vc *ViewCounter) Flush() {
+	vc.mu.Lock()
+	pending := vc.pending
+	vc.pending = make(map[dayKey]int64)
+	vc.pruneSeen(time.Now())
+	vc.mu.Unlock()
+
+	if len(pending) == 0 {
+		return
+	}
+
+	counts := make([]domain.TutorialViewDaily, 0, len(pending))
+	for key, views := range pending {
+		counts = append(counts, domain.TutorialViewDaily{TutorialID: key.tutorialID, Day: key.day, Views: views})
+	}
+	if err := vc.repo.AddViews(counts); err != nil {
+		log.Printf("flushing %d tutorial view counts failed: %v", len(counts), err)
+		vc.mu.Lock()
+		for key, views := range pending {
+			vc.pending[key] += views
+		}
+		vc.mu.Unlock()
+	}
+}
+
+// pruneSeen forgets viewers whose window has passed, oldest first (caller holds mu)
+func (vc *ViewCounter) pruneSeen(now time.Time) {
+	for oldest := vc.order.Back(); oldest != nil; oldest = vc.order.Back() {
+		if now.Sub(oldest.Value.(*seenEntry).last) < vc.window {
+			return
+		}
+		vc.forget(oldest)
+	}
+}
+
+// forget removes a remembered viewer (caller holds mu)
+func (vc *ViewCounter) forget(element *list.Element) {
+	vc.order.Remove(element)
+	delete(vc.seen, element.Value.(*seenEntry).key)
+}
+
+// utcDay truncates t to midnight UTC
+func utcDay(t time.Time) time.Time {
+	y, m, d := t.UTC().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
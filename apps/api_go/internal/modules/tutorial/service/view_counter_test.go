@@ -0,0 +1,70 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestViewCounterDeduplicatesWithinWindow(t *testing.T) {
+	vc := NewViewCounter(nil, time.Hour, time.Minute, 10)
+
+	if !vc.Record(1, "alice") {
+		t.Fatal("first view must count")
+	}
+	if vc.Record(1, "alice") {
+		t.Error("repeated view within the window must not count")
+	}
+	if !vc.Record(2, "alice") {
+		t.Error("a view of another tutorial must count")
+	}
+}
+
+func TestViewCounterEvictsOldestViewerWhenFull(t *testing.T) {
+	vc := NewViewCounter(nil, time.Hour, time.Minute, 3)
+
+	for i := 0; i < 3; i++ {
+		if !vc.Record(1, fmt.Sprintf("viewer-%d", i)) {
+			t.Fatalf("view %d must count", i)
+		}
+	}
+	// A client rotating its identity cannot stop counting for everyone else
+	for i := 3; i < 100; i++ {
+		if !vc.Record(1, fmt.Sprintf("rotating-%d", i)) {
+			t.Fatalf("view %d with a full map must still count", i)
+		}
+	}
+	if len(vc.seen) != 3 || vc.order.Len() != 3 {
+		t.Errorf("seen has %d entries (list %d), want 3", len(vc.seen), vc.order.Len())
+	}
+	if !vc.Record(2, "reader") {
+		t.Error("a new reader must count while the map is full")
+	}
+	if vc.Record(2, "reader") {
+		t.Error("the newest viewer must still be deduplicated")
+	}
+	if _, ok := vc.seen[viewerKey{tutorialID: 1, viewer: "viewer-0"}]; ok {
+		t.Error("the oldest viewer must have been evicted")
+	}
+	if views := vc.pending[dayKey{tutorialID: 1, day: utcDay(time.Now())}]; views != 100 {
+		t.Errorf("pending views = %d, want 100", views)
+	}
+}
+
+func TestViewCounterPrunesExpiredViewersOnRecord(t *testing.T) {
+	vc := NewViewCounter(nil, time.Hour, time.Minute, 10)
+	vc.Record(1, "alice")
+	vc.Record(1, "bob")
+
+	// alice's view falls out of the window; Record forgets her without waiting for a flush
+	vc.order.Back().Value.(*seenEntry).last = time.Now().Add(-2 * time.Hour)
+	if !vc.Record(1, "carol") {
+		t.Fatal("a new viewer must count")
+	}
+	if _, ok := vc.seen[viewerKey{tutorialID: 1, viewer: "alice"}]; ok {
+		t.Error("the expired viewer must be pruned")
+	}
+	if !vc.Record(1, "alice") {
+		t.Error("a view after the window must count again")
+	}
+}
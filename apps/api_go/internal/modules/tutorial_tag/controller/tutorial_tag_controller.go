@@ -0,0 +1,240 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"api_go/internal/domain"
+	"api_go/internal/modules/auth/middleware"
+)
+
+type TutorialTagController struct {
+	service domain.TutorialTagService
+}
+
+// NewTutorialTagController creates a new TutorialTagController instance
+func NewTutorialTagController(service domain.TutorialTagService) *TutorialTagController {
+	return &TutorialTagController{service: service}
+}
+
+// RegisterRoutes registers all tutorial-tag routes
+func (ctrl *TutorialTagController) RegisterRoutes(r *gin.RouterGroup, policy *middleware.RoutePolicy) {
+	// tutorial-tags endpoints
+	tutorialTags := r.Group("/tutorial-tags")
+	{
+		tutorialTags.POST("", policy.Authenticated(domain.ScopeTutorialsWrite), ctrl.AttachOne)
+		tutorialTags.DELETE("/:tutorialId/:tagId", policy.Authenticated(domain.ScopeTutorialsWrite), ctrl.DetachOne)
+	}
+
+	// Nested endpoints under /tutorials
+	r.PATCH("/tutorials/:id/tags", policy.Authenticated(domain.ScopeTutorialsWrite), ctrl.UpsertForTutorial)
+	r.GET("/tutorials/:id/tags", policy.Public(), ctrl.FindTagsByTutorial)
+
+	// Nested endpoint under /tags
+	r.GET("/tags/:id/tutorials", ctrl.FindTutorialsByTag)
+}
+
+// AttachOne handles POST /tutorial-tags
+// @Summary Attach a tag to a tutorial
+// @Description Create a new tutorial-tag mapping (author or moderator)
+// @Tags tutorial-tags
+// @Accept json
+// @Produce json
+// @Param dto body domain.CreateTutorialTagDTO true "Create TutorialTag DTO"
+// @Success 201 {object} domain.TutorialTagResponseDTO
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Security BearerAuth
+// @Router /tutorial-tags [post]
+func (ctrl *TutorialTagController) AttachOne(c *gin.Context) {
+	var dto domain.CreateTutorialTagDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	actor, ok := middleware.GetActor(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	result, err := ctrl.service.AttachOne(dto, actor)
+	if err != nil {
+		switch err.Error() {
+		case "tutorial not found", "tag not found":
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case "forbidden":
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case "mapping already exists":
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+// DetachOne handles DELETE /tutorial-tags/:tutorialId/:tagId
+// @Summary Detach a tag from a tutorial
+// @Description Remove a tutorial-tag mapping (author or moderator)
+// @Tags tutorial-tags
+// @Param tutorialId path int true "Tutorial ID"
+// @Param tagId path int true "Tag ID"
+// @Success 200 {object} map[string]bool
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Security BearerAuth
+// @Router /tutorial-tags/{tutorialId}/{tagId} [delete]
+func (ctrl *TutorialTagController) DetachOne(c *gin.Context) {
+	tutorialID, err := strconv.ParseUint(c.Param("tutorialId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid tutorial id"})
+		return
+	}
+
+	tagID, err := strconv.ParseUint(c.Param("tagId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid tag id"})
+		return
+	}
+
+	actor, ok := middleware.GetActor(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	if err := ctrl.service.DetachOne(uint(tutorialID), uint(tagID), actor); err != nil {
+		switch err.Error() {
+		case "tutorial not found":
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case "forbidden":
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"deleted": true})
+}
+
+// UpsertForTutorial handles PATCH /tutorials/:id/tags
+// @Summary Update all tags for a tutorial
+// @Description Replace all tags of a tutorial with a new set (author or moderator)
+// @Tags tutorial-tags
+// @Accept json
+// @Produce json
+// @Param id path int true "Tutorial ID"
+// @Param body body object true "Tag IDs" example({"tagIds": [1, 2, 3]})
+// @Success 200 {array} domain.TagResponseDTO
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Security BearerAuth
+// @Router /tutorials/{id}/tags [patch]
+func (ctrl *TutorialTagController) UpsertForTutorial(c *gin.Context) {
+	tutorialID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid tutorial id"})
+		return
+	}
+
+	var body struct {
+		TagIDs []uint `json:"tagIds" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	actor, ok := middleware.GetActor(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	dto := domain.UpsertTutorialTagsDTO{
+		TutorialID: uint(tutorialID),
+		TagIDs:     body.TagIDs,
+	}
+
+	tags, err := ctrl.service.UpsertForTutorial(dto, actor)
+	if err != nil {
+		switch err.Error() {
+		case "tutorial not found", "one or more tags not found":
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case "forbidden":
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, tags)
+}
+
+// FindTagsByTutorial handles GET /tutorials/:id/tags
+// @Summary Get all tags for a tutorial
+// @Description Retrieve all tags associated with a specific tutorial
+// @Tags tutorial-tags
+// @Produce json
+// @Param id path int true "Tutorial ID"
+// @Success 200 {array} domain.TagResponseDTO
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /tutorials/{id}/tags [get]
+func (ctrl *TutorialTagController) FindTagsByTutorial(c *gin.Context) {
+	tutorialID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid tutorial id"})
+		return
+	}
+
+	actor, _ := middleware.GetActor(c)
+	tags, err := ctrl.service.FindTagsByTutorial(uint(tutorialID), actor)
+	if err != nil {
+		if err.Error() == "tutorial not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, tags)
+}
+
+// FindTutorialsByTag handles GET /tags/:id/tutorials
+// @Summary Get all tutorials for a tag
+// @Description Retrieve the published tutorials associated with a specific tag
+// @Tags tutorial-tags
+// @Produce json
+// @Param id path int true "Tag ID"
+// @Success 200 {array} domain.TutorialListItemDTO
+// @Failure 400 {object} map[string]string
+// @Router /tags/{id}/tutorials [get]
+func (ctrl *TutorialTagController) FindTutorialsByTag(c *gin.Context) {
+	tagID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid tag id"})
+		return
+	}
+
+	tutorials, err := ctrl.service.FindTutorialsByTag(uint(tagID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, tutorials)
+}
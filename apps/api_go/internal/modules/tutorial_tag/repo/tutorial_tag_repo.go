@@ -0,0 +1,56 @@
+package repo
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+
+	"api_go/internal/domain"
+)
+
+type tutorialTagRepository struct {
+	db *gorm.DB
+}
+
+// NewTutorialTagRepository creates a new TutorialTagRepository instance
+func NewTutorialTagRepository(db *gorm.DB) domain.TutorialTagRepository {
+	return &tutorialTagRepository{db: db}
+}
+
+// Create inserts a new tutorial_tag into the database
+func (r *tutorialTagRepository) Create(tutorialTag *domain.TutorialTag) error {
+	return r.db.Create(tutorialTag).Error
+}
+
+// Delete removes a tutorial_tag by tutorial_id and tag_id
+func (r *tutorialTagRepository) Delete(tutorialID, tagID uint) error {
+	return r.db.Where("tutorial_id = ? AND tag_id = ?", tutorialID, tagID).Delete(&domain.TutorialTag{}).Error
+}
+
+// FindByTutorialID retrieves all tutorial_tags for a tutorial
+func (r *tutorialTagRepository) FindByTutorialID(tutorialID uint) ([]domain.TutorialTag, error) {
+	var tutorialTags []domain.TutorialTag
+	err := r.db.Preload("Tag").Where("tutorial_id = ?", tutorialID).Order("id").Find(&tutorialTags).Error
+	return tutorialTags, err
+}
+
+// FindOne retrieves a specific tutorial_tag
+func (r *tutorialTagRepository) FindOne(tutorialID, tagID uint) (*domain.TutorialTag, error) {
+	var tutorialTag domain.TutorialTag
+	err := r.db.Where("tutorial_id = ? AND tag_id = ?", tutorialID, tagID).First(&tutorialTag).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &tutorialTag, nil
+}
+
+// BulkCreate inserts multiple tutorial_tags
+func (r *tutorialTagRepository) BulkCreate(tutorialTags []domain.TutorialTag) error {
+	if len(tutorialTags) == 0 {
+		return nil
+	}
+	return r.db.Create(&tutorialTags).Error
+}
@@ -0,0 +1,212 @@
+package service
+
+import (
+	"errors"
+
+	"api_go/internal/domain"
+)
+
+type tutorialTagService struct {
+	repo            domain.TutorialTagRepository
+	tutorialRepo    domain.TutorialRepository
+	tagRepo         domain.TagRepository
+	tutorialService domain.TutorialService
+}
+
+// NewTutorialTagService creates a new TutorialTagService instance
+func NewTutorialTagService(
+	repo domain.TutorialTagRepository,
+	tutorialRepo domain.TutorialRepository,
+	tagRepo domain.TagRepository,
+	tutorialService domain.TutorialService,
+) domain.TutorialTagService {
+	return &tutorialTagService{
+		repo:            repo,
+		tutorialRepo:    tutorialRepo,
+		tagRepo:         tagRepo,
+		tutorialService: tutorialService,
+	}
+}
+
+// toTagResponseDTO converts Tag entity to TagResponseDTO
+func toTagResponseDTO(tag *domain.Tag) domain.TagResponseDTO {
+	return domain.TagResponseDTO{
+		ID:          tag.ID,
+		Name:        tag.Name,
+		Description: tag.Description,
+	}
+}
+
+// findModifiableTutorial loads a tutorial and checks the actor is its author or a moderator
+func (s *tutorialTagService) findModifiableTutorial(tutorialID uint, actor domain.Actor) (*domain.Tutorial, error) {
+	tutorial, err := s.tutorialRepo.FindOne(tutorialID)
+	if err != nil {
+		return nil, err
+	}
+	if tutorial == nil || !tutorial.VisibleTo(actor) {
+		return nil, errors.New("tutorial not found")
+	}
+	if !actor.CanModify(tutorial.AuthorID) {
+		return nil, errors.New("forbidden")
+	}
+	return tutorial, nil
+}
+
+// AttachOne attaches a single tag to a tutorial
+func (s *tutorialTagService) AttachOne(dto domain.CreateTutorialTagDTO, actor domain.Actor) (*domain.TutorialTagResponseDTO, error) {
+	// 1. Check tutorial exists and may be modified
+	if _, err := s.findModifiableTutorial(dto.TutorialID, actor); err != nil {
+		return nil, err
+	}
+
+	// 2. Check tag exists
+	tag, err := s.tagRepo.FindOne(dto.TagID)
+	if err != nil {
+		return nil, err
+	}
+	if tag == nil {
+		return nil, errors.New("tag not found")
+	}
+
+	// 3. Check if mapping already exists
+	existing, err := s.repo.FindOne(dto.TutorialID, dto.TagID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, errors.New("mapping already exists")
+	}
+
+	// 4. Create tutorial_tag
+	tutorialTag := &domain.TutorialTag{
+		TutorialID: dto.TutorialID,
+		TagID:      dto.TagID,
+		CreatedBy:  &actor.ID,
+	}
+	if err := s.repo.Create(tutorialTag); err != nil {
+		return nil, err
+	}
+
+	return &domain.TutorialTagResponseDTO{
+		ID:         tutorialTag.ID,
+		TutorialID: tutorialTag.TutorialID,
+		TagID:      tutorialTag.TagID,
+		CreatedAt:  tutorialTag.CreatedAt,
+		CreatedBy:  tutorialTag.CreatedBy,
+	}, nil
+}
+
+// DetachOne removes a tag from a tutorial
+func (s *tutorialTagService) DetachOne(tutorialID, tagID uint, actor domain.Actor) error {
+	if _, err := s.findModifiableTutorial(tutorialID, actor); err != nil {
+		return err
+	}
+	return s.repo.Delete(tutorialID, tagID)
+}
+
+// UpsertForTutorial replaces all tags of a tutorial (idempotent)
+func (s *tutorialTagService) UpsertForTutorial(dto domain.UpsertTutorialTagsDTO, actor domain.Actor) ([]domain.TagResponseDTO, error) {
+	// 1. Check tutorial exists and may be modified
+	if _, err := s.findModifiableTutorial(dto.TutorialID, actor); err != nil {
+		return nil, err
+	}
+
+	// 2. Validate all tagIds exist
+	for _, tagID := range dto.TagIDs {
+		tag, err := s.tagRepo.FindOne(tagID)
+		if err != nil {
+			return nil, err
+		}
+		if tag == nil {
+			return nil, errors.New("one or more tags not found")
+		}
+	}
+
+	// 3. Get current mappings
+	currentMappings, err := s.repo.FindByTutorialID(dto.TutorialID)
+	if err != nil {
+		return nil, err
+	}
+
+	currentTagIDs := make(map[uint]bool)
+	for _, m := range currentMappings {
+		currentTagIDs[m.TagID] = true
+	}
+
+	newTagIDs := make(map[uint]bool)
+	for _, id := range dto.TagIDs {
+		newTagIDs[id] = true
+	}
+
+	// 4. Calculate diff
+	var toAdd []uint
+	for id := range newTagIDs {
+		if !currentTagIDs[id] {
+			toAdd = append(toAdd, id)
+		}
+	}
+
+	var toRemove []uint
+	for id := range currentTagIDs {
+		if !newTagIDs[id] {
+			toRemove = append(toRemove, id)
+		}
+	}
+
+	// 5. Remove old mappings
+	for _, tagID := range toRemove {
+		if err := s.repo.Delete(dto.TutorialID, tagID); err != nil {
+			return nil, err
+		}
+	}
+
+	// 6. Add new mappings
+	if len(toAdd) > 0 {
+		newTutorialTags := make([]domain.TutorialTag, len(toAdd))
+		for i, tagID := range toAdd {
+			newTutorialTags[i] = domain.TutorialTag{
+				TutorialID: dto.TutorialID,
+				TagID:      tagID,
+				CreatedBy:  &actor.ID,
+			}
+		}
+		if err := s.repo.BulkCreate(newTutorialTags); err != nil {
+			return nil, err
+		}
+	}
+
+	// 7. Return final tags
+	return s.findTags(dto.TutorialID)
+}
+
+// FindTagsByTutorial returns all tags of a tutorial the actor can read
+func (s *tutorialTagService) FindTagsByTutorial(tutorialID uint, actor domain.Actor) ([]domain.TagResponseDTO, error) {
+	tutorial, err := s.tutorialRepo.FindOne(tutorialID)
+	if err != nil {
+		return nil, err
+	}
+	if tutorial == nil || !tutorial.VisibleTo(actor) {
+		return nil, errors.New("tutorial not found")
+	}
+	return s.findTags(tutorialID)
+}
+
+// FindTutorialsByTag returns the published tutorials with a tag
+func (s *tutorialTagService) FindTutorialsByTag(tagID uint) ([]domain.TutorialListItemDTO, error) {
+	return s.tutorialService.FindPublishedByTag(tagID)
+}
+
+func (s *tutorialTagService) findTags(tutorialID uint) ([]domain.TagResponseDTO, error) {
+	tutorialTags, err := s.repo.FindByTutorialID(tutorialID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.TagResponseDTO, 0, len(tutorialTags))
+	for _, tt := range tutorialTags {
+		if tt.Tag != nil {
+			result = append(result, toTagResponseDTO(tt.Tag))
+		}
+	}
+	return result, nil
+}
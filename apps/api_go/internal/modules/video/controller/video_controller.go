@@ -7,6 +7,7 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"api_go/internal/domain"
+	"api_go/internal/modules/auth/middleware"
 )
 
 type VideoController struct {
@@ -19,18 +20,18 @@ func NewVideoController(service domain.VideoService) *VideoController {
 }
 
 // RegisterRoutes registers all video routes
-func (ctrl *VideoController) RegisterRoutes(r *gin.RouterGroup) {
+func (ctrl *VideoController) RegisterRoutes(r *gin.RouterGroup, policy *middleware.RoutePolicy) {
 	videos := r.Group("/videos")
 	{
-		videos.POST("", ctrl.Create)
+		videos.POST("", policy.Authenticated(domain.ScopeVideosWrite), ctrl.Create)
 		videos.GET("", ctrl.FindAll)
 		videos.GET("/youtube/:youtubeId", ctrl.FindByYoutubeID)
 		videos.GET("/uploader/:uploaderId", ctrl.FindByUploaderID)
 		videos.GET("/tag/:tagId", ctrl.FindByTag)
 		videos.GET("/tag-name/:tagName", ctrl.FindByTagName)
 		videos.GET("/:id", ctrl.FindOne)
-		videos.PATCH("/:id", ctrl.Update)
-		videos.DELETE("/:id", ctrl.Remove)
+		videos.PATCH("/:id", policy.Authenticated(domain.ScopeVideosWrite), ctrl.Update)
+		videos.DELETE("/:id", policy.Authenticated(domain.ScopeVideosWrite), ctrl.Remove)
 	}
 }
 
@@ -44,6 +45,7 @@ func (ctrl *VideoController) RegisterRoutes(r *gin.RouterGroup) {
 // @Success 201 {object} domain.VideoResponseDTO
 // @Failure 400 {object} map[string]string
 // @Failure 409 {object} map[string]string
+// @Security BearerAuth
 // @Router /videos [post]
 func (ctrl *VideoController) Create(c *gin.Context) {
 	var dto domain.CreateVideoDTO
@@ -52,6 +54,13 @@ func (ctrl *VideoController) Create(c *gin.Context) {
 		return
 	}
 
+	actor, ok := middleware.GetActor(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	dto.UploaderID = &actor.ID
+
 	video, err := ctrl.service.Create(dto)
 	if err != nil {
 		if err.Error() == "video with this YouTube ID already exists" {
@@ -219,7 +228,9 @@ func (ctrl *VideoController) FindByTagName(c *gin.Context) {
 // @Param dto body domain.UpdateVideoDTO true "Update Video DTO"
 // @Success 200 {object} domain.VideoResponseDTO
 // @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
 // @Failure 404 {object} map[string]string
+// @Security BearerAuth
 // @Router /videos/{id} [patch]
 func (ctrl *VideoController) Update(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
@@ -234,11 +245,21 @@ func (ctrl *VideoController) Update(c *gin.Context) {
 		return
 	}
 
-	video, err := ctrl.service.Update(uint(id), dto)
+	actor, ok := middleware.GetActor(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	video, err := ctrl.service.Update(uint(id), dto, actor)
 	if err != nil {
-		if err.Error() == "video not found" {
+		switch err.Error() {
+		case "video not found":
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 			return
+		case "forbidden":
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
 		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -254,7 +275,9 @@ func (ctrl *VideoController) Update(c *gin.Context) {
 // @Param id path int true "Video ID"
 // @Success 200 {object} map[string]bool
 // @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
 // @Failure 404 {object} map[string]string
+// @Security BearerAuth
 // @Router /videos/{id} [delete]
 func (ctrl *VideoController) Remove(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
@@ -263,11 +286,21 @@ func (ctrl *VideoController) Remove(c *gin.Context) {
 		return
 	}
 
-	err = ctrl.service.Remove(uint(id))
+	actor, ok := middleware.GetActor(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	err = ctrl.service.Remove(uint(id), actor)
 	if err != nil {
-		if err.Error() == "video not found" {
+		switch err.Error() {
+		case "video not found":
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 			return
+		case "forbidden":
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
 		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
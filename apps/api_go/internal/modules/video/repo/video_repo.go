@@ -85,3 +85,22 @@ func (r *videoRepository) FindByUploaderID(uploaderID uint) ([]domain.Video, err
 	err := r.db.Where("uploader_id = ?", uploaderID).Order("created_at DESC").Find(&videos).Error
 	return videos, err
 }
+
+// FindPageByUploaderID retrieves a page of an uploader's videos (keyset on ID, newest first)
+func (r *videoRepository) FindPageByUploaderID(uploaderID uint, cursor *uint, limit int) ([]domain.Video, error) {
+	query := r.db.Where("uploader_id = ?", uploaderID).Order("id DESC").Limit(limit)
+	if cursor != nil {
+		query = query.Where("id < ?", *cursor)
+	}
+
+	var videos []domain.Video
+	err := query.Find(&videos).Error
+	return videos, err
+}
+
+// CountByUploaderID counts an uploader's videos
+func (r *videoRepository) CountByUploaderID(uploaderID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&domain.Video{}).Where("uploader_id = ?", uploaderID).Count(&count).Error
+	return count, err
+}
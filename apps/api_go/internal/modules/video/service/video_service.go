@@ -186,8 +186,8 @@ func (s *videoService) FindByYoutubeID(youtubeID string) (*domain.VideoResponseD
 }
 
 // Update updates a video
-func (s *videoService) Update(id uint, dto domain.UpdateVideoDTO) (*domain.VideoResponseDTO, error) {
-	// 1. Check exists
+func (s *videoService) Update(id uint, dto domain.UpdateVideoDTO, actor domain.Actor) (*domain.VideoResponseDTO, error) {
+	// 1. Check exists and ownership
 	existing, err := s.repo.FindOne(id)
 	if err != nil {
 		return nil, err
@@ -195,6 +195,9 @@ func (s *videoService) Update(id uint, dto domain.UpdateVideoDTO) (*domain.Video
 	if existing == nil {
 		return nil, errors.New("video not found")
 	}
+	if !canModifyVideo(existing, actor) {
+		return nil, errors.New("forbidden")
+	}
 
 	// 2. Build update
 	update := &domain.Video{}
@@ -232,7 +235,7 @@ func (s *videoService) Update(id uint, dto domain.UpdateVideoDTO) (*domain.Video
 }
 
 // Remove deletes a video
-func (s *videoService) Remove(id uint) error {
+func (s *videoService) Remove(id uint, actor domain.Actor) error {
 	existing, err := s.repo.FindOne(id)
 	if err != nil {
 		return err
@@ -240,9 +243,21 @@ func (s *videoService) Remove(id uint) error {
 	if existing == nil {
 		return errors.New("video not found")
 	}
+	if !canModifyVideo(existing, actor) {
+		return errors.New("forbidden")
+	}
 	return s.repo.Delete(id)
 }
 
+// canModifyVideo checks owner-or-mod access (videos without uploader are mod-only)
+func canModifyVideo(video *domain.Video, actor domain.Actor) bool {
+	var ownerID uint
+	if video.UploaderID != nil {
+		ownerID = *video.UploaderID
+	}
+	return actor.CanModify(ownerID)
+}
+
 // FindByUploaderID retrieves videos by uploader
 func (s *videoService) FindByUploaderID(uploaderID uint) ([]domain.VideoResponseDTO, error) {
 	videos, err := s.repo.FindByUploaderID(uploaderID)
@@ -267,3 +282,22 @@ func (s *videoService) FindByTagName(tagName string) ([]domain.VideoResponseDTO,
 	}
 	return s.videoTagSvc.FindVideosByTagName(tagName)
 }
+
+// FindPageByUploaderID retrieves a page of an uploader's videos
+func (s *videoService) FindPageByUploaderID(uploaderID uint, params domain.AuthorContentParams) (*domain.AuthorVideosDTO, error) {
+	limit := params.PageLimit()
+
+	// Fetch one extra to determine if there's more
+	videos, err := s.repo.FindPageByUploaderID(uploaderID, params.Cursor, limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	var nextCursor *uint
+	if len(videos) > limit {
+		videos = videos[:limit]
+		nextCursor = &videos[limit-1].ID
+	}
+
+	return &domain.AuthorVideosDTO{Items: toResponseDTOList(videos), NextCursor: nextCursor}, nil
+}
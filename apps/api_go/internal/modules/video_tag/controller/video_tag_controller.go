@@ -7,6 +7,7 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"api_go/internal/domain"
+	"api_go/internal/modules/auth/middleware"
 )
 
 type VideoTagController struct {
@@ -19,16 +20,16 @@ func NewVideoTagController(service domain.VideoTagService) *VideoTagController {
 }
 
 // RegisterRoutes registers all video-tag routes
-func (ctrl *VideoTagController) RegisterRoutes(r *gin.RouterGroup) {
+func (ctrl *VideoTagController) RegisterRoutes(r *gin.RouterGroup, policy *middleware.RoutePolicy) {
 	// video-tags endpoints
 	videoTags := r.Group("/video-tags")
 	{
-		videoTags.POST("", ctrl.AttachOne)
-		videoTags.DELETE("/:videoId/:tagId", ctrl.DetachOne)
+		videoTags.POST("", policy.Authenticated(domain.ScopeVideosWrite), ctrl.AttachOne)
+		videoTags.DELETE("/:videoId/:tagId", policy.Authenticated(domain.ScopeVideosWrite), ctrl.DetachOne)
 	}
 
 	// Nested endpoints under /videos
-	r.PATCH("/videos/:id/tags", ctrl.UpsertForVideo)
+	r.PATCH("/videos/:id/tags", policy.Authenticated(domain.ScopeVideosWrite), ctrl.UpsertForVideo)
 	r.GET("/videos/:id/tags", ctrl.FindTagsByVideo)
 
 	// Nested endpoint under /tags
@@ -41,12 +42,13 @@ func (ctrl *VideoTagController) RegisterRoutes(r *gin.RouterGroup) {
 // @Tags video-tags
 // @Accept json
 // @Produce json
-// @Param X-User-ID header int false "User ID"
 // @Param dto body domain.CreateVideoTagDTO true "Create VideoTag DTO"
 // @Success 201 {object} domain.VideoTagResponseDTO
 // @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
 // @Failure 404 {object} map[string]string
 // @Failure 409 {object} map[string]string
+// @Security BearerAuth
 // @Router /video-tags [post]
 func (ctrl *VideoTagController) AttachOne(c *gin.Context) {
 	var dto domain.CreateVideoTagDTO
@@ -55,20 +57,19 @@ func (ctrl *VideoTagController) AttachOne(c *gin.Context) {
 		return
 	}
 
-	// Get createdBy from header (TODO: from JWT)
-	var createdBy *uint
-	if userIDStr := c.GetHeader("X-User-ID"); userIDStr != "" {
-		if id, err := strconv.ParseUint(userIDStr, 10, 32); err == nil {
-			uid := uint(id)
-			createdBy = &uid
-		}
+	actor, ok := middleware.GetActor(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
 	}
 
-	result, err := ctrl.service.AttachOne(dto, createdBy)
+	result, err := ctrl.service.AttachOne(dto, actor)
 	if err != nil {
 		switch err.Error() {
 		case "video not found", "tag not found":
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case "forbidden":
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
 		case "mapping already exists":
 			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
 		default:
@@ -88,6 +89,9 @@ func (ctrl *VideoTagController) AttachOne(c *gin.Context) {
 // @Param tagId path int true "Tag ID"
 // @Success 200 {object} map[string]bool
 // @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Security BearerAuth
 // @Router /video-tags/{videoId}/{tagId} [delete]
 func (ctrl *VideoTagController) DetachOne(c *gin.Context) {
 	videoID, err := strconv.ParseUint(c.Param("videoId"), 10, 32)
@@ -102,8 +106,21 @@ func (ctrl *VideoTagController) DetachOne(c *gin.Context) {
 		return
 	}
 
-	if err := ctrl.service.DetachOne(uint(videoID), uint(tagID)); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	actor, ok := middleware.GetActor(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	if err := ctrl.service.DetachOne(uint(videoID), uint(tagID), actor); err != nil {
+		switch err.Error() {
+		case "video not found":
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case "forbidden":
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
 		return
 	}
 
@@ -117,11 +134,12 @@ func (ctrl *VideoTagController) DetachOne(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param id path int true "Video ID"
-// @Param X-User-ID header int false "User ID"
 // @Param body body object true "Tag IDs" example({"tagIds": [1, 2, 3]})
 // @Success 200 {array} domain.TagResponseDTO
 // @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
 // @Failure 404 {object} map[string]string
+// @Security BearerAuth
 // @Router /videos/{id}/tags [patch]
 func (ctrl *VideoTagController) UpsertForVideo(c *gin.Context) {
 	videoID, err := strconv.ParseUint(c.Param("id"), 10, 32)
@@ -138,13 +156,10 @@ func (ctrl *VideoTagController) UpsertForVideo(c *gin.Context) {
 		return
 	}
 
-	// Get createdBy from header (TODO: from JWT)
-	var createdBy *uint
-	if userIDStr := c.GetHeader("X-User-ID"); userIDStr != "" {
-		if id, err := strconv.ParseUint(userIDStr, 10, 32); err == nil {
-			uid := uint(id)
-			createdBy = &uid
-		}
+	actor, ok := middleware.GetActor(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
 	}
 
 	dto := domain.UpsertVideoTagsDTO{
@@ -152,11 +167,13 @@ func (ctrl *VideoTagController) UpsertForVideo(c *gin.Context) {
 		TagIDs:  body.TagIDs,
 	}
 
-	tags, err := ctrl.service.UpsertForVideo(dto, createdBy)
+	tags, err := ctrl.service.UpsertForVideo(dto, actor)
 	if err != nil {
 		switch err.Error() {
 		case "video not found", "one or more tags not found":
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case "forbidden":
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
 		default:
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		}
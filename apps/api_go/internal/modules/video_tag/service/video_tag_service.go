@@ -50,10 +50,9 @@ func toVideoResponseDTO(video *domain.Video) domain.VideoResponseDTO {
 	}
 }
 
-// AttachOne attaches a single tag to a video
-func (s *videoTagService) AttachOne(dto domain.CreateVideoTagDTO, createdBy *uint) (*domain.VideoTagResponseDTO, error) {
-	// 1. Check video exists
-	video, err := s.videoRepo.FindOne(dto.VideoID)
+// findModifiableVideo loads a video and checks the actor owns it or is a moderator
+func (s *videoTagService) findModifiableVideo(videoID uint, actor domain.Actor) (*domain.Video, error) {
+	video, err := s.videoRepo.FindOne(videoID)
 	if err != nil {
 		return nil, err
 	}
@@ -61,6 +60,23 @@ func (s *videoTagService) AttachOne(dto domain.CreateVideoTagDTO, createdBy *uin
 		return nil, errors.New("video not found")
 	}
 
+	var ownerID uint
+	if video.UploaderID != nil {
+		ownerID = *video.UploaderID
+	}
+	if !actor.CanModify(ownerID) {
+		return nil, errors.New("forbidden")
+	}
+	return video, nil
+}
+
+// AttachOne attaches a single tag to a video
+func (s *videoTagService) AttachOne(dto domain.CreateVideoTagDTO, actor domain.Actor) (*domain.VideoTagResponseDTO, error) {
+	// 1. Check video exists and may be modified
+	if _, err := s.findModifiableVideo(dto.VideoID, actor); err != nil {
+		return nil, err
+	}
+
 	// 2. Check tag exists
 	tag, err := s.tagRepo.FindOne(dto.TagID)
 	if err != nil {
@@ -83,7 +99,7 @@ func (s *videoTagService) AttachOne(dto domain.CreateVideoTagDTO, createdBy *uin
 	videoTag := &domain.VideoTag{
 		VideoID:   dto.VideoID,
 		TagID:     dto.TagID,
-		CreatedBy: createdBy,
+		CreatedBy: &actor.ID,
 	}
 
 	if err := s.repo.Create(videoTag); err != nil {
@@ -100,20 +116,19 @@ func (s *videoTagService) AttachOne(dto domain.CreateVideoTagDTO, createdBy *uin
 }
 
 // DetachOne removes a tag from a video
-func (s *videoTagService) DetachOne(videoID, tagID uint) error {
+func (s *videoTagService) DetachOne(videoID, tagID uint, actor domain.Actor) error {
+	if _, err := s.findModifiableVideo(videoID, actor); err != nil {
+		return err
+	}
 	return s.repo.Delete(videoID, tagID)
 }
 
 // UpsertForVideo replaces all tags for a video (idempotent)
-func (s *videoTagService) UpsertForVideo(dto domain.UpsertVideoTagsDTO, createdBy *uint) ([]domain.TagResponseDTO, error) {
-	// 1. Check video exists
-	video, err := s.videoRepo.FindOne(dto.VideoID)
-	if err != nil {
+func (s *videoTagService) UpsertForVideo(dto domain.UpsertVideoTagsDTO, actor domain.Actor) ([]domain.TagResponseDTO, error) {
+	// 1. Check video exists and may be modified
+	if _, err := s.findModifiableVideo(dto.VideoID, actor); err != nil {
 		return nil, err
 	}
-	if video == nil {
-		return nil, errors.New("video not found")
-	}
 
 	// 2. Validate all tagIds exist
 	for _, tagID := range dto.TagIDs {
@@ -171,7 +186,7 @@ func (s *videoTagService) UpsertForVideo(dto domain.UpsertVideoTagsDTO, createdB
 			newVideoTags[i] = domain.VideoTag{
 				VideoID:   dto.VideoID,
 				TagID:     tagID,
-				CreatedBy: createdBy,
+				CreatedBy: &actor.ID,
 			}
 		}
 		if err := s.repo.BulkCreate(newVideoTags); err != nil {
@@ -7,6 +7,7 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"api_go/internal/domain"
+	"api_go/internal/modules/auth/middleware"
 )
 
 type VoteController struct {
@@ -19,20 +20,20 @@ func NewVoteController(service domain.VoteService) *VoteController {
 }
 
 // RegisterRoutes registers all vote routes
-func (ctrl *VoteController) RegisterRoutes(r *gin.RouterGroup) {
+func (ctrl *VoteController) RegisterRoutes(r *gin.RouterGroup, policy *middleware.RoutePolicy) {
 	votes := r.Group("/votes")
 	{
-		votes.POST("", ctrl.Create)
-		votes.POST("/change", ctrl.ChangeVote)
+		votes.POST("", policy.Authenticated(domain.ScopeVotesWrite), ctrl.Create)
+		votes.POST("/change", policy.Authenticated(domain.ScopeVotesWrite), ctrl.ChangeVote)
 		votes.GET("", ctrl.FindAll)
 		votes.GET("/entity/:entityType/:entityId", ctrl.FindByEntity)
 		votes.GET("/entity/:entityType/:entityId/count", ctrl.GetVoteCounts)
 		votes.GET("/user/:userId", ctrl.FindByUser)
 		votes.GET("/user/:userId/entity/:entityType/:entityId", ctrl.FindUserVoteOnEntity)
 		votes.GET("/:id", ctrl.FindOne)
-		votes.PATCH("/:id", ctrl.Update)
-		votes.DELETE("/:id", ctrl.Remove)
-		votes.DELETE("/user/:userId/entity/:entityType/:entityId", ctrl.RemoveUserVote)
+		votes.PATCH("/:id", policy.Authenticated(domain.ScopeVotesWrite), ctrl.Update)
+		votes.DELETE("/:id", policy.Authenticated(domain.ScopeVotesWrite), ctrl.Remove)
+		votes.DELETE("/user/:userId/entity/:entityType/:entityId", policy.Authenticated(domain.ScopeVotesWrite), ctrl.RemoveUserVote)
 	}
 }
 
@@ -46,6 +47,7 @@ func (ctrl *VoteController) RegisterRoutes(r *gin.RouterGroup) {
 // @Success 201 {object} domain.VoteResponseDTO
 // @Failure 400 {object} map[string]string
 // @Failure 409 {object} map[string]string
+// @Security BearerAuth
 // @Router /votes [post]
 func (ctrl *VoteController) Create(c *gin.Context) {
 	var dto domain.CreateVoteDTO
@@ -54,11 +56,22 @@ func (ctrl *VoteController) Create(c *gin.Context) {
 		return
 	}
 
+	actor, ok := middleware.GetActor(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	dto.UserID = actor.ID
+
 	vote, err := ctrl.service.Create(dto)
 	if err != nil {
-		if err.Error() == "user already voted on this entity" {
+		switch err.Error() {
+		case "user already voted on this entity":
 			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
 			return
+		case "comment votes are managed by the comment endpoints":
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
 		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -75,6 +88,8 @@ func (ctrl *VoteController) Create(c *gin.Context) {
 // @Produce json
 // @Param dto body domain.CreateVoteDTO true "Vote DTO"
 // @Success 200 {object} domain.VoteResponseDTO
+// @Failure 400 {object} map[string]string
+// @Security BearerAuth
 // @Router /votes/change [post]
 func (ctrl *VoteController) ChangeVote(c *gin.Context) {
 	var dto domain.CreateVoteDTO
@@ -83,8 +98,18 @@ func (ctrl *VoteController) ChangeVote(c *gin.Context) {
 		return
 	}
 
-	vote, err := ctrl.service.ChangeVote(dto.UserID, dto.EntityType, dto.EntityID, dto.VoteType)
+	actor, ok := middleware.GetActor(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	vote, err := ctrl.service.ChangeVote(actor.ID, dto.EntityType, dto.EntityID, dto.VoteType)
 	if err != nil {
+		if err.Error() == "comment votes are managed by the comment endpoints" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -279,7 +304,9 @@ func (ctrl *VoteController) FindUserVoteOnEntity(c *gin.Context) {
 // @Param dto body domain.UpdateVoteDTO true "Update Vote DTO"
 // @Success 200 {object} domain.VoteResponseDTO
 // @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
 // @Failure 404 {object} map[string]string
+// @Security BearerAuth
 // @Router /votes/{id} [patch]
 func (ctrl *VoteController) Update(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
@@ -294,11 +321,24 @@ func (ctrl *VoteController) Update(c *gin.Context) {
 		return
 	}
 
-	vote, err := ctrl.service.Update(uint(id), dto)
+	actor, ok := middleware.GetActor(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	vote, err := ctrl.service.Update(uint(id), dto, actor)
 	if err != nil {
-		if err.Error() == "vote not found" {
+		switch err.Error() {
+		case "vote not found":
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 			return
+		case "forbidden":
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		case "comment votes are managed by the comment endpoints":
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
 		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -314,7 +354,9 @@ func (ctrl *VoteController) Update(c *gin.Context) {
 // @Param id path int true "Vote ID"
 // @Success 200 {object} map[string]bool
 // @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
 // @Failure 404 {object} map[string]string
+// @Security BearerAuth
 // @Router /votes/{id} [delete]
 func (ctrl *VoteController) Remove(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
@@ -323,11 +365,24 @@ func (ctrl *VoteController) Remove(c *gin.Context) {
 		return
 	}
 
-	err = ctrl.service.Remove(uint(id))
+	actor, ok := middleware.GetActor(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	err = ctrl.service.Remove(uint(id), actor)
 	if err != nil {
-		if err.Error() == "vote not found" {
+		switch err.Error() {
+		case "vote not found":
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 			return
+		case "forbidden":
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		case "comment votes are managed by the comment endpoints":
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
 		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -345,7 +400,9 @@ func (ctrl *VoteController) Remove(c *gin.Context) {
 // @Param entityId path int true "Entity ID"
 // @Success 200 {object} map[string]bool
 // @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
 // @Failure 404 {object} map[string]string
+// @Security BearerAuth
 // @Router /votes/user/{userId}/entity/{entityType}/{entityId} [delete]
 func (ctrl *VoteController) RemoveUserVote(c *gin.Context) {
 	userID, err := strconv.ParseUint(c.Param("userId"), 10, 32)
@@ -361,11 +418,25 @@ func (ctrl *VoteController) RemoveUserVote(c *gin.Context) {
 		return
 	}
 
+	actor, ok := middleware.GetActor(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	if !actor.CanModify(uint(userID)) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return
+	}
+
 	err = ctrl.service.RemoveUserVote(uint(userID), entityType, entityID)
 	if err != nil {
-		if err.Error() == "vote not found" {
+		switch err.Error() {
+		case "vote not found":
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 			return
+		case "comment votes are managed by the comment endpoints":
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
 		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -107,3 +107,17 @@ func (r *voteRepository) CountByEntity(entityType domain.EntityType, entityID in
 		Count(&count).Error
 	return count, err
 }
+
+// CountReceivedByAuthor counts votes of a type on the tutorials and videos authored by an account
+func (r *voteRepository) CountReceivedByAuthor(authorID uint, voteType domain.VoteType) (int64, error) {
+	var count int64
+	err := r.db.Model(&domain.Vote{}).
+		Where("vote_type = ?", voteType).
+		Where(r.db.
+			Where("entity_type = ? AND entity_id IN (?)", domain.EntityTypeTutorial,
+				r.db.Model(&domain.Tutorial{}).Select("id").Where("author_id = ?", authorID)).
+			Or("entity_type = ? AND entity_id IN (?)", domain.EntityTypeVideo,
+				r.db.Model(&domain.Video{}).Select("id").Where("uploader_id = ?", authorID))).
+		Count(&count).Error
+	return count, err
+}
@@ -39,8 +39,21 @@ func toResponseDTOList(votes []domain.Vote) []domain.VoteResponseDTO {
 	return result
 }
 
+// checkEntityType keeps comment votes out of the generic endpoints; the comment score is only
+// kept in sync by the comment module's upvote/downvote routes
+func checkEntityType(entityType domain.EntityType) error {
+	if entityType == domain.EntityTypeComment {
+		return errors.New("comment votes are managed by the comment endpoints")
+	}
+	return nil
+}
+
 // Create creates a new vote
 func (s *voteService) Create(dto domain.CreateVoteDTO) (*domain.VoteResponseDTO, error) {
+	if err := checkEntityType(dto.EntityType); err != nil {
+		return nil, err
+	}
+
 	// Check if user already voted on this entity
 	existing, err := s.repo.FindByUserAndEntity(dto.UserID, dto.EntityType, dto.EntityID)
 	if err != nil {
@@ -92,7 +105,7 @@ func (s *voteService) FindOne(id uint) (*domain.VoteResponseDTO, error) {
 }
 
 // Update updates a vote
-func (s *voteService) Update(id uint, dto domain.UpdateVoteDTO) (*domain.VoteResponseDTO, error) {
+func (s *voteService) Update(id uint, dto domain.UpdateVoteDTO, actor domain.Actor) (*domain.VoteResponseDTO, error) {
 	existing, err := s.repo.FindOne(id)
 	if err != nil {
 		return nil, err
@@ -100,6 +113,13 @@ func (s *voteService) Update(id uint, dto domain.UpdateVoteDTO) (*domain.VoteRes
 	if existing == nil {
 		return nil, errors.New("vote not found")
 	}
+	// Only the voter can change a vote
+	if existing.UserID != actor.ID {
+		return nil, errors.New("forbidden")
+	}
+	if err := checkEntityType(existing.EntityType); err != nil {
+		return nil, err
+	}
 
 	update := &domain.Vote{}
 	if dto.VoteType != nil {
@@ -119,7 +139,7 @@ func (s *voteService) Update(id uint, dto domain.UpdateVoteDTO) (*domain.VoteRes
 }
 
 // Remove deletes a vote
-func (s *voteService) Remove(id uint) error {
+func (s *voteService) Remove(id uint, actor domain.Actor) error {
 	existing, err := s.repo.FindOne(id)
 	if err != nil {
 		return err
@@ -127,6 +147,12 @@ func (s *voteService) Remove(id uint) error {
 	if existing == nil {
 		return errors.New("vote not found")
 	}
+	if !actor.CanModify(existing.UserID) {
+		return errors.New("forbidden")
+	}
+	if err := checkEntityType(existing.EntityType); err != nil {
+		return err
+	}
 	return s.repo.Delete(id)
 }
 
@@ -175,6 +201,10 @@ func (s *voteService) GetVoteCounts(entityType domain.EntityType, entityID int64
 
 // ChangeVote creates, removes, or changes vote based on current state
 func (s *voteService) ChangeVote(userID uint, entityType domain.EntityType, entityID int64, voteType domain.VoteType) (*domain.VoteResponseDTO, error) {
+	if err := checkEntityType(entityType); err != nil {
+		return nil, err
+	}
+
 	existing, err := s.repo.FindByUserAndEntity(userID, entityType, entityID)
 	if err != nil {
 		return nil, err
@@ -214,6 +244,10 @@ func (s *voteService) ChangeVote(userID uint, entityType domain.EntityType, enti
 
 // RemoveUserVote removes a user's vote on an entity
 func (s *voteService) RemoveUserVote(userID uint, entityType domain.EntityType, entityID int64) error {
+	if err := checkEntityType(entityType); err != nil {
+		return err
+	}
+
 	vote, err := s.repo.FindByUserAndEntity(userID, entityType, entityID)
 	if err != nil {
 		return err
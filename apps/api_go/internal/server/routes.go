@@ -1,6 +1,7 @@
 package server
 
 import (
+	"log"
 	"net/http"
 	"time"
 
@@ -20,6 +21,11 @@ func (s *Server) RegisterRoutes() http.Handler {
 
 	r := gin.Default()
 
+	// ClientIP (login throttling, view counting) only believes X-Forwarded-For from these proxies
+	if err := r.SetTrustedProxies(s.config.TrustedProxies); err != nil {
+		log.Fatalf("invalid trusted proxies: %v", err)
+	}
+
 	// Configure CORS based on environment
 	var allowOrigins []string
 	if s.config.IsDevelopment() {
@@ -59,16 +65,20 @@ func (s *Server) RegisterRoutes() http.Handler {
 	api := r.Group("")
 
 	// Register auth routes
-	s.authController.RegisterRoutes(api)
-
-	// Register module routes
-	s.accountController.RegisterRoutes(api)
-	s.tagController.RegisterRoutes(api)
-	s.tutorialController.RegisterRoutes(api)
-	s.videoController.RegisterRoutes(api)
-	s.videoTagController.RegisterRoutes(api)
-	s.commentController.RegisterRoutes(api)
-	s.voteController.RegisterRoutes(api)
+	s.authController.RegisterRoutes(api, s.routePolicy)
+
+	// Register module routes (each route declares its own auth policy)
+	s.accountController.RegisterRoutes(api, s.routePolicy)
+	s.tagController.RegisterRoutes(api, s.routePolicy)
+	s.tutorialController.RegisterRoutes(api, s.routePolicy)
+	s.tutorialTagController.RegisterRoutes(api, s.routePolicy)
+	s.seriesController.RegisterRoutes(api, s.routePolicy)
+	s.videoController.RegisterRoutes(api, s.routePolicy)
+	s.videoTagController.RegisterRoutes(api, s.routePolicy)
+	s.commentController.RegisterRoutes(api, s.routePolicy)
+	s.voteController.RegisterRoutes(api, s.routePolicy)
+	s.authorController.RegisterRoutes(api, s.routePolicy)
+	s.mediaController.RegisterRoutes(api, s.routePolicy)
 
 	return r
 }
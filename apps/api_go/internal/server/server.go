@@ -8,47 +8,67 @@ import (
 	"api_go/internal/config"
 	account_controller "api_go/internal/modules/account/controller"
 	auth_controller "api_go/internal/modules/auth/controller"
+	"api_go/internal/modules/auth/middleware"
+	author_controller "api_go/internal/modules/author/controller"
 	comment_controller "api_go/internal/modules/comment/controller"
+	media_controller "api_go/internal/modules/media/controller"
+	series_controller "api_go/internal/modules/series/controller"
 	tag_controller "api_go/internal/modules/tag/controller"
 	tutorial_controller "api_go/internal/modules/tutorial/controller"
+	tutorial_tag_controller "api_go/internal/modules/tutorial_tag/controller"
 	video_controller "api_go/internal/modules/video/controller"
 	video_tag_controller "api_go/internal/modules/video_tag/controller"
 	vote_controller "api_go/internal/modules/vote/controller"
 )
 
 type Server struct {
-	config             *config.Config
-	accountController  *account_controller.AccountController
-	authController     *auth_controller.AuthController
-	tagController      *tag_controller.TagController
-	tutorialController *tutorial_controller.TutorialController
-	videoController    *video_controller.VideoController
-	videoTagController *video_tag_controller.VideoTagController
-	commentController  *comment_controller.CommentController
-	voteController     *vote_controller.VoteController
+	config                *config.Config
+	routePolicy           *middleware.RoutePolicy
+	accountController     *account_controller.AccountController
+	authController        *auth_controller.AuthController
+	tagController         *tag_controller.TagController
+	tutorialController    *tutorial_controller.TutorialController
+	tutorialTagController *tutorial_tag_controller.TutorialTagController
+	seriesController      *series_controller.SeriesController
+	videoController       *video_controller.VideoController
+	videoTagController    *video_tag_controller.VideoTagController
+	commentController     *comment_controller.CommentController
+	voteController        *vote_controller.VoteController
+	authorController      *author_controller.AuthorController
+	mediaController       *media_controller.MediaController
 }
 
 func NewServer(
 	cfg *config.Config,
+	routePolicy *middleware.RoutePolicy,
 	accountCtrl *account_controller.AccountController,
 	authCtrl *auth_controller.AuthController,
 	tagCtrl *tag_controller.TagController,
 	tutorialCtrl *tutorial_controller.TutorialController,
+	tutorialTagCtrl *tutorial_tag_controller.TutorialTagController,
+	seriesCtrl *series_controller.SeriesController,
 	videoCtrl *video_controller.VideoController,
 	videoTagCtrl *video_tag_controller.VideoTagController,
 	commentCtrl *comment_controller.CommentController,
 	voteCtrl *vote_controller.VoteController,
+	authorCtrl *author_controller.AuthorController,
+	mediaCtrl *media_controller.MediaController,
 ) *http.Server {
 	s := &Server{
-		config:             cfg,
-		accountController:  accountCtrl,
-		authController:     authCtrl,
-		tagController:      tagCtrl,
-		tutorialController: tutorialCtrl,
-		videoController:    videoCtrl,
-		videoTagController: videoTagCtrl,
-		commentController:  commentCtrl,
-		voteController:     voteCtrl,
+		config:                cfg,
+		routePolicy:           routePolicy,
+		accountController:     accountCtrl,
+		authController:        authCtrl,
+		tagController:         tagCtrl,
+		tutorialController:    tutorialCtrl,
+		tutorialTagController: tutorialTagCtrl,
+		seriesController:      seriesCtrl,
+		videoController:       videoCtrl,
+		videoTagController:    videoTagCtrl,
+		commentController:     commentCtrl,
+		voteController:        voteCtrl,
+		authorController:      authorCtrl,
+		mediaController:       mediaCtrl,
 	}
 
 	// Declare Server config
@@ -0,0 +1,40 @@
+// Package slug turns free text into URL-friendly identifiers.
+package slug
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+var (
+	invalidChars = regexp.MustCompile(`[^a-z0-9\-]`)
+	hyphens      = regexp.MustCompile(`-+`)
+)
+
+// Make folds diacritics, lowercases and keeps only a-z, 0-9 and single hyphens ("Xin chào Go!" -> "xin-chao-go")
+func Make(text string) string {
+	// Normalize unicode
+	s := norm.NFD.String(text)
+	// Remove diacritics
+	var result strings.Builder
+	for _, r := range s {
+		if unicode.Is(unicode.Mn, r) {
+			continue
+		}
+		result.WriteRune(r)
+	}
+	slug := result.String()
+	// To lowercase
+	slug = strings.ToLower(slug)
+	// Replace spaces with hyphens
+	slug = strings.ReplaceAll(slug, " ", "-")
+	// Remove special characters
+	slug = invalidChars.ReplaceAllString(slug, "")
+	// Remove multiple hyphens
+	slug = hyphens.ReplaceAllString(slug, "-")
+	// Trim hyphens
+	return strings.Trim(slug, "-")
+}
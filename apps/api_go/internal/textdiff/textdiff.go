@@ -0,0 +1,173 @@
+// Package textdiff computes line-level differences between two texts.
+package textdiff
+
+import "strings"
+
+// Op is the kind of change a line represents
+type Op string
+
+const (
+	Equal  Op = "equal"
+	Insert Op = "insert"
+	Delete Op = "delete"
+)
+
+// maxEdits bounds the work (and memory) of the search; texts that differ more are
+// reported as a plain replacement of their differing middle part
+const maxEdits = 1000
+
+// Line is one line of the diff. OldLine and NewLine are 1-based, 0 when the line
+// does not exist on that side.
+type Line struct {
+	Op      Op
+	Text    string
+	OldLine int
+	NewLine int
+}
+
+// Lines returns the shortest edit script turning from into to (Myers' algorithm)
+func Lines(from, to string) []Line {
+	a, b := split(from), split(to)
+
+	// Common prefix and suffix are cheap and keep the search small
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	result := make([]Line, 0, len(a)+len(b)-prefix-suffix)
+	for i := 0; i < prefix; i++ {
+		result = append(result, Line{Op: Equal, Text: a[i], OldLine: i + 1, NewLine: i + 1})
+	}
+
+	middle := diff(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])
+	for _, line := range middle {
+		if line.OldLine > 0 {
+			line.OldLine += prefix
+		}
+		if line.NewLine > 0 {
+			line.NewLine += prefix
+		}
+		result = append(result, line)
+	}
+
+	for i := 0; i < suffix; i++ {
+		oldIndex, newIndex := len(a)-suffix+i, len(b)-suffix+i
+		result = append(result, Line{Op: Equal, Text: a[oldIndex], OldLine: oldIndex + 1, NewLine: newIndex + 1})
+	}
+	return result
+}
+
+// split breaks text into lines, ignoring a trailing newline and CRLF line endings
+func split(text string) []string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = strings.TrimSuffix(text, "\n")
+	if text == "" {
+		return nil
+	}
+	return strings.Split(text, "\n")
+}
+
+// diff runs the forward search keeping one snapshot of the furthest reaching paths per
+// edit count, then walks the snapshots back to recover the edit script
+func diff(a, b []string) []Line {
+	n, m := len(a), len(b)
+	limit := n + m
+	if limit > maxEdits {
+		limit = maxEdits
+	}
+
+	offset := limit + 1
+	v := make([]int, 2*offset+1)
+	// trace[d] holds v for diagonals -(d-1)..d-1 as it was before step d
+	var trace [][]int
+
+	for d := 0; d <= limit; d++ {
+		var snapshot []int
+		if d > 0 {
+			snapshot = append(snapshot, v[offset-d+1:offset+d]...)
+		}
+		trace = append(trace, snapshot)
+
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1] // move down: insert
+			} else {
+				x = v[offset+k-1] + 1 // move right: delete
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+
+			if x >= n && y >= m {
+				return backtrack(a, b, trace)
+			}
+		}
+	}
+
+	return replace(a, b)
+}
+
+func backtrack(a, b []string, trace [][]int) []Line {
+	x, y := len(a), len(b)
+	var reversed []Line
+
+	for d := len(trace) - 1; d > 0; d-- {
+		snapshot := trace[d]
+		at := func(k int) int { return snapshot[k+d-1] }
+
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && at(k-1) < at(k+1)) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := at(prevK)
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			reversed = append(reversed, Line{Op: Equal, Text: a[x-1], OldLine: x, NewLine: y})
+			x--
+			y--
+		}
+		if x == prevX {
+			reversed = append(reversed, Line{Op: Insert, Text: b[y-1], NewLine: y})
+			y--
+		} else {
+			reversed = append(reversed, Line{Op: Delete, Text: a[x-1], OldLine: x})
+			x--
+		}
+	}
+	for x > 0 && y > 0 {
+		reversed = append(reversed, Line{Op: Equal, Text: a[x-1], OldLine: x, NewLine: y})
+		x--
+		y--
+	}
+
+	result := make([]Line, len(reversed))
+	for i, line := range reversed {
+		result[len(reversed)-1-i] = line
+	}
+	return result
+}
+
+// replace reports every line of a as deleted and every line of b as inserted
+func replace(a, b []string) []Line {
+	result := make([]Line, 0, len(a)+len(b))
+	for i, text := range a {
+		result = append(result, Line{Op: Delete, Text: text, OldLine: i + 1})
+	}
+	for i, text := range b {
+		result = append(result, Line{Op: Insert, Text: text, NewLine: i + 1})
+	}
+	return result
+}
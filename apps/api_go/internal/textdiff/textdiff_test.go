@@ -0,0 +1,104 @@
+package textdiff
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestLines(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to string
+		want     []Line
+	}{
+		{"both empty", "", "", []Line{}},
+		{"identical", "a\nb\n", "a\nb\n", []Line{
+			{Op: Equal, Text: "a", OldLine: 1, NewLine: 1},
+			{Op: Equal, Text: "b", OldLine: 2, NewLine: 2},
+		}},
+		{"empty old", "", "a\nb", []Line{
+			{Op: Insert, Text: "a", NewLine: 1},
+			{Op: Insert, Text: "b", NewLine: 2},
+		}},
+		{"empty new", "a\nb", "", []Line{
+			{Op: Delete, Text: "a", OldLine: 1},
+			{Op: Delete, Text: "b", OldLine: 2},
+		}},
+		{"insert only", "a\nc", "a\nb\nc", []Line{
+			{Op: Equal, Text: "a", OldLine: 1, NewLine: 1},
+			{Op: Insert, Text: "b", NewLine: 2},
+			{Op: Equal, Text: "c", OldLine: 2, NewLine: 3},
+		}},
+		{"delete only", "a\nb\nc", "a\nc", []Line{
+			{Op: Equal, Text: "a", OldLine: 1, NewLine: 1},
+			{Op: Delete, Text: "b", OldLine: 2},
+			{Op: Equal, Text: "c", OldLine: 3, NewLine: 2},
+		}},
+		{"changed line", "a\nx\nc", "a\ny\nc", []Line{
+			{Op: Equal, Text: "a", OldLine: 1, NewLine: 1},
+			{Op: Delete, Text: "x", OldLine: 2},
+			{Op: Insert, Text: "y", NewLine: 2},
+			{Op: Equal, Text: "c", OldLine: 3, NewLine: 3},
+		}},
+		{"scattered edits", "a\nb\nc\nd\ne", "b\nc\nx\nd", []Line{
+			{Op: Delete, Text: "a", OldLine: 1},
+			{Op: Equal, Text: "b", OldLine: 2, NewLine: 1},
+			{Op: Equal, Text: "c", OldLine: 3, NewLine: 2},
+			{Op: Insert, Text: "x", NewLine: 3},
+			{Op: Equal, Text: "d", OldLine: 4, NewLine: 4},
+			{Op: Delete, Text: "e", OldLine: 5},
+		}},
+		{"CRLF against LF", "a\r\nb\r\n", "a\nb\n", []Line{
+			{Op: Equal, Text: "a", OldLine: 1, NewLine: 1},
+			{Op: Equal, Text: "b", OldLine: 2, NewLine: 2},
+		}},
+		{"trailing newline", "a\nb", "a\nb\n", []Line{
+			{Op: Equal, Text: "a", OldLine: 1, NewLine: 1},
+			{Op: Equal, Text: "b", OldLine: 2, NewLine: 2},
+		}},
+		{"blank line kept", "a\n\nb", "a\nb", []Line{
+			{Op: Equal, Text: "a", OldLine: 1, NewLine: 1},
+			{Op: Delete, Text: "", OldLine: 2},
+			{Op: Equal, Text: "b", OldLine: 3, NewLine: 2},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Lines(tt.from, tt.to); !slices.Equal(got, tt.want) {
+				t.Errorf("Lines(%q, %q) = %+v, want %+v", tt.from, tt.to, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLinesFallsBackToReplaceBeyondMaxEdits(t *testing.T) {
+	// 600 lines replaced by 600 others need 1200 edits, more than maxEdits
+	var from, to []string
+	for i := 0; i < 600; i++ {
+		from = append(from, fmt.Sprintf("old %d", i))
+		to = append(to, fmt.Sprintf("new %d", i))
+	}
+	from = append(append([]string{"head"}, from...), "tail")
+	to = append(append([]string{"head"}, to...), "tail")
+
+	got := Lines(strings.Join(from, "\n"), strings.Join(to, "\n"))
+	if len(got) != 1202 {
+		t.Fatalf("got %d lines, want 1202", len(got))
+	}
+	if want := (Line{Op: Equal, Text: "head", OldLine: 1, NewLine: 1}); got[0] != want {
+		t.Errorf("first line = %+v, want %+v", got[0], want)
+	}
+	for i := 0; i < 600; i++ {
+		if want := (Line{Op: Delete, Text: from[i+1], OldLine: i + 2}); got[1+i] != want {
+			t.Fatalf("line %d = %+v, want %+v", 1+i, got[1+i], want)
+		}
+		if want := (Line{Op: Insert, Text: to[i+1], NewLine: i + 2}); got[601+i] != want {
+			t.Fatalf("line %d = %+v, want %+v", 601+i, got[601+i], want)
+		}
+	}
+	if want := (Line{Op: Equal, Text: "tail", OldLine: 602, NewLine: 602}); got[1201] != want {
+		t.Errorf("last line = %+v, want %+v", got[1201], want)
+	}
+}